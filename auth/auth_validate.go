@@ -0,0 +1,1334 @@
+package auth
+
+// Code generated by generate/validators.go. DO NOT EDIT.
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// FieldViolation is a field of a message that breaks one of its rules.
+type FieldViolation struct {
+	// Field is the path to the field, such as "user.email".
+	Field       string
+	Description string
+}
+
+// ValidationError is returned by Validate, ValidatePartial and ValidateMask,
+// listing every rule the message breaks.
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.Field + ": " + v.Description
+	}
+	return "invalid request: " + strings.Join(msgs, "; ")
+}
+
+// validator collects the violations of a message and its submessages.
+type validator struct {
+	partial bool
+	// masked are the paths of the fields a partial validation still requires
+	masked     map[string]bool
+	violations []FieldViolation
+}
+
+// required reports whether the required rule of field applies.
+func (v *validator) required(field string) bool {
+	return !v.partial || v.masked[field]
+}
+
+func (v *validator) add(field, format string, args ...interface{}) {
+	v.violations = append(v.violations, FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: v.violations}
+}
+
+// validEmail reports whether s is a bare email address.
+func validEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
+
+// Compiled pattern rules.
+var (
+	permissionPermissionNamePattern = regexp.MustCompile("^[a-z][a-z0-9_.:-]*$")
+	roleRoleNamePattern             = regexp.MustCompile("^[A-Za-z0-9_.-]+$")
+	userUsernamePattern             = regexp.MustCompile("^[A-Za-z0-9_.-]+$")
+)
+
+// Validate checks the [User] against the rules declared in proto/auth.proto.
+func (x *User) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [User] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *User) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [User] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *User) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *User) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	if !v.partial && x.GetUserId() != 0 {
+		v.add(prefix+"user_id", "is output only")
+	}
+	if v.required(prefix+"username") && x.GetUsername() == "" {
+		v.add(prefix+"username", "is required")
+	}
+	if s := x.GetUsername(); s != "" {
+		if utf8.RuneCountInString(s) < 3 {
+			v.add(prefix+"username", "must be at least 3 characters")
+		}
+		if utf8.RuneCountInString(s) > 64 {
+			v.add(prefix+"username", "must be at most 64 characters")
+		}
+		if !userUsernamePattern.MatchString(s) {
+			v.add(prefix+"username", "must match %s", userUsernamePattern)
+		}
+	}
+	if v.required(prefix+"email") && x.GetEmail() == "" {
+		v.add(prefix+"email", "is required")
+	}
+	if s := x.GetEmail(); s != "" {
+		if utf8.RuneCountInString(s) > 255 {
+			v.add(prefix+"email", "must be at most 255 characters")
+		}
+		if !validEmail(s) {
+			v.add(prefix+"email", "must be an email address")
+		}
+	}
+	if !v.partial && x.GetCreatedAt() != nil {
+		v.add(prefix+"created_at", "is output only")
+	}
+	if !v.partial && x.GetUpdatedAt() != nil {
+		v.add(prefix+"updated_at", "is output only")
+	}
+	if !v.partial && x.GetPasswordHash() != "" {
+		v.add(prefix+"password_hash", "is output only")
+	}
+	if !v.partial && x.GetDeletedAt() != nil {
+		v.add(prefix+"deleted_at", "is output only")
+	}
+	if !v.partial && x.GetVersion() != 0 {
+		v.add(prefix+"version", "is output only")
+	}
+}
+
+// Validate checks the [Role] against the rules declared in proto/auth.proto.
+func (x *Role) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [Role] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *Role) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [Role] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *Role) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *Role) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	if !v.partial && x.GetRoleId() != 0 {
+		v.add(prefix+"role_id", "is output only")
+	}
+	if v.required(prefix+"role_name") && x.GetRoleName() == "" {
+		v.add(prefix+"role_name", "is required")
+	}
+	if s := x.GetRoleName(); s != "" {
+		if utf8.RuneCountInString(s) > 64 {
+			v.add(prefix+"role_name", "must be at most 64 characters")
+		}
+		if !roleRoleNamePattern.MatchString(s) {
+			v.add(prefix+"role_name", "must match %s", roleRoleNamePattern)
+		}
+	}
+	if !v.partial && x.GetCreatedAt() != nil {
+		v.add(prefix+"created_at", "is output only")
+	}
+	if !v.partial && x.GetUpdatedAt() != nil {
+		v.add(prefix+"updated_at", "is output only")
+	}
+	if !v.partial && x.GetDeletedAt() != nil {
+		v.add(prefix+"deleted_at", "is output only")
+	}
+	if !v.partial && x.GetVersion() != 0 {
+		v.add(prefix+"version", "is output only")
+	}
+}
+
+// Validate checks the [UserRole] against the rules declared in proto/auth.proto.
+func (x *UserRole) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [UserRole] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *UserRole) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [UserRole] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *UserRole) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *UserRole) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	if v.required(prefix+"user_id") && x.GetUserId() == 0 {
+		v.add(prefix+"user_id", "is required")
+	}
+	if v.required(prefix+"role_id") && x.GetRoleId() == 0 {
+		v.add(prefix+"role_id", "is required")
+	}
+	if !v.partial && x.GetAssignedAt() != nil {
+		v.add(prefix+"assigned_at", "is output only")
+	}
+}
+
+// Validate checks the [Permission] against the rules declared in proto/auth.proto.
+func (x *Permission) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [Permission] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *Permission) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [Permission] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *Permission) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *Permission) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	if !v.partial && x.GetPermissionId() != 0 {
+		v.add(prefix+"permission_id", "is output only")
+	}
+	if v.required(prefix+"permission_name") && x.GetPermissionName() == "" {
+		v.add(prefix+"permission_name", "is required")
+	}
+	if s := x.GetPermissionName(); s != "" {
+		if utf8.RuneCountInString(s) > 128 {
+			v.add(prefix+"permission_name", "must be at most 128 characters")
+		}
+		if !permissionPermissionNamePattern.MatchString(s) {
+			v.add(prefix+"permission_name", "must match %s", permissionPermissionNamePattern)
+		}
+	}
+	if !v.partial && x.GetCreatedAt() != nil {
+		v.add(prefix+"created_at", "is output only")
+	}
+}
+
+// Validate checks the [RolePermission] against the rules declared in proto/auth.proto.
+func (x *RolePermission) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [RolePermission] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *RolePermission) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [RolePermission] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *RolePermission) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *RolePermission) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	if v.required(prefix+"role_id") && x.GetRoleId() == 0 {
+		v.add(prefix+"role_id", "is required")
+	}
+	if v.required(prefix+"permission_id") && x.GetPermissionId() == 0 {
+		v.add(prefix+"permission_id", "is required")
+	}
+	if !v.partial && x.GetGrantedAt() != nil {
+		v.add(prefix+"granted_at", "is output only")
+	}
+}
+
+// Validate checks the [RefreshToken] against the rules declared in proto/auth.proto.
+func (x *RefreshToken) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [RefreshToken] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *RefreshToken) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [RefreshToken] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *RefreshToken) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *RefreshToken) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+}
+
+// Validate checks the [AuditEvent] against the rules declared in proto/auth.proto.
+func (x *AuditEvent) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [AuditEvent] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *AuditEvent) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [AuditEvent] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *AuditEvent) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *AuditEvent) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+}
+
+// Validate checks the [CreateUserRequest] against the rules declared in proto/auth.proto.
+func (x *CreateUserRequest) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [CreateUserRequest] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *CreateUserRequest) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [CreateUserRequest] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *CreateUserRequest) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *CreateUserRequest) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	if v.required(prefix+"user") && x.GetUser() == nil {
+		v.add(prefix+"user", "is required")
+	}
+	x.GetUser().validate(v, prefix+"user"+".")
+	if v.required(prefix+"password") && x.GetPassword() == "" {
+		v.add(prefix+"password", "is required")
+	}
+}
+
+// Validate checks the [LoginRequest] against the rules declared in proto/auth.proto.
+func (x *LoginRequest) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [LoginRequest] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *LoginRequest) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [LoginRequest] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *LoginRequest) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *LoginRequest) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	if v.required(prefix+"username") && x.GetUsername() == "" {
+		v.add(prefix+"username", "is required")
+	}
+	if v.required(prefix+"password") && x.GetPassword() == "" {
+		v.add(prefix+"password", "is required")
+	}
+}
+
+// Validate checks the [LoginResponse] against the rules declared in proto/auth.proto.
+func (x *LoginResponse) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [LoginResponse] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *LoginResponse) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [LoginResponse] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *LoginResponse) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *LoginResponse) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	x.GetUser().validate(v, prefix+"user"+".")
+}
+
+// Validate checks the [RefreshAccessTokenRequest] against the rules declared in proto/auth.proto.
+func (x *RefreshAccessTokenRequest) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [RefreshAccessTokenRequest] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *RefreshAccessTokenRequest) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [RefreshAccessTokenRequest] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *RefreshAccessTokenRequest) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *RefreshAccessTokenRequest) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	if v.required(prefix+"refresh_token") && x.GetRefreshToken() == "" {
+		v.add(prefix+"refresh_token", "is required")
+	}
+}
+
+// Validate checks the [RevokeRefreshTokenRequest] against the rules declared in proto/auth.proto.
+func (x *RevokeRefreshTokenRequest) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [RevokeRefreshTokenRequest] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *RevokeRefreshTokenRequest) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [RevokeRefreshTokenRequest] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *RevokeRefreshTokenRequest) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *RevokeRefreshTokenRequest) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	if v.required(prefix+"refresh_token") && x.GetRefreshToken() == "" {
+		v.add(prefix+"refresh_token", "is required")
+	}
+}
+
+// Validate checks the [RevokeRefreshTokenResponse] against the rules declared in proto/auth.proto.
+func (x *RevokeRefreshTokenResponse) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [RevokeRefreshTokenResponse] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *RevokeRefreshTokenResponse) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [RevokeRefreshTokenResponse] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *RevokeRefreshTokenResponse) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *RevokeRefreshTokenResponse) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+}
+
+// Validate checks the [ValidateTokenRequest] against the rules declared in proto/auth.proto.
+func (x *ValidateTokenRequest) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [ValidateTokenRequest] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *ValidateTokenRequest) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [ValidateTokenRequest] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *ValidateTokenRequest) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *ValidateTokenRequest) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	if v.required(prefix+"access_token") && x.GetAccessToken() == "" {
+		v.add(prefix+"access_token", "is required")
+	}
+}
+
+// Validate checks the [ValidateTokenResponse] against the rules declared in proto/auth.proto.
+func (x *ValidateTokenResponse) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [ValidateTokenResponse] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *ValidateTokenResponse) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [ValidateTokenResponse] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *ValidateTokenResponse) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *ValidateTokenResponse) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+}
+
+// Validate checks the [CheckPermissionRequest] against the rules declared in proto/auth.proto.
+func (x *CheckPermissionRequest) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [CheckPermissionRequest] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *CheckPermissionRequest) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [CheckPermissionRequest] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *CheckPermissionRequest) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *CheckPermissionRequest) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	if v.required(prefix+"user_id") && x.GetUserId() == 0 {
+		v.add(prefix+"user_id", "is required")
+	}
+	if v.required(prefix+"permission") && x.GetPermission() == "" {
+		v.add(prefix+"permission", "is required")
+	}
+}
+
+// Validate checks the [CheckPermissionResponse] against the rules declared in proto/auth.proto.
+func (x *CheckPermissionResponse) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [CheckPermissionResponse] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *CheckPermissionResponse) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [CheckPermissionResponse] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *CheckPermissionResponse) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *CheckPermissionResponse) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+}
+
+// Validate checks the [ChangePasswordRequest] against the rules declared in proto/auth.proto.
+func (x *ChangePasswordRequest) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [ChangePasswordRequest] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *ChangePasswordRequest) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [ChangePasswordRequest] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *ChangePasswordRequest) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *ChangePasswordRequest) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	if v.required(prefix+"user_id") && x.GetUserId() == 0 {
+		v.add(prefix+"user_id", "is required")
+	}
+	if v.required(prefix+"current_password") && x.GetCurrentPassword() == "" {
+		v.add(prefix+"current_password", "is required")
+	}
+	if v.required(prefix+"new_password") && x.GetNewPassword() == "" {
+		v.add(prefix+"new_password", "is required")
+	}
+}
+
+// Validate checks the [GetUserRequest] against the rules declared in proto/auth.proto.
+func (x *GetUserRequest) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [GetUserRequest] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *GetUserRequest) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [GetUserRequest] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *GetUserRequest) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *GetUserRequest) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	if v.required(prefix+"user_id") && x.GetUserId() == 0 {
+		v.add(prefix+"user_id", "is required")
+	}
+}
+
+// Validate checks the [GetRoleRequest] against the rules declared in proto/auth.proto.
+func (x *GetRoleRequest) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [GetRoleRequest] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *GetRoleRequest) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [GetRoleRequest] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *GetRoleRequest) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *GetRoleRequest) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	if v.required(prefix+"role_id") && x.GetRoleId() == 0 {
+		v.add(prefix+"role_id", "is required")
+	}
+}
+
+// Validate checks the [UndeleteUserRequest] against the rules declared in proto/auth.proto.
+func (x *UndeleteUserRequest) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [UndeleteUserRequest] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *UndeleteUserRequest) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [UndeleteUserRequest] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *UndeleteUserRequest) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *UndeleteUserRequest) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	if v.required(prefix+"user_id") && x.GetUserId() == 0 {
+		v.add(prefix+"user_id", "is required")
+	}
+}
+
+// Validate checks the [UndeleteRoleRequest] against the rules declared in proto/auth.proto.
+func (x *UndeleteRoleRequest) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [UndeleteRoleRequest] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *UndeleteRoleRequest) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [UndeleteRoleRequest] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *UndeleteRoleRequest) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *UndeleteRoleRequest) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	if v.required(prefix+"role_id") && x.GetRoleId() == 0 {
+		v.add(prefix+"role_id", "is required")
+	}
+}
+
+// Validate checks the [UpdateUserRequest] against the rules declared in proto/auth.proto.
+func (x *UpdateUserRequest) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [UpdateUserRequest] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *UpdateUserRequest) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [UpdateUserRequest] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *UpdateUserRequest) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *UpdateUserRequest) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	x.GetUser().validate(v, prefix+"user"+".")
+}
+
+// Validate checks the [UpdateRoleRequest] against the rules declared in proto/auth.proto.
+func (x *UpdateRoleRequest) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [UpdateRoleRequest] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *UpdateRoleRequest) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [UpdateRoleRequest] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *UpdateRoleRequest) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *UpdateRoleRequest) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	x.GetRole().validate(v, prefix+"role"+".")
+}
+
+// Validate checks the [BatchAssignRolesRequest] against the rules declared in proto/auth.proto.
+func (x *BatchAssignRolesRequest) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [BatchAssignRolesRequest] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *BatchAssignRolesRequest) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [BatchAssignRolesRequest] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *BatchAssignRolesRequest) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *BatchAssignRolesRequest) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	if v.required(prefix+"assignments") && len(x.GetAssignments()) == 0 {
+		v.add(prefix+"assignments", "is required")
+	}
+	for i, m := range x.GetAssignments() {
+		m.validate(v, fmt.Sprintf("%sassignments[%d].", prefix, i))
+	}
+}
+
+// Validate checks the [BatchAssignRolesResponse] against the rules declared in proto/auth.proto.
+func (x *BatchAssignRolesResponse) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [BatchAssignRolesResponse] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *BatchAssignRolesResponse) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [BatchAssignRolesResponse] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *BatchAssignRolesResponse) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *BatchAssignRolesResponse) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	for i, m := range x.GetAssignments() {
+		m.validate(v, fmt.Sprintf("%sassignments[%d].", prefix, i))
+	}
+}
+
+// Validate checks the [ListUsersRequest] against the rules declared in proto/auth.proto.
+func (x *ListUsersRequest) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [ListUsersRequest] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *ListUsersRequest) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [ListUsersRequest] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *ListUsersRequest) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *ListUsersRequest) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+}
+
+// Validate checks the [ListUsersResponse] against the rules declared in proto/auth.proto.
+func (x *ListUsersResponse) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [ListUsersResponse] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *ListUsersResponse) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [ListUsersResponse] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *ListUsersResponse) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *ListUsersResponse) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	for i, m := range x.GetUsers() {
+		m.validate(v, fmt.Sprintf("%susers[%d].", prefix, i))
+	}
+}
+
+// Validate checks the [ListRolesRequest] against the rules declared in proto/auth.proto.
+func (x *ListRolesRequest) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [ListRolesRequest] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *ListRolesRequest) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [ListRolesRequest] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *ListRolesRequest) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *ListRolesRequest) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+}
+
+// Validate checks the [ListRolesResponse] against the rules declared in proto/auth.proto.
+func (x *ListRolesResponse) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [ListRolesResponse] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *ListRolesResponse) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [ListRolesResponse] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *ListRolesResponse) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *ListRolesResponse) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	for i, m := range x.GetRoles() {
+		m.validate(v, fmt.Sprintf("%sroles[%d].", prefix, i))
+	}
+}
+
+// Validate checks the [ListUserRolesRequest] against the rules declared in proto/auth.proto.
+func (x *ListUserRolesRequest) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [ListUserRolesRequest] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *ListUserRolesRequest) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [ListUserRolesRequest] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *ListUserRolesRequest) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *ListUserRolesRequest) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+}
+
+// Validate checks the [ListUserRolesResponse] against the rules declared in proto/auth.proto.
+func (x *ListUserRolesResponse) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [ListUserRolesResponse] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *ListUserRolesResponse) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [ListUserRolesResponse] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *ListUserRolesResponse) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *ListUserRolesResponse) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	for i, m := range x.GetUserRoles() {
+		m.validate(v, fmt.Sprintf("%suser_roles[%d].", prefix, i))
+	}
+}
+
+// Validate checks the [ListAuditEventsRequest] against the rules declared in proto/auth.proto.
+func (x *ListAuditEventsRequest) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [ListAuditEventsRequest] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *ListAuditEventsRequest) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [ListAuditEventsRequest] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *ListAuditEventsRequest) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *ListAuditEventsRequest) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+}
+
+// Validate checks the [ListAuditEventsResponse] against the rules declared in proto/auth.proto.
+func (x *ListAuditEventsResponse) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidatePartial checks the [ListAuditEventsResponse] like Validate, but skips the required
+// and output_only rules, for requests holding a partial resource.
+func (x *ListAuditEventsResponse) ValidatePartial() error {
+	v := &validator{partial: true}
+	x.validate(v, "")
+	return v.err()
+}
+
+// ValidateMask checks the [ListAuditEventsResponse] like ValidatePartial, but applies the
+// required rules of the fields named by paths, for updates that set them.
+func (x *ListAuditEventsResponse) ValidateMask(paths ...string) error {
+	v := &validator{partial: true, masked: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		v.masked[p] = true
+	}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x *ListAuditEventsResponse) validate(v *validator, prefix string) {
+	if x == nil {
+		return
+	}
+	for i, m := range x.GetAuditEvents() {
+		m.validate(v, fmt.Sprintf("%saudit_events[%d].", prefix, i))
+	}
+}
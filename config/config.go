@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"time"
 )
 
 // DatabaseConfig represents the database configuration
@@ -14,17 +15,45 @@ type DatabaseConfig struct {
 	DbName   string
 }
 
-// ServerConfig represents the server configuration
+// ServerConfig represents the server configuration.
+//
+// The gRPC server listens on GRPCPort, serving TLS when both TLSCertPath and
+// TLSKeyPath are set, and additionally requiring client certificates signed by
+// TLSCaCertPath when it is set. InsecureGRPCPort optionally serves plaintext
+// alongside the TLS listener. The REST gateway listens on HTTPPort, when set,
+// and proxies to the gRPC server at GrpcGatewayURL, in plaintext when that is
+// the InsecureGRPCPort and otherwise over TLS, presenting GatewayTLSCertPath
+// and GatewayTLSKeyPath as its client certificate under mutual TLS. The
+// database is checked every HealthCheckInterval to report health. On shutdown
+// in-flight RPCs are given ShutdownTimeout to finish.
 type ServerConfig struct {
-	GRPCPort       string
-	TLSCertPath    string
-	TLSKeyPath     string
-	TLSCaCertPath  string
-	Environment    string
-	GrpcGatewayURL string
+	GRPCPort            string
+	InsecureGRPCPort    string
+	TLSCertPath         string
+	TLSKeyPath          string
+	TLSCaCertPath       string
+	GatewayTLSCertPath  string
+	GatewayTLSKeyPath   string
+	Environment         string
+	HTTPPort            string
+	GrpcGatewayURL      string
+	HealthCheckInterval time.Duration
+	ShutdownTimeout     time.Duration
 }
 
-// LoadConfig loads the database and server configurations
+// AuthConfig represents the token configuration.
+//
+// Access tokens are signed with HS256 using JWTSecret, or with RS256 or ES256
+// when JWTPrivateKeyPath names a PEM encoded RSA or P-256 EC private key.
+type AuthConfig struct {
+	JWTSecret         string
+	JWTPrivateKeyPath string
+	JWTIssuer         string
+	AccessTokenTTL    time.Duration
+	RefreshTokenTTL   time.Duration
+}
+
+// LoadConfig loads the database, server and token configurations
 func LoadConfig() Config {
 	return Config{
 		Database: DatabaseConfig{
@@ -35,12 +64,25 @@ func LoadConfig() Config {
 			DbName:   getEnv("DB_NAME", "example_project_proto_db"),
 		},
 		Server: ServerConfig{
-			GRPCPort:       getEnv("GRPC_PORT", "50051"),
-			TLSCertPath:    getEnv("TLS_CERT_PATH", "/etc/ssl/server.crt"),
-			TLSKeyPath:     getEnv("TLS_KEY_PATH", "/etc/ssl/server.key"),
-			TLSCaCertPath:  getEnv("TLS_CA_CERT_PATH", "/etc/ssl/ca.crt"),
-			Environment:    getEnv("ENVIRONMENT", "development"),
-			GrpcGatewayURL: getEnv("GRPC_GATEWAY_URL", "localhost:50052"),
+			GRPCPort:            getEnv("GRPC_PORT", "50051"),
+			InsecureGRPCPort:    getEnv("INSECURE_GRPC_PORT", ""),
+			TLSCertPath:         getEnv("TLS_CERT_PATH", ""),
+			TLSKeyPath:          getEnv("TLS_KEY_PATH", ""),
+			TLSCaCertPath:       getEnv("TLS_CA_CERT_PATH", ""),
+			GatewayTLSCertPath:  getEnv("GATEWAY_TLS_CERT_PATH", ""),
+			GatewayTLSKeyPath:   getEnv("GATEWAY_TLS_KEY_PATH", ""),
+			Environment:         getEnv("ENVIRONMENT", "development"),
+			HTTPPort:            getEnv("HTTP_PORT", "8080"),
+			GrpcGatewayURL:      getEnv("GRPC_GATEWAY_URL", "localhost:50051"),
+			HealthCheckInterval: getDurationEnv("HEALTH_CHECK_INTERVAL", 10*time.Second),
+			ShutdownTimeout:     getDurationEnv("SHUTDOWN_TIMEOUT", 30*time.Second),
+		},
+		Auth: AuthConfig{
+			JWTSecret:         getEnv("JWT_SECRET", ""),
+			JWTPrivateKeyPath: getEnv("JWT_PRIVATE_KEY_PATH", ""),
+			JWTIssuer:         getEnv("JWT_ISSUER", "example-project-proto-db"),
+			AccessTokenTTL:    getDurationEnv("ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTokenTTL:   getDurationEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour),
 		},
 	}
 }
@@ -49,6 +91,7 @@ func LoadConfig() Config {
 type Config struct {
 	Database DatabaseConfig
 	Server   ServerConfig
+	Auth     AuthConfig
 }
 
 // getEnv fetches the value of an environment variable or returns a default value
@@ -60,3 +103,14 @@ func getEnv(key string, defaultValue string) string {
 	}
 	return value
 }
+
+// getDurationEnv fetches a duration such as "30s" from an environment variable or returns a default value
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	value := getEnv(key, defaultValue.String())
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Environment variable %s is not a duration: %v. Using default: %s", key, err, defaultValue)
+		return defaultValue
+	}
+	return d
+}
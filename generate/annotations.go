@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/imran31415/example-project-proto-db/auth"
+	db_annotations "github.com/imran31415/protobuf-db/db-annotations"
+	"google.golang.org/protobuf/proto"
+)
+
+// generateAnnotations writes the annotations.go file of the xo templates in
+// templatesDir, holding the soft_delete, row_version and db_update_action
+// annotations of the columns of each message's table. xo only reads the
+// database, which does not record them, so it must run before the models are
+// generated.
+func generateAnnotations(templatesDir string, protoMessages []proto.Message) error {
+	src, err := annotationSource(protoMessages)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(templatesDir, "annotations.go"), src, 0o644); err != nil {
+		return fmt.Errorf("failed to write annotations: %w", err)
+	}
+	return nil
+}
+
+// annotationSource returns the formatted columnAnnotations of the tables of
+// protoMessages, leaving out the columns without annotations.
+func annotationSource(protoMessages []proto.Message) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "//go:build xotpl\n\n")
+	fmt.Fprintf(buf, "// Code generated by generate/annotations.go. DO NOT EDIT.\n\n")
+	fmt.Fprintf(buf, "package gotpl\n\n")
+	fmt.Fprintf(buf, "// columnAnnotations are the annotations of the columns, keyed by table and\n")
+	fmt.Fprintf(buf, "// column name.\n")
+	fmt.Fprintf(buf, "var columnAnnotations = map[string]map[string]columnAnnotation{\n")
+	for _, msg := range protoMessages {
+		md := msg.ProtoReflect().Descriptor()
+		table := string(md.Name())
+		columns, err := columnAnnotations(msg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate annotations for table '%s': %w", table, err)
+		}
+		if len(columns) != 0 {
+			fmt.Fprintf(buf, "%q: {\n%s\n},\n", table, strings.Join(columns, "\n"))
+		}
+	}
+	fmt.Fprintf(buf, "}\n")
+	return format.Source(buf.Bytes())
+}
+
+// columnAnnotations returns the columnAnnotation entries of the annotated
+// columns of msg, checking that the columns suit the code the templates
+// generate for them.
+func columnAnnotations(msg proto.Message) ([]string, error) {
+	var columns []string
+	var softDeletes, rowVersions int
+	all := msg.ProtoReflect().Descriptor().Fields()
+	for i := 0; i < all.Len(); i++ {
+		fd := all.Get(i)
+		column, _ := proto.GetExtension(fd.Options(), db_annotations.E_DbColumn).(string)
+		if column == "" {
+			continue
+		}
+		var set []string
+		if softDelete, _ := proto.GetExtension(fd.Options(), auth.E_SoftDelete).(bool); softDelete {
+			if modelGoType(fd) != "sql.NullTime" {
+				return nil, fmt.Errorf("soft_delete field '%s' must be a nullable DATETIME column", fd.Name())
+			}
+			softDeletes++
+			set = append(set, "SoftDelete: true")
+		}
+		if rowVersion, _ := proto.GetExtension(fd.Options(), auth.E_RowVersion).(bool); rowVersion {
+			if pk, _ := proto.GetExtension(fd.Options(), db_annotations.E_DbPrimaryKey).(bool); pk || modelGoType(fd) != "int" {
+				return nil, fmt.Errorf("row_version field '%s' must be a NOT NULL INT column outside the primary key", fd.Name())
+			}
+			rowVersions++
+			set = append(set, "RowVersion: true")
+		}
+		action, _ := proto.GetExtension(fd.Options(), db_annotations.E_DbUpdateAction).(db_annotations.DbUpdateAction)
+		if action != db_annotations.DbUpdateAction_DB_UPDATE_ACTION_UNSPECIFIED {
+			set = append(set, "DBUpdated: true")
+		}
+		if len(set) != 0 {
+			columns = append(columns, fmt.Sprintf("%q: {%s},", column, strings.Join(set, ", ")))
+		}
+	}
+	switch {
+	case softDeletes > 1:
+		return nil, fmt.Errorf("%d soft_delete fields, want at most one", softDeletes)
+	case rowVersions > 1:
+		return nil, fmt.Errorf("%d row_version fields, want at most one", rowVersions)
+	}
+	return columns, nil
+}
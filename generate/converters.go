@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/imran31415/example-project-proto-db/auth"
+	db_annotations "github.com/imran31415/protobuf-db/db-annotations"
+	"github.com/kenshaw/snaker"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// protoPkg is the import path of the package holding the annotated messages.
+const protoPkg = "github.com/imran31415/example-project-proto-db/auth"
+
+// timestampName is the full name of google.protobuf.Timestamp.
+const timestampName = "google.protobuf.Timestamp"
+
+// nullFields maps the database/sql null types xo generates for nullable
+// columns to the field holding their value.
+var nullFields = map[string]string{
+	"sql.NullInt64":   "Int64",
+	"sql.NullString":  "String",
+	"sql.NullBool":    "Bool",
+	"sql.NullTime":    "Time",
+	"sql.NullFloat64": "Float64",
+}
+
+// converterField is a db_column annotated field of a message.
+type converterField struct {
+	fd        protoreflect.FieldDescriptor
+	sensitive bool   // never converted, see the sensitive option
+	protoName string // Go name of the protobuf message field
+	protoType string // Go type of the protobuf message field
+	modelName string // Go name of the generated_models struct field
+	modelType string // Go type of the generated_models struct field
+}
+
+// generateConverters writes a <table>_proto.go file to outputDir for each of
+// the messages, holding a ToProto method and a FromProto func that convert
+// between the message and the generated_models struct of its table. Fields
+// are paired by their db_column annotation, and the struct field types follow
+// the ones xo generates for the annotated column type and nullability. Fields
+// with the sensitive option are left out of both conversions.
+func generateConverters(outputDir string, protoMessages []proto.Message) error {
+	for _, msg := range protoMessages {
+		src, err := converterSource(msg)
+		if err != nil {
+			return err
+		}
+		table := string(msg.ProtoReflect().Descriptor().Name())
+		name := filepath.Join(outputDir, strings.ToLower(table)+"_proto.go")
+		if err := os.WriteFile(name, src, 0o644); err != nil {
+			return fmt.Errorf("failed to write converters for table '%s': %w", table, err)
+		}
+	}
+	return nil
+}
+
+// converterSource returns the formatted converters for the table of msg.
+func converterSource(msg proto.Message) ([]byte, error) {
+	md := msg.ProtoReflect().Descriptor()
+	table := string(md.Name())
+	fields, err := converterFields(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate converters for table '%s': %w", table, err)
+	}
+	goName, protoName := snaker.ForceCamelIdentifier(table), "auth."+table
+	// the receiver name xo uses, ie ur for UserRole
+	var short string
+	for _, word := range strings.Split(snaker.CamelToSnake(goName), "_") {
+		short += word[:1]
+	}
+	if short == "m" {
+		short = "x"
+	}
+
+	var toProto, fromProto []string
+	imports := map[string]bool{protoPkg: true}
+	for _, f := range fields {
+		if f.sensitive {
+			comment := fmt.Sprintf("// %s is sensitive and never converted", f.modelName)
+			toProto, fromProto = append(toProto, comment), append(fromProto, comment)
+			continue
+		}
+		to, from, err := f.conversions(short, imports)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate converters for table '%s': %w", table, err)
+		}
+		toProto, fromProto = append(toProto, to), append(fromProto, from)
+	}
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "package generated_models\n\n")
+	fmt.Fprintf(buf, "// Code generated by generate/converters.go. DO NOT EDIT.\n\n")
+	fmt.Fprintf(buf, "import (\n")
+	for _, pkg := range []string{"database/sql", protoPkg, "google.golang.org/protobuf/proto", "google.golang.org/protobuf/types/known/timestamppb"} {
+		if imports[pkg] {
+			fmt.Fprintf(buf, "%q\n", pkg)
+		}
+	}
+	fmt.Fprintf(buf, ")\n\n")
+	fmt.Fprintf(buf, "// ToProto converts the [%s] to a [%s].\n", goName, protoName)
+	fmt.Fprintf(buf, "func (%s *%s) ToProto() *%s {\n", short, goName, protoName)
+	fmt.Fprintf(buf, "if %s == nil {\nreturn nil\n}\n", short)
+	fmt.Fprintf(buf, "m := new(%s)\n%s\nreturn m\n}\n\n", protoName, strings.Join(toProto, "\n"))
+	fmt.Fprintf(buf, "// %sFromProto converts a [%s] to a [%s]. The result is not\n", goName, protoName, goName)
+	fmt.Fprintf(buf, "// marked as existing in the database.\n")
+	fmt.Fprintf(buf, "func %sFromProto(m *%s) *%s {\n", goName, protoName, goName)
+	fmt.Fprintf(buf, "if m == nil {\nreturn nil\n}\n")
+	fmt.Fprintf(buf, "%s := new(%s)\n%s\nreturn %s\n}\n", short, goName, strings.Join(fromProto, "\n"), short)
+	return format.Source(buf.Bytes())
+}
+
+// converterFields returns the db_column annotated fields of msg.
+func converterFields(msg proto.Message) ([]converterField, error) {
+	protoNames := goFieldNames(msg)
+	var fields []converterField
+	all := msg.ProtoReflect().Descriptor().Fields()
+	for i := 0; i < all.Len(); i++ {
+		fd := all.Get(i)
+		column, _ := proto.GetExtension(fd.Options(), db_annotations.E_DbColumn).(string)
+		if column == "" {
+			continue
+		}
+		sensitive, _ := proto.GetExtension(fd.Options(), auth.E_Sensitive).(bool)
+		protoType, err := protoGoType(fd)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, converterField{
+			fd:        fd,
+			sensitive: sensitive,
+			protoName: protoNames[string(fd.Name())],
+			protoType: protoType,
+			modelName: snaker.ForceCamelIdentifier(column),
+			modelType: modelGoType(fd),
+		})
+	}
+	return fields, nil
+}
+
+// goFieldNames returns the Go names of the fields of msg, keyed by their
+// protobuf names.
+func goFieldNames(msg proto.Message) map[string]string {
+	names := make(map[string]string)
+	typ := reflect.TypeOf(msg).Elem()
+	for i := 0; i < typ.NumField(); i++ {
+		for _, opt := range strings.Split(typ.Field(i).Tag.Get("protobuf"), ",") {
+			if name, ok := strings.CutPrefix(opt, "name="); ok {
+				names[name] = typ.Field(i).Name
+			}
+		}
+	}
+	return names
+}
+
+// protoGoType returns the Go type protoc-gen-go generates for fd, or "" for
+// google.protobuf.Timestamp.
+func protoGoType(fd protoreflect.FieldDescriptor) (string, error) {
+	if fd.IsList() || fd.IsMap() {
+		return "", fmt.Errorf("field '%s' is repeated", fd.Name())
+	}
+	switch fd.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return "int32", nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return "uint32", nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return "int64", nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "uint64", nil
+	case protoreflect.FloatKind:
+		return "float32", nil
+	case protoreflect.DoubleKind:
+		return "float64", nil
+	case protoreflect.BoolKind:
+		return "bool", nil
+	case protoreflect.StringKind:
+		return "string", nil
+	case protoreflect.BytesKind:
+		return "[]byte", nil
+	case protoreflect.MessageKind:
+		if fd.Message().FullName() == timestampName {
+			return "", nil
+		}
+	}
+	return "", fmt.Errorf("field '%s' has unsupported type %s", fd.Name(), fd.Kind())
+}
+
+// modelGoType returns the Go type xo generates for the column of fd.
+func modelGoType(fd protoreflect.FieldDescriptor) string {
+	opts := fd.Options()
+	pk, _ := proto.GetExtension(opts, db_annotations.E_DbPrimaryKey).(bool)
+	nullable := !pk
+	constraints, _ := proto.GetExtension(opts, db_annotations.E_DbConstraints).([]db_annotations.DbConstraint)
+	for _, c := range constraints {
+		if c == db_annotations.DbConstraint_DB_CONSTRAINT_NOT_NULL || c == db_annotations.DbConstraint_DB_CONSTRAINT_PRIMARY_KEY {
+			nullable = false
+		}
+	}
+	typ, null := "string", "sql.NullString"
+	switch t, _ := proto.GetExtension(opts, db_annotations.E_DbColumnType).(db_annotations.DbColumnType); t {
+	case db_annotations.DbColumnType_DB_TYPE_INT:
+		typ, null = "int", "sql.NullInt64"
+	case db_annotations.DbColumnType_DB_TYPE_BOOLEAN:
+		typ, null = "bool", "sql.NullBool"
+	case db_annotations.DbColumnType_DB_TYPE_DATETIME:
+		typ, null = "time.Time", "sql.NullTime"
+	case db_annotations.DbColumnType_DB_TYPE_FLOAT:
+		typ, null = "float32", "sql.NullFloat64"
+	case db_annotations.DbColumnType_DB_TYPE_DOUBLE:
+		typ, null = "float64", "sql.NullFloat64"
+	case db_annotations.DbColumnType_DB_TYPE_BINARY:
+		typ, null = "[]byte", "[]byte"
+	}
+	if nullable {
+		return null
+	}
+	return typ
+}
+
+// conversions returns the statements converting f from the model held in
+// short to the message held in m, and back.
+func (f converterField) conversions(short string, imports map[string]bool) (string, string, error) {
+	model, msg := short+"."+f.modelName, "m."+f.protoName
+	inner, nullable := nullFields[f.modelType]
+	if nullable {
+		imports["database/sql"] = true
+	}
+	// timestamps
+	if f.protoType == "" {
+		imports["google.golang.org/protobuf/types/known/timestamppb"] = true
+		switch f.modelType {
+		case "time.Time":
+			return fmt.Sprintf("%s = timestamppb.New(%s)", msg, model),
+				fmt.Sprintf("if %s != nil {\n%s = %s.AsTime()\n}", msg, model, msg), nil
+		case "sql.NullTime":
+			return fmt.Sprintf("if %s.Valid {\n%s = timestamppb.New(%s.Time)\n}", model, msg, model),
+				fmt.Sprintf("if %s != nil {\n%s = sql.NullTime{Time: %s.AsTime(), Valid: true}\n}", msg, model, msg), nil
+		}
+		return "", "", fmt.Errorf("field '%s' is a timestamp but column is %s", f.fd.Name(), f.modelType)
+	}
+	if f.modelType == "time.Time" || f.modelType == "sql.NullTime" {
+		return "", "", fmt.Errorf("field '%s' is %s but column is %s", f.fd.Name(), f.protoType, f.modelType)
+	}
+	// optional scalars are pointers, with nil for NULL
+	presence := f.fd.HasPresence() && f.protoType != "[]byte"
+	if presence {
+		imports["google.golang.org/protobuf/proto"] = true
+	}
+	if !nullable {
+		if presence {
+			return fmt.Sprintf("%s = %s", msg, pointer(f.protoType, convert(f.protoType, f.modelType, model))),
+				fmt.Sprintf("if %s != nil {\n%s = %s\n}", msg, model, convert(f.modelType, f.protoType, "*"+msg)), nil
+		}
+		return fmt.Sprintf("%s = %s", msg, convert(f.protoType, f.modelType, model)),
+			fmt.Sprintf("%s = %s", model, convert(f.modelType, f.protoType, msg)), nil
+	}
+	innerType := strings.ToLower(inner)
+	if presence {
+		return fmt.Sprintf("if %s.Valid {\n%s = %s\n}", model, msg, pointer(f.protoType, convert(f.protoType, innerType, model+"."+inner))),
+			fmt.Sprintf("if %s != nil {\n%s = %s{%s: %s, Valid: true}\n}", msg, model, f.modelType, inner, convert(innerType, f.protoType, "*"+msg)), nil
+	}
+	// without presence the zero value stands for NULL
+	return fmt.Sprintf("if %s.Valid {\n%s = %s\n}", model, msg, convert(f.protoType, innerType, model+"."+inner)),
+		fmt.Sprintf("if %s != %s {\n%s = %s{%s: %s, Valid: true}\n}", msg, zero(f.protoType), model, f.modelType, inner, convert(innerType, f.protoType, msg)), nil
+}
+
+// convert returns expr of type from converted to type to.
+func convert(to, from, expr string) string {
+	if to == from {
+		return expr
+	}
+	return to + "(" + expr + ")"
+}
+
+// pointer returns expr of a protobuf scalar Go type as a pointer.
+func pointer(typ, expr string) string {
+	return "proto." + strings.ToUpper(typ[:1]) + typ[1:] + "(" + expr + ")"
+}
+
+// zero returns the zero value of a protobuf scalar Go type.
+func zero(typ string) string {
+	switch typ {
+	case "string":
+		return `""`
+	case "bool":
+		return "false"
+	}
+	return "0"
+}
@@ -1,8 +1,9 @@
 package main
 
 import (
+	"log"
+
 	auth "github.com/imran31415/example-project-proto-db/auth"
-	configGenerator "github.com/imran31415/proto-db-translator/config_generator"
 	translator "github.com/imran31415/proto-db-translator/translator"
 	db "github.com/imran31415/proto-db-translator/translator/db"
 
@@ -13,13 +14,31 @@ func main() {
 	conn := db.DefaultMysqlConnection()
 	conn.DbName = "example_project_proto_db"
 	t := translator.NewTranslator(conn)
+	messages := []proto.Message{&auth.User{}, &auth.Role{}, &auth.UserRole{}, &auth.RefreshToken{}, &auth.Permission{}, &auth.RolePermission{}, &auth.AuditEvent{}}
+
+	// generateAnnotations writes the annotations of the columns that the database does not record
+	// for the xo templates, so it runs before the models are generated
+	if err := generateAnnotations("../templates", messages); err != nil {
+		log.Fatal(err)
+	}
+
 	// .GenerateModels does the following:
 	//   1. Takes each of the protos and generate the SQL create table statement,
 	//   2. Execute the statements to generate all the tables based on the protobuf annotations
 	///  3. With the created SQL tables, generate the Go CRUD models.
-	t.GenerateModels("../generated_models", []proto.Message{&auth.User{}, &auth.Role{}, &auth.UserRole{}})
+	t.GenerateModels("../generated_models", messages)
+
+	// generateConverters writes the ToProto/FromProto conversions between each proto and its Go model,
+	// pairing fields by their db_column annotation
+	if err := generateConverters("../generated_models", messages); err != nil {
+		log.Fatal(err)
+	}
 
-	// .GenerateConfig will create a /config directory with a config.go that supplies a valid config object to supply to the API
-	configGenerator.GenerateConfig("../config")
+	// generateValidators writes the Validate methods of the protos, checking the rules option of their fields
+	if err := generateValidators("../auth", auth.File_proto_auth_proto); err != nil {
+		log.Fatal(err)
+	}
 
+	// config/config.go was bootstrapped by configGenerator.GenerateConfig and is now maintained by hand,
+	// so it is not regenerated here
 }
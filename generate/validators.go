@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/imran31415/example-project-proto-db/auth"
+	"github.com/kenshaw/snaker"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// validatorHelpers are the types and funcs shared by the generated Validate
+// methods.
+const validatorHelpers = `
+// FieldViolation is a field of a message that breaks one of its rules.
+type FieldViolation struct {
+	// Field is the path to the field, such as "user.email".
+	Field       string
+	Description string
+}
+
+// ValidationError is returned by Validate, ValidatePartial and ValidateMask,
+// listing every rule the message breaks.
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.Field + ": " + v.Description
+	}
+	return "invalid request: " + strings.Join(msgs, "; ")
+}
+
+// validator collects the violations of a message and its submessages.
+type validator struct {
+	partial bool
+	// masked are the paths of the fields a partial validation still requires
+	masked     map[string]bool
+	violations []FieldViolation
+}
+
+// required reports whether the required rule of field applies.
+func (v *validator) required(field string) bool {
+	return !v.partial || v.masked[field]
+}
+
+func (v *validator) add(field, format string, args ...interface{}) {
+	v.violations = append(v.violations, FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: v.violations}
+}
+
+// validEmail reports whether s is a bare email address.
+func validEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
+`
+
+// generateValidators writes a <file>_validate.go file to outputDir, holding
+// Validate, ValidatePartial and ValidateMask methods for each message of file that check
+// the rules option of its fields. Message fields of the same file are
+// validated recursively, with their violations reported under the path to the
+// field.
+func generateValidators(outputDir string, file protoreflect.FileDescriptor) error {
+	src, err := validatorSource(file)
+	if err != nil {
+		return fmt.Errorf("failed to generate validators for '%s': %w", file.Path(), err)
+	}
+	base := strings.TrimSuffix(path.Base(file.Path()), ".proto")
+	name := filepath.Join(outputDir, base+"_validate.go")
+	if err := os.WriteFile(name, src, 0o644); err != nil {
+		return fmt.Errorf("failed to write validators for '%s': %w", file.Path(), err)
+	}
+	return nil
+}
+
+// validatorSource returns the formatted validators for the messages of file.
+func validatorSource(file protoreflect.FileDescriptor) ([]byte, error) {
+	imports := map[string]bool{"fmt": true, "net/mail": true, "strings": true}
+	patterns := make(map[string]string)
+	body := new(bytes.Buffer)
+	messages := file.Messages()
+	for i := 0; i < messages.Len(); i++ {
+		if err := writeValidator(body, messages.Get(i), imports, patterns); err != nil {
+			return nil, err
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "package %s\n\n", path.Base(protoPkg))
+	fmt.Fprintf(buf, "// Code generated by generate/validators.go. DO NOT EDIT.\n\n")
+	fmt.Fprintf(buf, "import (\n")
+	pkgs := make([]string, 0, len(imports))
+	for pkg := range imports {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+	for _, pkg := range pkgs {
+		fmt.Fprintf(buf, "%q\n", pkg)
+	}
+	fmt.Fprintf(buf, ")\n")
+	buf.WriteString(validatorHelpers)
+	if len(patterns) != 0 {
+		names := make([]string, 0, len(patterns))
+		for name := range patterns {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(buf, "\n// Compiled pattern rules.\nvar (\n")
+		for _, name := range names {
+			fmt.Fprintf(buf, "%s = regexp.MustCompile(%q)\n", name, patterns[name])
+		}
+		fmt.Fprintf(buf, ")\n")
+	}
+	buf.Write(body.Bytes())
+	return format.Source(buf.Bytes())
+}
+
+// writeValidator writes the Validate, ValidatePartial, ValidateMask and
+// validate methods of md to buf.
+func writeValidator(buf *bytes.Buffer, md protoreflect.MessageDescriptor, imports map[string]bool, patterns map[string]string) error {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(md.FullName())
+	if err != nil {
+		return fmt.Errorf("message '%s' is not registered: %w", md.FullName(), err)
+	}
+	goFields := goFieldNames(mt.New().Interface())
+	name := string(md.Name())
+
+	fmt.Fprintf(buf, "\n// Validate checks the [%s] against the rules declared in %s.\n", name, md.ParentFile().Path())
+	fmt.Fprintf(buf, "func (x *%s) Validate() error {\nv := &validator{}\nx.validate(v, \"\")\nreturn v.err()\n}\n", name)
+	fmt.Fprintf(buf, "\n// ValidatePartial checks the [%s] like Validate, but skips the required\n", name)
+	fmt.Fprintf(buf, "// and output_only rules, for requests holding a partial resource.\n")
+	fmt.Fprintf(buf, "func (x *%s) ValidatePartial() error {\nv := &validator{partial: true}\nx.validate(v, \"\")\nreturn v.err()\n}\n", name)
+	fmt.Fprintf(buf, "\n// ValidateMask checks the [%s] like ValidatePartial, but applies the\n", name)
+	fmt.Fprintf(buf, "// required rules of the fields named by paths, for updates that set them.\n")
+	fmt.Fprintf(buf, "func (x *%s) ValidateMask(paths ...string) error {\nv := &validator{partial: true, masked: make(map[string]bool, len(paths))}\nfor _, p := range paths {\nv.masked[p] = true\n}\nx.validate(v, \"\")\nreturn v.err()\n}\n", name)
+	fmt.Fprintf(buf, "\nfunc (x *%s) validate(v *validator, prefix string) {\nif x == nil {\nreturn\n}\n", name)
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		get := "x.Get" + goFields[string(fd.Name())] + "()"
+		field := fmt.Sprintf("prefix+%q", fd.Name())
+		rules, _ := proto.GetExtension(fd.Options(), auth.E_Rules).(*auth.FieldRules)
+		if err := writeFieldRules(buf, fd, rules, get, field, imports, patterns); err != nil {
+			return fmt.Errorf("field '%s' of message '%s': %w", fd.Name(), name, err)
+		}
+		// submessages of this file have validators of their own
+		if fd.Message() == nil || fd.IsMap() || fd.Message().ParentFile().Path() != md.ParentFile().Path() {
+			continue
+		}
+		if fd.IsList() {
+			fmt.Fprintf(buf, "for i, m := range %s {\nm.validate(v, fmt.Sprintf(\"%%s%s[%%d].\", prefix, i))\n}\n", get, fd.Name())
+			continue
+		}
+		fmt.Fprintf(buf, "%s.validate(v, %s+\".\")\n", get, field)
+	}
+	fmt.Fprintf(buf, "}\n")
+	return nil
+}
+
+// writeFieldRules writes the checks of the rules of a field, read with get
+// and reported as field.
+func writeFieldRules(buf *bytes.Buffer, fd protoreflect.FieldDescriptor, rules *auth.FieldRules, get, field string, imports map[string]bool, patterns map[string]string) error {
+	if rules == nil {
+		return nil
+	}
+	set, unset := fieldSet(fd, get)
+	if rules.GetRequired() {
+		fmt.Fprintf(buf, "if v.required(%s) && %s {\nv.add(%s, \"is required\")\n}\n", field, unset, field)
+	}
+	if rules.GetOutputOnly() {
+		fmt.Fprintf(buf, "if !v.partial && %s {\nv.add(%s, \"is output only\")\n}\n", set, field)
+	}
+
+	stringRules := rules.GetMinLen() != 0 || rules.GetMaxLen() != 0 || rules.GetPattern() != "" || rules.GetEmail()
+	if !stringRules {
+		return nil
+	}
+	if fd.Kind() != protoreflect.StringKind || fd.IsList() || fd.IsMap() {
+		return fmt.Errorf("length, pattern and email rules need a string, not %s", fd.Kind())
+	}
+	fmt.Fprintf(buf, "if s := %s; s != \"\" {\n", get)
+	if rules.GetMinLen() != 0 {
+		imports["unicode/utf8"] = true
+		fmt.Fprintf(buf, "if utf8.RuneCountInString(s) < %d {\nv.add(%s, \"must be at least %d characters\")\n}\n", rules.GetMinLen(), field, rules.GetMinLen())
+	}
+	if rules.GetMaxLen() != 0 {
+		imports["unicode/utf8"] = true
+		fmt.Fprintf(buf, "if utf8.RuneCountInString(s) > %d {\nv.add(%s, \"must be at most %d characters\")\n}\n", rules.GetMaxLen(), field, rules.GetMaxLen())
+	}
+	if rules.GetPattern() != "" {
+		imports["regexp"] = true
+		name := snaker.ForceLowerCamelIdentifier(string(fd.ContainingMessage().Name()) + "_" + string(fd.Name()) + "_pattern")
+		patterns[name] = rules.GetPattern()
+		fmt.Fprintf(buf, "if !%s.MatchString(s) {\nv.add(%s, \"must match %%s\", %s)\n}\n", name, field, name)
+	}
+	if rules.GetEmail() {
+		fmt.Fprintf(buf, "if !validEmail(s) {\nv.add(%s, \"must be an email address\")\n}\n", field)
+	}
+	fmt.Fprintf(buf, "}\n")
+	return nil
+}
+
+// fieldSet returns expressions reporting whether the field read with get is
+// set to a non-zero value, and whether it is unset.
+func fieldSet(fd protoreflect.FieldDescriptor, get string) (string, string) {
+	if fd.IsList() || fd.IsMap() {
+		return "len(" + get + ") != 0", "len(" + get + ") == 0"
+	}
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return get + ` != ""`, get + ` == ""`
+	case protoreflect.BytesKind:
+		return "len(" + get + ") != 0", "len(" + get + ") == 0"
+	case protoreflect.BoolKind:
+		return get, "!" + get
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return get + " != nil", get + " == nil"
+	}
+	return get + " != 0", get + " == 0"
+}
@@ -0,0 +1,1018 @@
+// Package generated_models contains generated code for schema 'example_project_proto_db'.
+package generated_models
+
+// Code generated by xo. DO NOT EDIT.
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AuditEvent represents a row from 'AuditEvent'.
+type AuditEvent struct {
+	AuditEventID int            `json:"audit_event_id"` // audit_event_id
+	ActorUserID  sql.NullInt64  `json:"actor_user_id"`  // actor_user_id
+	Method       string         `json:"method"`         // method
+	Entity       string         `json:"entity"`         // entity
+	EntityPk     string         `json:"entity_pk"`      // entity_pk
+	Action       string         `json:"action"`         // action
+	BeforeJSON   sql.NullString `json:"before_json"`    // before_json
+	AfterJSON    sql.NullString `json:"after_json"`     // after_json
+	CreatedAt    time.Time      `json:"created_at"`     // created_at
+	// xo fields
+	_exists, _deleted bool
+}
+
+// Exists returns true when the [AuditEvent] exists in the database.
+func (ae *AuditEvent) Exists() bool {
+	return ae._exists
+}
+
+// Deleted returns true when the [AuditEvent] has been marked for deletion
+// from the database.
+func (ae *AuditEvent) Deleted() bool {
+	return ae._deleted
+}
+
+// Insert inserts the [AuditEvent] to the database.
+func (ae *AuditEvent) Insert(ctx context.Context, db DB) error {
+	switch {
+	case ae._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case ae._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	// run the BeforeInsert hook
+	if err := beforeInsert(ctx, db, ae); err != nil {
+		return logerror(&ErrInsertFailed{err})
+	}
+	// insert (primary key generated and returned by database), leaving
+	// unset columns with a database default to the database
+	columns := []string{"actor_user_id", "method", "entity", "entity_pk", "action", "before_json", "after_json"}
+	args := []interface{}{ae.ActorUserID, ae.Method, ae.Entity, ae.EntityPk, ae.Action, ae.BeforeJSON, ae.AfterJSON}
+	var defaults []string
+	var dest []interface{}
+	if ae.CreatedAt.IsZero() {
+		defaults, dest = append(defaults, "created_at"), append(dest, &ae.CreatedAt)
+	} else {
+		columns, args = append(columns, "created_at"), append(args, ae.CreatedAt)
+	}
+	sqlstr := `INSERT INTO AuditEvent (` + strings.Join(columns, ", ") + `) ` +
+		`VALUES (` + bindvars(len(args)) + `)`
+	// run
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
+		return logerror(err)
+	}
+	// retrieve id
+	id, err := res.LastInsertId()
+	if err != nil {
+		return logerror(err)
+	}
+	// set primary key
+	ae.AuditEventID = int(id)
+	// read back the values the database assigned
+	if len(defaults) != 0 {
+		sqlstr := `SELECT ` + strings.Join(defaults, ", ") + ` ` +
+			`FROM AuditEvent ` +
+			`WHERE audit_event_id = ?`
+		logf(sqlstr, ae.AuditEventID)
+		if err := db.QueryRowContext(ctx, sqlstr, ae.AuditEventID).Scan(dest...); err != nil {
+			return logerror(err)
+		}
+	}
+	// set exists
+	ae._exists = true
+	// run the AfterInsert hook
+	if err := afterInsert(ctx, db, ae); err != nil {
+		return logerror(&ErrInsertFailed{err})
+	}
+	return nil
+}
+
+// Update updates a [AuditEvent] in the database.
+func (ae *AuditEvent) Update(ctx context.Context, db DB) error {
+	switch {
+	case !ae._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case ae._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// run the BeforeUpdate hook
+	if err := beforeUpdate(ctx, db, ae); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	// update with primary key, leaving unset columns
+	// with a database default unchanged
+	set := []string{"actor_user_id = ?", "method = ?", "entity = ?", "entity_pk = ?", "action = ?", "before_json = ?", "after_json = ?"}
+	args := []interface{}{ae.ActorUserID, ae.Method, ae.Entity, ae.EntityPk, ae.Action, ae.BeforeJSON, ae.AfterJSON}
+	if !ae.CreatedAt.IsZero() {
+		set, args = append(set, "created_at = ?"), append(args, ae.CreatedAt)
+	}
+	sqlstr := `UPDATE AuditEvent SET ` + strings.Join(set, ", ") + ` ` +
+		`WHERE audit_event_id = ?`
+	args = append(args, ae.AuditEventID)
+	// run
+	logf(sqlstr, args...)
+	if _, err := db.ExecContext(ctx, sqlstr, args...); err != nil {
+		return logerror(err)
+	}
+	// run the AfterUpdate hook
+	if err := afterUpdate(ctx, db, ae); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	return nil
+}
+
+// Save saves the [AuditEvent] to the database.
+func (ae *AuditEvent) Save(ctx context.Context, db DB) error {
+	if ae.Exists() {
+		return ae.Update(ctx, db)
+	}
+	return ae.Insert(ctx, db)
+}
+
+// Upsert performs an upsert for [AuditEvent].
+func (ae *AuditEvent) Upsert(ctx context.Context, db DB) error {
+	switch {
+	case ae._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	// run the BeforeInsert hook
+	if err := beforeInsert(ctx, db, ae); err != nil {
+		return logerror(&ErrUpsertFailed{err})
+	}
+	// upsert, leaving unset columns with a database default to the database
+	// or, on conflict, to their stored values
+	columns := []string{"actor_user_id", "method", "entity", "entity_pk", "action", "before_json", "after_json"}
+	args := []interface{}{ae.ActorUserID, ae.Method, ae.Entity, ae.EntityPk, ae.Action, ae.BeforeJSON, ae.AfterJSON}
+	update := []string{"actor_user_id = VALUES(actor_user_id)", "method = VALUES(method)", "entity = VALUES(entity)", "entity_pk = VALUES(entity_pk)", "action = VALUES(action)", "before_json = VALUES(before_json)", "after_json = VALUES(after_json)"}
+	var defaults []string
+	var dest []interface{}
+	if ae.CreatedAt.IsZero() {
+		defaults, dest = append(defaults, "created_at"), append(dest, &ae.CreatedAt)
+	} else {
+		columns, args = append(columns, "created_at"), append(args, ae.CreatedAt)
+		update = append(update, "created_at = VALUES(created_at)")
+	}
+	// an unset audit_event_id is generated by the database, or on conflict with
+	// another unique key is that of the stored row, and read back either way
+	if ae.AuditEventID != 0 {
+		columns, args = append(columns, "audit_event_id"), append(args, ae.AuditEventID)
+	} else {
+		update = append(update, "audit_event_id = LAST_INSERT_ID(audit_event_id)")
+	}
+	sqlstr := `INSERT INTO AuditEvent (` + strings.Join(columns, ", ") + `) ` +
+		`VALUES (` + bindvars(len(args)) + `) ` +
+		`ON DUPLICATE KEY UPDATE ` + strings.Join(update, ", ")
+	// run
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
+		return logerror(err)
+	}
+	if ae.AuditEventID == 0 {
+		// retrieve id
+		id, err := res.LastInsertId()
+		if err != nil {
+			return logerror(err)
+		}
+		ae.AuditEventID = int(id)
+	}
+	// read back the values the database assigned or kept
+	if len(defaults) != 0 {
+		sqlstr := `SELECT ` + strings.Join(defaults, ", ") + ` ` +
+			`FROM AuditEvent ` +
+			`WHERE audit_event_id = ?`
+		logf(sqlstr, ae.AuditEventID)
+		if err := db.QueryRowContext(ctx, sqlstr, ae.AuditEventID).Scan(dest...); err != nil {
+			return logerror(err)
+		}
+	}
+	// set exists
+	ae._exists = true
+	// run the AfterInsert hook
+	if err := afterInsert(ctx, db, ae); err != nil {
+		return logerror(&ErrUpsertFailed{err})
+	}
+	return nil
+}
+
+// Delete deletes the [AuditEvent] from the database.
+func (ae *AuditEvent) Delete(ctx context.Context, db DB) error {
+	switch {
+	case !ae._exists: // doesn't exist
+		return nil
+	case ae._deleted: // deleted
+		return nil
+	}
+	// run the BeforeDelete hook
+	if err := beforeDelete(ctx, db, ae); err != nil {
+		return logerror(err)
+	}
+	// delete with single primary key
+	const sqlstr = `DELETE FROM AuditEvent ` +
+		`WHERE audit_event_id = ?`
+	// run
+	logf(sqlstr, ae.AuditEventID)
+	if _, err := db.ExecContext(ctx, sqlstr, ae.AuditEventID); err != nil {
+		return logerror(err)
+	}
+	// set deleted
+	ae._deleted = true
+	// run the AfterDelete hook
+	if err := afterDelete(ctx, db, ae); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// AuditEventColumn is a column name of 'AuditEvent'.
+type AuditEventColumn string
+
+// AuditEventColumn values.
+const (
+	// AuditEventColumnAuditEventID is the 'audit_event_id' column.
+	AuditEventColumnAuditEventID AuditEventColumn = "audit_event_id"
+	// AuditEventColumnActorUserID is the 'actor_user_id' column.
+	AuditEventColumnActorUserID AuditEventColumn = "actor_user_id"
+	// AuditEventColumnMethod is the 'method' column.
+	AuditEventColumnMethod AuditEventColumn = "method"
+	// AuditEventColumnEntity is the 'entity' column.
+	AuditEventColumnEntity AuditEventColumn = "entity"
+	// AuditEventColumnEntityPk is the 'entity_pk' column.
+	AuditEventColumnEntityPk AuditEventColumn = "entity_pk"
+	// AuditEventColumnAction is the 'action' column.
+	AuditEventColumnAction AuditEventColumn = "action"
+	// AuditEventColumnBeforeJSON is the 'before_json' column.
+	AuditEventColumnBeforeJSON AuditEventColumn = "before_json"
+	// AuditEventColumnAfterJSON is the 'after_json' column.
+	AuditEventColumnAfterJSON AuditEventColumn = "after_json"
+	// AuditEventColumnCreatedAt is the 'created_at' column.
+	AuditEventColumnCreatedAt AuditEventColumn = "created_at"
+)
+
+// Valid returns true when the [AuditEventColumn] is a column of 'AuditEvent'.
+func (c AuditEventColumn) Valid() bool {
+	switch c {
+	case AuditEventColumnAuditEventID, AuditEventColumnActorUserID, AuditEventColumnMethod, AuditEventColumnEntity, AuditEventColumnEntityPk, AuditEventColumnAction, AuditEventColumnBeforeJSON, AuditEventColumnAfterJSON, AuditEventColumnCreatedAt:
+		return true
+	}
+	return false
+}
+
+// Nullable returns true when the [AuditEventColumn] is a column of
+// 'AuditEvent' that may hold NULL.
+func (c AuditEventColumn) Nullable() bool {
+	switch c {
+	case AuditEventColumnActorUserID, AuditEventColumnBeforeJSON, AuditEventColumnAfterJSON:
+		return true
+	}
+	return false
+}
+
+// ParseAuditEventColumn parses a client supplied column name, returning
+// [ErrUnknownColumn] when it is not a column of 'AuditEvent'.
+func ParseAuditEventColumn(s string) (AuditEventColumn, error) {
+	if c := AuditEventColumn(s); c.Valid() {
+		return c, nil
+	}
+	return "", &ErrUnknownColumn{Table: "AuditEvent", Column: s}
+}
+
+// ColumnValue returns the value of column for the [AuditEvent], or nil when
+// column is not a valid [AuditEventColumn].
+func (ae *AuditEvent) ColumnValue(column AuditEventColumn) interface{} {
+	switch column {
+	case AuditEventColumnAuditEventID:
+		return ae.AuditEventID
+	case AuditEventColumnActorUserID:
+		return ae.ActorUserID
+	case AuditEventColumnMethod:
+		return ae.Method
+	case AuditEventColumnEntity:
+		return ae.Entity
+	case AuditEventColumnEntityPk:
+		return ae.EntityPk
+	case AuditEventColumnAction:
+		return ae.Action
+	case AuditEventColumnBeforeJSON:
+		return ae.BeforeJSON
+	case AuditEventColumnAfterJSON:
+		return ae.AfterJSON
+	case AuditEventColumnCreatedAt:
+		return ae.CreatedAt
+	}
+	return nil
+}
+
+// UpdateColumns updates only the listed columns of the [AuditEvent] in the
+// database. Unlisted columns keep their stored values, so columns the database
+// maintains itself, such as ON UPDATE CURRENT_TIMESTAMP, are left to it. The
+// row is then reloaded so the [AuditEvent] reflects the stored values.
+func (ae *AuditEvent) UpdateColumns(ctx context.Context, db DB, columns ...AuditEventColumn) error {
+	switch {
+	case !ae._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case ae._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// check the listed columns
+	var listed []AuditEventColumn
+	seen := make(map[AuditEventColumn]bool)
+	for _, c := range columns {
+		switch {
+		case !c.Valid():
+			return logerror(&ErrUpdateFailed{&ErrUnknownColumn{Table: "AuditEvent", Column: string(c)}})
+		case c == AuditEventColumnAuditEventID:
+			return logerror(&ErrUpdateFailed{ErrPrimaryKeyColumn})
+		case seen[c]:
+			continue
+		}
+		seen[c] = true
+		listed = append(listed, c)
+	}
+	if len(listed) == 0 {
+		return nil
+	}
+	// run the BeforeUpdate hook
+	if err := beforeUpdate(ctx, db, ae); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	// build the set list from the values the hook left
+	var set []string
+	var args []interface{}
+	for _, c := range listed {
+		set = append(set, string(c)+" = ?")
+		args = append(args, ae.ColumnValue(c))
+	}
+	// update with primary key
+	sqlstr := `UPDATE AuditEvent SET ` + strings.Join(set, ", ") + ` ` +
+		`WHERE audit_event_id = ?`
+	args = append(args, ae.AuditEventID)
+	// run
+	logf(sqlstr, args...)
+	if _, err := db.ExecContext(ctx, sqlstr, args...); err != nil {
+		return logerror(err)
+	}
+	// reload
+	const selstr = `SELECT ` +
+		`audit_event_id, actor_user_id, method, entity, entity_pk, action, before_json, after_json, created_at ` +
+		`FROM AuditEvent ` +
+		`WHERE audit_event_id = ?`
+	logf(selstr, ae.AuditEventID)
+	if err := db.QueryRowContext(ctx, selstr, ae.AuditEventID).Scan(&ae.AuditEventID, &ae.ActorUserID, &ae.Method, &ae.Entity, &ae.EntityPk, &ae.Action, &ae.BeforeJSON, &ae.AfterJSON, &ae.CreatedAt); err != nil {
+		return logerror(err)
+	}
+	// run the AfterUpdate hook
+	if err := afterUpdate(ctx, db, ae); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	return nil
+}
+
+// decodeAuditEventColumnValue decodes a JSON encoded value of column.
+func decodeAuditEventColumnValue(column AuditEventColumn, buf []byte) (interface{}, error) {
+	switch column {
+	case AuditEventColumnAuditEventID:
+		var v int
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case AuditEventColumnActorUserID:
+		var v sql.NullInt64
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case AuditEventColumnMethod:
+		var v string
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case AuditEventColumnEntity:
+		var v string
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case AuditEventColumnEntityPk:
+		var v string
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case AuditEventColumnAction:
+		var v string
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case AuditEventColumnBeforeJSON:
+		var v sql.NullString
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case AuditEventColumnAfterJSON:
+		var v sql.NullString
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case AuditEventColumnCreatedAt:
+		var v time.Time
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	}
+	return nil, &ErrUnknownColumn{Table: "AuditEvent", Column: string(column)}
+}
+
+// AuditEventFilters holds a typed predicate builder for each column of 'AuditEvent'.
+type AuditEventFilters struct {
+	AuditEventID ColumnFilter[AuditEvent, int]
+	ActorUserID  ColumnFilter[AuditEvent, int64]
+	Method       StringColumnFilter[AuditEvent]
+	Entity       StringColumnFilter[AuditEvent]
+	EntityPk     StringColumnFilter[AuditEvent]
+	Action       StringColumnFilter[AuditEvent]
+	BeforeJSON   StringColumnFilter[AuditEvent]
+	AfterJSON    StringColumnFilter[AuditEvent]
+	CreatedAt    ColumnFilter[AuditEvent, time.Time]
+}
+
+// AuditEventFilter builds predicates over 'AuditEvent' for
+// [AuditEventKeysetPage], [AuditEventCount] and [AuditEventDeleteWhere].
+var AuditEventFilter = AuditEventFilters{
+	AuditEventID: ColumnFilter[AuditEvent, int]{column: "audit_event_id"},
+	ActorUserID:  ColumnFilter[AuditEvent, int64]{column: "actor_user_id"},
+	Method:       StringColumnFilter[AuditEvent]{ColumnFilter[AuditEvent, string]{column: "method"}},
+	Entity:       StringColumnFilter[AuditEvent]{ColumnFilter[AuditEvent, string]{column: "entity"}},
+	EntityPk:     StringColumnFilter[AuditEvent]{ColumnFilter[AuditEvent, string]{column: "entity_pk"}},
+	Action:       StringColumnFilter[AuditEvent]{ColumnFilter[AuditEvent, string]{column: "action"}},
+	BeforeJSON:   StringColumnFilter[AuditEvent]{ColumnFilter[AuditEvent, string]{column: "before_json"}},
+	AfterJSON:    StringColumnFilter[AuditEvent]{ColumnFilter[AuditEvent, string]{column: "after_json"}},
+	CreatedAt:    ColumnFilter[AuditEvent, time.Time]{column: "created_at"},
+}
+
+// AuditEventKeysetPage retrieves a page of [AuditEvent] records using keyset pagination with dynamic filtering.
+//
+// The keyset pagination retrieves results after or before a specific value (`key`)
+// for a given column (`column`) with a limit (`limit`) and order (`ASC` or `DESC`).
+//
+// If `order` is `ASC`, it retrieves records where the value of `column` is greater than `key`.
+// If `order` is `DESC`, it retrieves records where the value of `column` is less than `key`.
+//
+// Rows are further restricted by `where`, built from [AuditEventFilter]; the zero
+// [Predicate] applies no filter.
+//
+// `column` must be a valid [AuditEventColumn], otherwise [ErrUnknownColumn] is
+// returned before any SQL is built. Nullable columns return [ErrNullableColumn].
+func AuditEventKeysetPage(ctx context.Context, db DB, column AuditEventColumn, key interface{}, limit int, order string, where Predicate[AuditEvent]) ([]*AuditEvent, *AuditEvent, error) {
+	if order != "ASC" && order != "DESC" {
+		return nil, nil, fmt.Errorf("invalid order: %s", order)
+	}
+
+	// Only known columns may be interpolated into the query
+	if !column.Valid() {
+		return nil, nil, logerror(&ErrUnknownColumn{Table: "AuditEvent", Column: string(column)})
+	}
+	if column.Nullable() {
+		return nil, nil, logerror(ErrNullableColumn)
+	}
+
+	// Start building the query
+	query := fmt.Sprintf(
+		`SELECT audit_event_id, actor_user_id, method, entity, entity_pk, action, before_json, after_json, created_at `+
+			`FROM AuditEvent WHERE %s %s ?`,
+		column, condition(order),
+	)
+
+	// Arguments for the query
+	args := []interface{}{key}
+
+	// Add the filter predicate
+	if !where.IsZero() {
+		cond, condArgs := where.SQL()
+		query += " AND (" + cond + ")"
+		args = append(args, condArgs...)
+	}
+
+	// Finalize the query with the order and limit
+	query += fmt.Sprintf(" ORDER BY %s %s LIMIT ?", column, order)
+	args = append(args, limit)
+
+	// Execute the query
+	logf(query, args...)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, logerror(err)
+	}
+	defer rows.Close()
+
+	var results []*AuditEvent
+	var lastItem *AuditEvent // Variable to store the last item
+
+	for rows.Next() {
+		ae := AuditEvent{
+			_exists: true,
+		}
+		if err := rows.Scan(
+			&ae.AuditEventID, &ae.ActorUserID, &ae.Method, &ae.Entity, &ae.EntityPk, &ae.Action, &ae.BeforeJSON, &ae.AfterJSON, &ae.CreatedAt,
+		); err != nil {
+			return nil, nil, logerror(err)
+		}
+		results = append(results, &ae)
+	}
+
+	// Check for errors during row iteration.
+	if err := rows.Err(); err != nil {
+		return nil, nil, logerror(err)
+	}
+
+	// If we have results, set the lastItem to the last element in results.
+	if len(results) > 0 {
+		lastItem = results[len(results)-1]
+	}
+
+	return results, lastItem, nil
+}
+
+// AuditEventPage is a page of [AuditEvent] records returned by [AuditEventCursorPage].
+type AuditEventPage struct {
+	Items []*AuditEvent
+	// NextCursor retrieves the following page. It is empty on the last page.
+	NextCursor string
+	// PrevCursor retrieves the preceding page. It is empty on the first page.
+	PrevCursor string
+}
+
+// auditEventKeysetColumns returns column followed by the primary key columns
+// of 'AuditEvent' that break ties between equal values of column.
+func auditEventKeysetColumns(column AuditEventColumn) []AuditEventColumn {
+	columns := []AuditEventColumn{column}
+	if column != AuditEventColumnAuditEventID {
+		columns = append(columns, AuditEventColumnAuditEventID)
+	}
+	return columns
+}
+
+// AuditEventCursorPage retrieves a page of [AuditEvent] records ordered by
+// (`column`, primary key) in `order` (`ASC` or `DESC`), so that rows sharing a
+// value of `column` are neither skipped nor repeated between pages.
+//
+// An empty `cursor` retrieves the first page. Passing the returned NextCursor or
+// PrevCursor, together with the same `column` and `order`, retrieves the
+// following or preceding page. Cursors are opaque and return [ErrInvalidCursor]
+// when they are malformed or were issued for a different column or order.
+//
+// `column` must not be nullable, otherwise [ErrNullableColumn] is returned.
+// Rows are further restricted by `where`, built from [AuditEventFilter].
+func AuditEventCursorPage(ctx context.Context, db DB, column AuditEventColumn, order, cursor string, limit int, where Predicate[AuditEvent]) (*AuditEventPage, error) {
+	switch {
+	case order != "ASC" && order != "DESC":
+		return nil, fmt.Errorf("invalid order: %s", order)
+	case limit <= 0:
+		return nil, fmt.Errorf("invalid limit: %d", limit)
+	case !column.Valid():
+		return nil, logerror(&ErrUnknownColumn{Table: "AuditEvent", Column: string(column)})
+	case column.Nullable():
+		return nil, logerror(ErrNullableColumn)
+	}
+	columns := auditEventKeysetColumns(column)
+	// decode the boundary row
+	var backward bool
+	var conds []string
+	var args []interface{}
+	if cursor != "" {
+		c, err := decodeCursor(cursor, string(column), order)
+		if err != nil || len(c.Values) != len(columns) {
+			return nil, logerror(ErrInvalidCursor)
+		}
+		for i, col := range columns {
+			v, err := decodeAuditEventColumnValue(col, c.Values[i])
+			if err != nil {
+				return nil, logerror(ErrInvalidCursor)
+			}
+			args = append(args, v)
+		}
+		backward = c.Backward
+	}
+	// paging backward walks the index in reverse
+	dir := order
+	if backward {
+		dir = reverse(order)
+	}
+	if cursor != "" {
+		conds = append(conds, keysetCondition(columns, dir))
+	}
+	if !where.IsZero() {
+		cond, condArgs := where.SQL()
+		conds = append(conds, "("+cond+")")
+		args = append(args, condArgs...)
+	}
+	// query
+	sqlstr := `SELECT ` +
+		`audit_event_id, actor_user_id, method, entity, entity_pk, action, before_json, after_json, created_at ` +
+		`FROM AuditEvent`
+	if len(conds) != 0 {
+		sqlstr += ` WHERE ` + strings.Join(conds, " AND ")
+	}
+	sqlstr += ` ORDER BY ` + keysetOrderBy(columns, dir) + ` LIMIT ?`
+	// fetch one extra row to learn whether another page follows
+	args = append(args, limit+1)
+	// run
+	logf(sqlstr, args...)
+	rows, err := db.QueryContext(ctx, sqlstr, args...)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*AuditEvent
+	for rows.Next() {
+		ae := AuditEvent{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&ae.AuditEventID, &ae.ActorUserID, &ae.Method, &ae.Entity, &ae.EntityPk, &ae.Action, &ae.BeforeJSON, &ae.AfterJSON, &ae.CreatedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &ae)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	more := len(res) > limit
+	if more {
+		res = res[:limit]
+	}
+	if backward {
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+	page := &AuditEventPage{Items: res}
+	if len(res) == 0 {
+		return page, nil
+	}
+	// a backward page always has rows after it, and a forward page from a
+	// cursor always has rows before it
+	hasNext, hasPrev := more, cursor != ""
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+	if hasNext {
+		if page.NextCursor, err = auditEventCursor(res[len(res)-1], column, order, false); err != nil {
+			return nil, logerror(err)
+		}
+	}
+	if hasPrev {
+		if page.PrevCursor, err = auditEventCursor(res[0], column, order, true); err != nil {
+			return nil, logerror(err)
+		}
+	}
+	return page, nil
+}
+
+// auditEventCursor encodes the position of ae as a cursor for [AuditEventCursorPage].
+func auditEventCursor(ae *AuditEvent, column AuditEventColumn, order string, backward bool) (string, error) {
+	var values []interface{}
+	for _, col := range auditEventKeysetColumns(column) {
+		values = append(values, ae.ColumnValue(col))
+	}
+	return encodeCursor(string(column), order, backward, values)
+}
+
+// AuditEventCount returns the number of [AuditEvent] records matching `where`.
+func AuditEventCount(ctx context.Context, db DB, where Predicate[AuditEvent]) (int64, error) {
+	cond, args := where.SQL()
+	sqlstr := `SELECT COUNT(*) FROM AuditEvent WHERE ` + cond
+	// run
+	logf(sqlstr, args...)
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlstr, args...).Scan(&count); err != nil {
+		return 0, logerror(err)
+	}
+	return count, nil
+}
+
+// AuditEventDeleteWhere deletes the [AuditEvent] records matching `where`,
+// returning the number of records deleted. The zero [Predicate] is rejected with
+// [ErrEmptyPredicate] rather than deleting every record.
+func AuditEventDeleteWhere(ctx context.Context, db DB, where Predicate[AuditEvent]) (int64, error) {
+	if where.IsZero() {
+		return 0, logerror(ErrEmptyPredicate)
+	}
+	cond, args := where.SQL()
+	// with delete hooks the matching rows are locked and
+	// deleted one by one, so that each deletion is hooked
+	if hasDeleteHooks(&AuditEvent{}) {
+		sqlstr := `SELECT ` +
+			`audit_event_id, actor_user_id, method, entity, entity_pk, action, before_json, after_json, created_at ` +
+			`FROM AuditEvent WHERE (` + cond + `) FOR UPDATE`
+		logf(sqlstr, args...)
+		rows, err := db.QueryContext(ctx, sqlstr, args...)
+		if err != nil {
+			return 0, logerror(err)
+		}
+		var matched []*AuditEvent
+		for rows.Next() {
+			ae := AuditEvent{
+				_exists: true,
+			}
+			if err := rows.Scan(&ae.AuditEventID, &ae.ActorUserID, &ae.Method, &ae.Entity, &ae.EntityPk, &ae.Action, &ae.BeforeJSON, &ae.AfterJSON, &ae.CreatedAt); err != nil {
+				rows.Close()
+				return 0, logerror(err)
+			}
+			matched = append(matched, &ae)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return 0, logerror(err)
+		}
+		for _, ae := range matched {
+			if err := ae.Delete(ctx, db); err != nil {
+				return 0, err
+			}
+		}
+		return int64(len(matched)), nil
+	}
+	sqlstr := `DELETE FROM AuditEvent WHERE ` + cond
+	// run
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
+		return 0, logerror(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, logerror(err)
+	}
+	return n, nil
+}
+
+// batchColumns returns the columns and values of the [AuditEvent] in a
+// multi-row insert or upsert. As with [AuditEvent.Insert], unset columns with a
+// database default are left out. Upserts also bind the generated primary key.
+func (ae *AuditEvent) batchColumns(upsert bool) ([]string, []interface{}) {
+	var columns []string
+	var args []interface{}
+	if upsert {
+		columns, args = append(columns, "audit_event_id"), append(args, ae.AuditEventID)
+	}
+	columns = append(columns, "actor_user_id", "method", "entity", "entity_pk", "action", "before_json", "after_json")
+	args = append(args, ae.ActorUserID, ae.Method, ae.Entity, ae.EntityPk, ae.Action, ae.BeforeJSON, ae.AfterJSON)
+	if !ae.CreatedAt.IsZero() {
+		columns, args = append(columns, "created_at"), append(args, ae.CreatedAt)
+	}
+	return columns, args
+}
+
+// readBackAuditEvents reads the values of the columns with a database default
+// back into rows, after a multi-row insert or upsert left some to the database.
+func readBackAuditEvents(ctx context.Context, db DB, rows []*AuditEvent) error {
+	type key struct {
+		AuditEventID int
+	}
+	byKey := make(map[key]*AuditEvent, len(rows))
+	var args []interface{}
+	for _, ae := range rows {
+		byKey[key{ae.AuditEventID}] = ae
+		args = append(args, ae.AuditEventID)
+	}
+	sqlstr := `SELECT ` +
+		`audit_event_id, created_at ` +
+		`FROM AuditEvent ` +
+		`WHERE (audit_event_id) IN (` + batchValues(1, len(rows)) + `)`
+	// run
+	logf(sqlstr, args...)
+	res, err := db.QueryContext(ctx, sqlstr, args...)
+	if err != nil {
+		return logerror(err)
+	}
+	defer res.Close()
+	for res.Next() {
+		var stored AuditEvent
+		if err := res.Scan(&stored.AuditEventID, &stored.CreatedAt); err != nil {
+			return logerror(err)
+		}
+		if ae := byKey[key{stored.AuditEventID}]; ae != nil {
+			ae.CreatedAt = stored.CreatedAt
+		}
+	}
+	if err := res.Err(); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// InsertAuditEvents inserts the [AuditEvent] rows to the database with multi-row
+// statements, split under the limits of [SetBatchLimits]. As with
+// [AuditEvent.Insert], the primary keys the database generates are set on
+// the rows, unset columns with a database default are read back, and the
+// hooks run for every row.
+func InsertAuditEvents(ctx context.Context, db DB, rows []*AuditEvent) error {
+	for _, ae := range rows {
+		switch {
+		case ae._exists: // already exists
+			return logerror(&ErrInsertFailed{ErrAlreadyExists})
+		case ae._deleted: // deleted
+			return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+		}
+		// run the BeforeInsert hook
+		if err := beforeInsert(ctx, db, ae); err != nil {
+			return logerror(&ErrInsertFailed{err})
+		}
+	}
+	for _, b := range batches(rows, func(ae *AuditEvent) ([]string, []interface{}) {
+		return ae.batchColumns(false)
+	}) {
+		// insert
+		sqlstr := `INSERT INTO AuditEvent (` + strings.Join(b.columns, ", ") + `) ` +
+			`VALUES ` + batchValues(len(b.columns), len(b.rows))
+		// run
+		logf(sqlstr, b.args...)
+		res, err := db.ExecContext(ctx, sqlstr, b.args...)
+		if err != nil {
+			return logerror(err)
+		}
+		// retrieve ids, which MySQL generates consecutively for the rows of a
+		// multi-row insert, starting with the returned one, as long as
+		// auto_increment_increment is left at 1
+		id, err := res.LastInsertId()
+		if err != nil {
+			return logerror(err)
+		}
+		for i, ae := range b.rows {
+			ae.AuditEventID = int(id + int64(i))
+		}
+		// read back the values the database assigned
+		if len(b.columns) < 8 {
+			if err := readBackAuditEvents(ctx, db, b.rows); err != nil {
+				return err
+			}
+		}
+	}
+	for _, ae := range rows {
+		// set exists
+		ae._exists = true
+		// run the AfterInsert hook
+		if err := afterInsert(ctx, db, ae); err != nil {
+			return logerror(&ErrInsertFailed{err})
+		}
+	}
+	return nil
+}
+
+// UpsertAuditEvents performs an upsert of the [AuditEvent] rows with multi-row
+// statements, split under the limits of [SetBatchLimits]. As with
+// [AuditEvent.Upsert], unset columns with a database default are left to the
+// database or, on conflict, keep their stored values, and are read back. The
+// rows must carry their primary keys, as MySQL reports the generated key of
+// only one row of a multi-row upsert; insert new rows with [InsertAuditEvents].
+func UpsertAuditEvents(ctx context.Context, db DB, rows []*AuditEvent) error {
+	for _, ae := range rows {
+		switch {
+		case ae._deleted: // deleted
+			return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+		case ae.AuditEventID == 0: // primary key unset
+			return logerror(&ErrUpsertFailed{ErrPrimaryKeyUnset})
+		}
+		// run the BeforeInsert hook
+		if err := beforeInsert(ctx, db, ae); err != nil {
+			return logerror(&ErrUpsertFailed{err})
+		}
+	}
+	for _, b := range batches(rows, func(ae *AuditEvent) ([]string, []interface{}) {
+		return ae.batchColumns(true)
+	}) {
+		// upsert, updating the columns other than the primary key on conflict
+		var update []string
+		for _, c := range b.columns {
+			switch c {
+			case "audit_event_id":
+			default:
+				update = append(update, c+" = VALUES("+c+")")
+			}
+		}
+		sqlstr := `INSERT INTO AuditEvent (` + strings.Join(b.columns, ", ") + `) ` +
+			`VALUES ` + batchValues(len(b.columns), len(b.rows)) + ` ` +
+			`ON DUPLICATE KEY UPDATE ` + strings.Join(update, ", ")
+		// run
+		logf(sqlstr, b.args...)
+		if _, err := db.ExecContext(ctx, sqlstr, b.args...); err != nil {
+			return logerror(err)
+		}
+		// read back the values the database assigned or kept
+		if len(b.columns) < 9 {
+			if err := readBackAuditEvents(ctx, db, b.rows); err != nil {
+				return err
+			}
+		}
+	}
+	for _, ae := range rows {
+		// set exists
+		ae._exists = true
+		// run the AfterInsert hook
+		if err := afterInsert(ctx, db, ae); err != nil {
+			return logerror(&ErrUpsertFailed{err})
+		}
+	}
+	return nil
+}
+
+// DeleteAuditEventsByIDs deletes the [AuditEvent] records with the primary keys
+// ids, returning the number of records deleted. The ids are split into
+// statements under the limits of [SetBatchLimits], each deleting as
+// [AuditEventDeleteWhere] does.
+func DeleteAuditEventsByIDs(ctx context.Context, db DB, ids []int) (int64, error) {
+	var n int64
+	for _, chunk := range chunks(ids, batchPlaceholders) {
+		deleted, err := AuditEventDeleteWhere(ctx, db, AuditEventFilter.AuditEventID.In(chunk...))
+		n += deleted
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// AuditEventByAuditEventID retrieves a row from 'AuditEvent' as a [AuditEvent].
+//
+// Generated from index 'AuditEvent_audit_event_id_pkey'.
+func AuditEventByAuditEventID(ctx context.Context, db DB, auditEventID int) (*AuditEvent, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`audit_event_id, actor_user_id, method, entity, entity_pk, action, before_json, after_json, created_at ` +
+		`FROM AuditEvent ` +
+		`WHERE audit_event_id = ?`
+	// run
+	logf(sqlstr, auditEventID)
+	ae := AuditEvent{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, auditEventID).Scan(&ae.AuditEventID, &ae.ActorUserID, &ae.Method, &ae.Entity, &ae.EntityPk, &ae.Action, &ae.BeforeJSON, &ae.AfterJSON, &ae.CreatedAt); err != nil {
+		return nil, logerror(err)
+	}
+	return &ae, nil
+}
+
+// AuditEventByActorUserID retrieves a row from 'AuditEvent' as a [AuditEvent].
+//
+// Generated from index 'actor_user_id'.
+func AuditEventByActorUserID(ctx context.Context, db DB, actorUserID sql.NullInt64) ([]*AuditEvent, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`audit_event_id, actor_user_id, method, entity, entity_pk, action, before_json, after_json, created_at ` +
+		`FROM AuditEvent ` +
+		`WHERE actor_user_id = ?`
+	// run
+	logf(sqlstr, actorUserID)
+	rows, err := db.QueryContext(ctx, sqlstr, actorUserID)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*AuditEvent
+	for rows.Next() {
+		ae := AuditEvent{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&ae.AuditEventID, &ae.ActorUserID, &ae.Method, &ae.Entity, &ae.EntityPk, &ae.Action, &ae.BeforeJSON, &ae.AfterJSON, &ae.CreatedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &ae)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	return res, nil
+}
+
+// AuditEventByEntity retrieves a row from 'AuditEvent' as a [AuditEvent].
+//
+// Generated from index 'entity'.
+func AuditEventByEntity(ctx context.Context, db DB, entity string) ([]*AuditEvent, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`audit_event_id, actor_user_id, method, entity, entity_pk, action, before_json, after_json, created_at ` +
+		`FROM AuditEvent ` +
+		`WHERE entity = ?`
+	// run
+	logf(sqlstr, entity)
+	rows, err := db.QueryContext(ctx, sqlstr, entity)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*AuditEvent
+	for rows.Next() {
+		ae := AuditEvent{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&ae.AuditEventID, &ae.ActorUserID, &ae.Method, &ae.Entity, &ae.EntityPk, &ae.Action, &ae.BeforeJSON, &ae.AfterJSON, &ae.CreatedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &ae)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	return res, nil
+}
@@ -1,4 +1,3 @@
-// Package generated_models contains generated code for schema 'example_project_proto_db'.
 package generated_models
 
 // Code generated by xo. DO NOT EDIT.
@@ -6,8 +5,11 @@ package generated_models
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 )
 
 var (
@@ -23,6 +25,11 @@ func logerror(err error) error {
 	return err
 }
 
+// bindvars returns a list of n placeholders, ie "?, ?, ?".
+func bindvars(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
 // condition returns the appropriate SQL comparison operator based on the `order` parameter.
 func condition(order string) string {
 	if order == "ASC" {
@@ -31,6 +38,82 @@ func condition(order string) string {
 	return "<"
 }
 
+// reverse returns the opposite of the `order` parameter.
+func reverse(order string) string {
+	if order == "ASC" {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+// cursor is the decoded form of an opaque keyset pagination token. It records
+// the sort column and order it was issued for, the direction to page in, and
+// the sort and primary key values of the boundary row.
+type cursor struct {
+	Column   string            `json:"c"`
+	Order    string            `json:"o"`
+	Backward bool              `json:"b,omitempty"`
+	Values   []json.RawMessage `json:"v"`
+}
+
+// encodeCursor encodes the boundary values as an opaque token.
+func encodeCursor(column, order string, backward bool, values []interface{}) (string, error) {
+	c := cursor{
+		Column:   column,
+		Order:    order,
+		Backward: backward,
+	}
+	for _, v := range values {
+		buf, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		c.Values = append(c.Values, buf)
+	}
+	buf, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// decodeCursor decodes an opaque token, checking that it was issued for the
+// same column and order.
+func decodeCursor(s, column, order string) (cursor, error) {
+	var c cursor
+	buf, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(buf, &c); err != nil {
+		return cursor{}, ErrInvalidCursor
+	}
+	if c.Column != column || c.Order != order {
+		return cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// keysetCondition builds the row comparison selecting rows after the
+// boundary values in the given order, ie "(a, b) > (?, ?)".
+func keysetCondition[C ~string](columns []C, order string) string {
+	names := make([]string, len(columns))
+	placeholders := make([]string, len(columns))
+	for i, c := range columns {
+		names[i], placeholders[i] = string(c), "?"
+	}
+	return "(" + strings.Join(names, ", ") + ") " + condition(order) + " (" + strings.Join(placeholders, ", ") + ")"
+}
+
+// keysetOrderBy builds the ORDER BY list sorting every column in order.
+func keysetOrderBy[C ~string](columns []C, order string) string {
+	list := make([]string, len(columns))
+	for i, c := range columns {
+		list[i] = string(c) + " " + order
+	}
+	return strings.Join(list, ", ")
+}
+
 // Logf logs a message using the package logger.
 func Logf(s string, v ...interface{}) {
 	logf(s, v...)
@@ -76,6 +159,223 @@ func convLogger(logger interface{}) func(string, ...interface{}) {
 	panic(fmt.Sprintf("unsupported logger type %T", logger))
 }
 
+// Lifecycle hooks a model may implement. The generated Insert, Update,
+// UpdateColumns, Upsert and Delete methods call them with the context and DB
+// they were given: Before hooks ahead of the statement, where they may still
+// change the model, and After hooks once it has succeeded. An error from a
+// Before hook aborts the operation before any SQL runs; an error from an After
+// hook is returned after the change was made, and should roll back the
+// transaction. Upsert runs the insert hooks, as it writes the whole row.
+type (
+	// BeforeInserter is implemented by models with logic to run before they
+	// are inserted.
+	BeforeInserter interface {
+		BeforeInsert(context.Context, DB) error
+	}
+	// AfterInserter is implemented by models with logic to run after they
+	// are inserted.
+	AfterInserter interface {
+		AfterInsert(context.Context, DB) error
+	}
+	// BeforeUpdater is implemented by models with logic to run before they
+	// are updated.
+	BeforeUpdater interface {
+		BeforeUpdate(context.Context, DB) error
+	}
+	// AfterUpdater is implemented by models with logic to run after they
+	// are updated.
+	AfterUpdater interface {
+		AfterUpdate(context.Context, DB) error
+	}
+	// BeforeDeleter is implemented by models with logic to run before they
+	// are deleted.
+	BeforeDeleter interface {
+		BeforeDelete(context.Context, DB) error
+	}
+	// AfterDeleter is implemented by models with logic to run after they
+	// are deleted.
+	AfterDeleter interface {
+		AfterDelete(context.Context, DB) error
+	}
+)
+
+// beforeInsert runs the BeforeInsert hook of v, if it has one.
+func beforeInsert(ctx context.Context, db DB, v interface{}) error {
+	if h, ok := v.(BeforeInserter); ok {
+		return h.BeforeInsert(ctx, db)
+	}
+	return nil
+}
+
+// afterInsert runs the AfterInsert hook of v, if it has one.
+func afterInsert(ctx context.Context, db DB, v interface{}) error {
+	if h, ok := v.(AfterInserter); ok {
+		return h.AfterInsert(ctx, db)
+	}
+	return nil
+}
+
+// beforeUpdate runs the BeforeUpdate hook of v, if it has one.
+func beforeUpdate(ctx context.Context, db DB, v interface{}) error {
+	if h, ok := v.(BeforeUpdater); ok {
+		return h.BeforeUpdate(ctx, db)
+	}
+	return nil
+}
+
+// afterUpdate runs the AfterUpdate hook of v, if it has one.
+func afterUpdate(ctx context.Context, db DB, v interface{}) error {
+	if h, ok := v.(AfterUpdater); ok {
+		return h.AfterUpdate(ctx, db)
+	}
+	return nil
+}
+
+// beforeDelete runs the BeforeDelete hook of v, if it has one.
+func beforeDelete(ctx context.Context, db DB, v interface{}) error {
+	if h, ok := v.(BeforeDeleter); ok {
+		return h.BeforeDelete(ctx, db)
+	}
+	return nil
+}
+
+// afterDelete runs the AfterDelete hook of v, if it has one.
+func afterDelete(ctx context.Context, db DB, v interface{}) error {
+	if h, ok := v.(AfterDeleter); ok {
+		return h.AfterDelete(ctx, db)
+	}
+	return nil
+}
+
+// hasDeleteHooks reports whether v implements either delete hook.
+func hasDeleteHooks(v interface{}) bool {
+	_, before := v.(BeforeDeleter)
+	_, after := v.(AfterDeleter)
+	return before || after
+}
+
+// AuditEntry describes a mutation of a row made by a generated method.
+type AuditEntry struct {
+	// Table is the name of the mutated table.
+	Table string
+	// Action is one of insert, update, upsert, delete or restore.
+	Action string
+	// PrimaryKey holds the primary key values of the row, joined by commas.
+	PrimaryKey string
+	// Before is the stored row before the mutation, nil when there was none.
+	Before interface{}
+	// After is the row as left by the mutation, nil when it was removed.
+	After interface{}
+}
+
+// AuditHook records an [AuditEntry]. The generated methods call it with the
+// [DB] they were given, so inside a transaction the record commits or rolls
+// back with the mutation. An error is returned by the mutating method after
+// the change was made, and should roll back the transaction.
+type AuditHook func(context.Context, DB, AuditEntry) error
+
+// auditHook is the package audit hook, nil when auditing is disabled.
+var auditHook AuditHook
+
+// SetAuditHook sets the package audit hook. A nil hook disables auditing, and
+// the generated methods then skip loading the rows they would record.
+func SetAuditHook(hook AuditHook) {
+	auditHook = hook
+}
+
+// auditKey joins primary key values for [AuditEntry.PrimaryKey].
+func auditKey(values ...interface{}) string {
+	keys := make([]string, len(values))
+	for i, v := range values {
+		keys[i] = fmt.Sprint(v)
+	}
+	return strings.Join(keys, ",")
+}
+
+var (
+	// batchPlaceholders is the most placeholders a batch statement binds,
+	// the limit of a MySQL prepared statement.
+	batchPlaceholders = 65535
+	// batchBytes is the most bytes the values of a batch statement take, kept
+	// under max_allowed_packet, which defaults to 4MB in MySQL 5.7.
+	batchBytes = 4 << 20
+)
+
+// SetBatchLimits sets the most placeholders and the approximate most bytes
+// of values a statement of the batch functions, such as the InsertXs
+// functions, binds. Rows are split over as many statements as it takes.
+func SetBatchLimits(placeholders, bytes int) {
+	batchPlaceholders, batchBytes = placeholders, bytes
+}
+
+// batch is a run of rows of a multi-row statement binding the same columns.
+type batch[T any] struct {
+	columns []string
+	rows    []T
+	args    []interface{}
+	bytes   int
+}
+
+// batches groups rows by the columns they bind, as returned by row, and
+// splits the groups into batches under the batch limits, keeping the order
+// of the rows in each. A row over the limits on its own is a batch of one.
+func batches[T any](rows []T, row func(T) ([]string, []interface{})) []*batch[T] {
+	var res []*batch[T]
+	open := make(map[string]*batch[T])
+	for _, r := range rows {
+		columns, args := row(r)
+		key := strings.Join(columns, ",")
+		size := 0
+		for _, arg := range args {
+			size += argBytes(arg)
+		}
+		b := open[key]
+		if b == nil || len(b.args)+len(args) > batchPlaceholders || b.bytes+size > batchBytes {
+			b = &batch[T]{columns: columns}
+			open[key] = b
+			res = append(res, b)
+		}
+		b.rows = append(b.rows, r)
+		b.args = append(b.args, args...)
+		b.bytes += size
+	}
+	return res
+}
+
+// argBytes estimates the bytes a value takes in a statement, with its
+// placeholder and length header.
+func argBytes(v interface{}) int {
+	switch x := v.(type) {
+	case string:
+		return len(x) + 12
+	case []byte:
+		return len(x) + 12
+	case sql.NullString:
+		return len(x.String) + 12
+	}
+	return 12
+}
+
+// batchValues returns the VALUES lists of n rows of columns values each, ie
+// "(?, ?), (?, ?)".
+func batchValues(columns, n int) string {
+	row := "(" + bindvars(columns) + ")"
+	return strings.TrimSuffix(strings.Repeat(row+", ", n), ", ")
+}
+
+// chunks splits items into runs of at most size items.
+func chunks[T any](items []T, size int) [][]T {
+	var res [][]T
+	for len(items) > size {
+		res = append(res, items[:size])
+		items = items[size:]
+	}
+	if len(items) != 0 {
+		res = append(res, items)
+	}
+	return res
+}
+
 // DB is the common interface for database operations that can be used with
 // types from schema 'example_project_proto_db'.
 //
@@ -102,6 +402,27 @@ const (
 	ErrDoesNotExist Error = "does not exist"
 	// ErrMarkedForDeletion is the marked for deletion error.
 	ErrMarkedForDeletion Error = "marked for deletion"
+	// ErrEmptyPredicate is the empty predicate error, returned when a delete
+	// by filter would otherwise remove every row.
+	ErrEmptyPredicate Error = "empty predicate"
+	// ErrInvalidCursor is the invalid pagination cursor error.
+	ErrInvalidCursor Error = "invalid cursor"
+	// ErrNullableColumn is the error returned when keyset pagination sorts on a
+	// nullable column, whose NULL boundaries no row compares after.
+	ErrNullableColumn Error = "nullable column"
+	// ErrPrimaryKeyColumn is the error returned when a partial update names a
+	// primary key column.
+	ErrPrimaryKeyColumn Error = "primary key column"
+	// ErrVersionColumn is the error returned when a partial update names the
+	// row version column, which updates maintain themselves.
+	ErrVersionColumn Error = "version column"
+	// ErrPrimaryKeyUnset is the error returned when a batch upsert is given a
+	// row without its generated primary key, which the statement cannot set
+	// back on rows that are inserted alongside updated ones.
+	ErrPrimaryKeyUnset Error = "primary key unset"
+	// ErrStaleVersion is the stale version error, returned when an update of a
+	// versioned row matches no row because the row changed since it was read.
+	ErrStaleVersion Error = "stale version"
 )
 
 // ErrInsertFailed is the insert failed error.
@@ -148,3 +469,173 @@ func (err *ErrUpsertFailed) Error() string {
 func (err *ErrUpsertFailed) Unwrap() error {
 	return err.Err
 }
+
+// Predicate is a parameterized SQL condition over the rows of R. Predicates
+// are built from the generated <Type>Filter values and combined with [And],
+// [Or] and [Not]. The zero Predicate matches every row.
+type Predicate[R any] struct {
+	sql  string
+	args []interface{}
+}
+
+// IsZero returns true when the [Predicate] has no condition.
+func (p Predicate[R]) IsZero() bool {
+	return p.sql == ""
+}
+
+// SQL returns the condition and its bind arguments.
+func (p Predicate[R]) SQL() (string, []interface{}) {
+	if p.sql == "" {
+		return "1 = 1", nil
+	}
+	return p.sql, p.args
+}
+
+// And returns a [Predicate] matching rows that satisfy every predicate.
+// Zero predicates are ignored.
+func And[R any](preds ...Predicate[R]) Predicate[R] {
+	return joinPredicates(" AND ", preds)
+}
+
+// Or returns a [Predicate] matching rows that satisfy any predicate.
+// Zero predicates are ignored.
+func Or[R any](preds ...Predicate[R]) Predicate[R] {
+	return joinPredicates(" OR ", preds)
+}
+
+// Not returns a [Predicate] matching rows that do not satisfy p.
+func Not[R any](p Predicate[R]) Predicate[R] {
+	if p.sql == "" {
+		return Predicate[R]{sql: "1 = 0"}
+	}
+	return Predicate[R]{sql: "NOT (" + p.sql + ")", args: p.args}
+}
+
+// joinPredicates joins the non-zero predicates with sep.
+func joinPredicates[R any](sep string, preds []Predicate[R]) Predicate[R] {
+	var last Predicate[R]
+	var parts []string
+	var args []interface{}
+	for _, p := range preds {
+		if p.sql == "" {
+			continue
+		}
+		last = p
+		parts = append(parts, "("+p.sql+")")
+		args = append(args, p.args...)
+	}
+	if len(parts) < 2 {
+		return last
+	}
+	return Predicate[R]{sql: strings.Join(parts, sep), args: args}
+}
+
+// ColumnFilter builds predicates comparing a column of R to values of type T.
+type ColumnFilter[R, T any] struct {
+	column string
+}
+
+// compare builds a "column op ?" predicate.
+func (c ColumnFilter[R, T]) compare(op string, v T) Predicate[R] {
+	return Predicate[R]{sql: c.column + " " + op + " ?", args: []interface{}{v}}
+}
+
+// Eq matches rows where the column equals v.
+func (c ColumnFilter[R, T]) Eq(v T) Predicate[R] {
+	return c.compare("=", v)
+}
+
+// NotEq matches rows where the column does not equal v.
+func (c ColumnFilter[R, T]) NotEq(v T) Predicate[R] {
+	return c.compare("<>", v)
+}
+
+// Lt matches rows where the column is less than v.
+func (c ColumnFilter[R, T]) Lt(v T) Predicate[R] {
+	return c.compare("<", v)
+}
+
+// Lte matches rows where the column is less than or equal to v.
+func (c ColumnFilter[R, T]) Lte(v T) Predicate[R] {
+	return c.compare("<=", v)
+}
+
+// Gt matches rows where the column is greater than v.
+func (c ColumnFilter[R, T]) Gt(v T) Predicate[R] {
+	return c.compare(">", v)
+}
+
+// Gte matches rows where the column is greater than or equal to v.
+func (c ColumnFilter[R, T]) Gte(v T) Predicate[R] {
+	return c.compare(">=", v)
+}
+
+// Between matches rows where the column is within [lo, hi].
+func (c ColumnFilter[R, T]) Between(lo, hi T) Predicate[R] {
+	return Predicate[R]{sql: c.column + " BETWEEN ? AND ?", args: []interface{}{lo, hi}}
+}
+
+// In matches rows where the column equals any of vs. An empty list matches
+// no rows.
+func (c ColumnFilter[R, T]) In(vs ...T) Predicate[R] {
+	if len(vs) == 0 {
+		return Predicate[R]{sql: "1 = 0"}
+	}
+	return c.list("IN", vs)
+}
+
+// NotIn matches rows where the column equals none of vs. An empty list
+// matches every row.
+func (c ColumnFilter[R, T]) NotIn(vs ...T) Predicate[R] {
+	if len(vs) == 0 {
+		return Predicate[R]{}
+	}
+	return c.list("NOT IN", vs)
+}
+
+// list builds a "column op (?, ...)" predicate.
+func (c ColumnFilter[R, T]) list(op string, vs []T) Predicate[R] {
+	placeholders := make([]string, len(vs))
+	args := make([]interface{}, len(vs))
+	for i, v := range vs {
+		placeholders[i], args[i] = "?", v
+	}
+	return Predicate[R]{sql: c.column + " " + op + " (" + strings.Join(placeholders, ", ") + ")", args: args}
+}
+
+// IsNull matches rows where the column is NULL.
+func (c ColumnFilter[R, T]) IsNull() Predicate[R] {
+	return Predicate[R]{sql: c.column + " IS NULL"}
+}
+
+// IsNotNull matches rows where the column is not NULL.
+func (c ColumnFilter[R, T]) IsNotNull() Predicate[R] {
+	return Predicate[R]{sql: c.column + " IS NOT NULL"}
+}
+
+// StringColumnFilter builds predicates over a string column of R.
+type StringColumnFilter[R any] struct {
+	ColumnFilter[R, string]
+}
+
+// Like matches rows where the column matches the LIKE pattern.
+func (c StringColumnFilter[R]) Like(pattern string) Predicate[R] {
+	return c.compare("LIKE", pattern)
+}
+
+// NotLike matches rows where the column does not match the LIKE pattern.
+func (c StringColumnFilter[R]) NotLike(pattern string) Predicate[R] {
+	return c.compare("NOT LIKE", pattern)
+}
+
+// ErrUnknownColumn is the unknown column error, returned when a column name
+// supplied at runtime is not a column of the table.
+type ErrUnknownColumn struct {
+	Table  string
+	Column string
+}
+
+// Error satisfies the error interface.
+func (err *ErrUnknownColumn) Error() string {
+	return fmt.Sprintf("unknown column %q for table %s", err.Column, err.Table)
+}
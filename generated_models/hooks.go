@@ -0,0 +1,29 @@
+package generated_models
+
+import (
+	"context"
+	"strings"
+)
+
+// Lifecycle hooks of the models, run by the generated Insert, Update,
+// UpdateColumns, Upsert and Delete methods. See BeforeInserter and the other
+// hook interfaces in db.xo.go.
+
+// BeforeInsert normalizes the email. The timestamps left unset are assigned
+// by the database defaults.
+func (u *User) BeforeInsert(ctx context.Context, db DB) error {
+	u.Email = normalizeEmail(u.Email)
+	return nil
+}
+
+// BeforeUpdate normalizes the email. updated_at is left to the database.
+func (u *User) BeforeUpdate(ctx context.Context, db DB) error {
+	u.Email = normalizeEmail(u.Email)
+	return nil
+}
+
+// normalizeEmail trims an email and lowercases it, so that each address is
+// stored in a single form.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,22 @@
+package generated_models
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestUserBeforeUpdate(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	u := &User{Email: " Alice@Example.COM ", UpdatedAt: updatedAt}
+	if err := u.BeforeUpdate(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if u.Email != "alice@example.com" {
+		t.Errorf("got email %q, want %q", u.Email, "alice@example.com")
+	}
+	// updated_at belongs to the database's ON UPDATE CURRENT_TIMESTAMP
+	if !u.UpdatedAt.Equal(updatedAt) {
+		t.Errorf("got updated_at %v, want it unchanged", u.UpdatedAt)
+	}
+}
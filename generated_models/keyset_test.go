@@ -0,0 +1,84 @@
+package generated_models
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/imran31415/example-project-proto-db/internal/fakedb"
+)
+
+func TestCursorPageRejectsNullableColumn(t *testing.T) {
+	tests := []struct {
+		name string
+		page func(ctx context.Context, db DB) error
+	}{
+		{"User.deleted_at", func(ctx context.Context, db DB) error {
+			_, err := UserCursorPage(ctx, db, UserColumnDeletedAt, "ASC", "", 10, Predicate[User]{})
+			return err
+		}},
+		{"AuditEvent.actor_user_id", func(ctx context.Context, db DB) error {
+			_, err := AuditEventCursorPage(ctx, db, AuditEventColumnActorUserID, "DESC", "", 10, Predicate[AuditEvent]{})
+			return err
+		}},
+		{"RefreshToken.revoked_at", func(ctx context.Context, db DB) error {
+			_, err := RefreshTokenCursorPage(ctx, db, RefreshTokenColumnRevokedAt, "ASC", "", 10, Predicate[RefreshToken]{})
+			return err
+		}},
+		{"User.deleted_at keyset", func(ctx context.Context, db DB) error {
+			_, _, err := UserKeysetPage(ctx, db, UserColumnDeletedAt, nil, 10, "ASC", Predicate[User]{})
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &fakedb.Handler{}
+			db := fakedb.Open(h)
+			defer db.Close()
+
+			if err := tt.page(context.Background(), db); !errors.Is(err, ErrNullableColumn) {
+				t.Fatalf("got error %v, want %v", err, ErrNullableColumn)
+			}
+			if stmts := h.Statements(); len(stmts) != 0 {
+				t.Errorf("ran %d statements, want none", len(stmts))
+			}
+		})
+	}
+}
+
+func TestCursorPageNotNullColumn(t *testing.T) {
+	h := &fakedb.Handler{}
+	db := fakedb.Open(h)
+	defer db.Close()
+
+	page, err := UserCursorPage(context.Background(), db, UserColumnCreatedAt, "ASC", "", 10, Predicate[User]{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Items) != 0 || page.NextCursor != "" {
+		t.Errorf("got page %+v, want an empty last page", page)
+	}
+	if stmts := h.Statements(); len(stmts) != 1 {
+		t.Fatalf("ran %d statements, want 1", len(stmts))
+	}
+}
+
+func TestKeysetPageSelectsColumns(t *testing.T) {
+	h := &fakedb.Handler{}
+	db := fakedb.Open(h)
+	defer db.Close()
+
+	if _, _, err := UserKeysetPage(context.Background(), db, UserColumnUserID, 0, 10, "ASC", Predicate[User]{}); err != nil {
+		t.Fatal(err)
+	}
+	stmts := h.Statements()
+	if len(stmts) != 1 {
+		t.Fatalf("ran %d statements, want 1", len(stmts))
+	}
+	// the scan relies on the order of the selected columns
+	want := "SELECT user_id, username, email, created_at, updated_at, password_hash, deleted_at, version FROM User "
+	if !strings.HasPrefix(stmts[0].Query, want) {
+		t.Errorf("got query %q, want it to start with %q", stmts[0].Query, want)
+	}
+}
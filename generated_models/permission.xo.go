@@ -0,0 +1,977 @@
+package generated_models
+
+// Code generated by xo. DO NOT EDIT.
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Permission represents a row from 'Permission'.
+type Permission struct {
+	PermissionID   int       `json:"permission_id"`   // permission_id
+	PermissionName string    `json:"permission_name"` // permission_name
+	CreatedAt      time.Time `json:"created_at"`      // created_at
+	// xo fields
+	_exists, _deleted bool
+}
+
+// Exists returns true when the [Permission] exists in the database.
+func (p *Permission) Exists() bool {
+	return p._exists
+}
+
+// Deleted returns true when the [Permission] has been marked for deletion
+// from the database.
+func (p *Permission) Deleted() bool {
+	return p._deleted
+}
+
+// auditBefore loads the stored [Permission] as the before image of an audit
+// entry. It returns nil when no audit hook is set or no row is stored.
+func (p *Permission) auditBefore(ctx context.Context, db DB) (*Permission, error) {
+	if auditHook == nil {
+		return nil, nil
+	}
+	const sqlstr = `SELECT ` +
+		`permission_id, permission_name, created_at ` +
+		`FROM Permission ` +
+		`WHERE permission_id = ?`
+	logf(sqlstr, p.PermissionID)
+	before := Permission{
+		_exists: true,
+	}
+	switch err := db.QueryRowContext(ctx, sqlstr, p.PermissionID).Scan(&before.PermissionID, &before.PermissionName, &before.CreatedAt); {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, logerror(err)
+	}
+	return &before, nil
+}
+
+// audit passes a mutation of the [Permission] to the audit hook, with the row
+// before and after it, either of which may be nil.
+func (p *Permission) audit(ctx context.Context, db DB, action string, before, after *Permission) error {
+	if auditHook == nil {
+		return nil
+	}
+	entry := AuditEntry{
+		Table:      "Permission",
+		Action:     action,
+		PrimaryKey: auditKey(p.PermissionID),
+	}
+	// keep typed nils out of the interfaces
+	if before != nil {
+		entry.Before = before
+	}
+	if after != nil {
+		entry.After = after
+	}
+	if err := auditHook(ctx, db, entry); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// Insert inserts the [Permission] to the database.
+func (p *Permission) Insert(ctx context.Context, db DB) error {
+	switch {
+	case p._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case p._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	// run the BeforeInsert hook
+	if err := beforeInsert(ctx, db, p); err != nil {
+		return logerror(&ErrInsertFailed{err})
+	}
+	// insert (primary key generated and returned by database), leaving
+	// unset columns with a database default to the database
+	columns := []string{"permission_name"}
+	args := []interface{}{p.PermissionName}
+	var defaults []string
+	var dest []interface{}
+	if p.CreatedAt.IsZero() {
+		defaults, dest = append(defaults, "created_at"), append(dest, &p.CreatedAt)
+	} else {
+		columns, args = append(columns, "created_at"), append(args, p.CreatedAt)
+	}
+	sqlstr := `INSERT INTO Permission (` + strings.Join(columns, ", ") + `) ` +
+		`VALUES (` + bindvars(len(args)) + `)`
+	// run
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
+		return logerror(err)
+	}
+	// retrieve id
+	id, err := res.LastInsertId()
+	if err != nil {
+		return logerror(err)
+	}
+	// set primary key
+	p.PermissionID = int(id)
+	// read back the values the database assigned
+	if len(defaults) != 0 {
+		sqlstr := `SELECT ` + strings.Join(defaults, ", ") + ` ` +
+			`FROM Permission ` +
+			`WHERE permission_id = ?`
+		logf(sqlstr, p.PermissionID)
+		if err := db.QueryRowContext(ctx, sqlstr, p.PermissionID).Scan(dest...); err != nil {
+			return logerror(err)
+		}
+	}
+	// set exists
+	p._exists = true
+	// run the AfterInsert hook
+	if err := afterInsert(ctx, db, p); err != nil {
+		return logerror(&ErrInsertFailed{err})
+	}
+	return p.audit(ctx, db, "insert", nil, p)
+}
+
+// Update updates a [Permission] in the database.
+func (p *Permission) Update(ctx context.Context, db DB) error {
+	switch {
+	case !p._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case p._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// run the BeforeUpdate hook
+	if err := beforeUpdate(ctx, db, p); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	before, err := p.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// update with primary key, leaving unset columns
+	// with a database default unchanged
+	set := []string{"permission_name = ?"}
+	args := []interface{}{p.PermissionName}
+	if !p.CreatedAt.IsZero() {
+		set, args = append(set, "created_at = ?"), append(args, p.CreatedAt)
+	}
+	sqlstr := `UPDATE Permission SET ` + strings.Join(set, ", ") + ` ` +
+		`WHERE permission_id = ?`
+	args = append(args, p.PermissionID)
+	// run
+	logf(sqlstr, args...)
+	if _, err := db.ExecContext(ctx, sqlstr, args...); err != nil {
+		return logerror(err)
+	}
+	// run the AfterUpdate hook
+	if err := afterUpdate(ctx, db, p); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	return p.audit(ctx, db, "update", before, p)
+}
+
+// Save saves the [Permission] to the database.
+func (p *Permission) Save(ctx context.Context, db DB) error {
+	if p.Exists() {
+		return p.Update(ctx, db)
+	}
+	return p.Insert(ctx, db)
+}
+
+// Upsert performs an upsert for [Permission].
+func (p *Permission) Upsert(ctx context.Context, db DB) error {
+	switch {
+	case p._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	// run the BeforeInsert hook
+	if err := beforeInsert(ctx, db, p); err != nil {
+		return logerror(&ErrUpsertFailed{err})
+	}
+	before, err := p.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// upsert, leaving unset columns with a database default to the database
+	// or, on conflict, to their stored values
+	columns := []string{"permission_name"}
+	args := []interface{}{p.PermissionName}
+	update := []string{"permission_name = VALUES(permission_name)"}
+	var defaults []string
+	var dest []interface{}
+	if p.CreatedAt.IsZero() {
+		defaults, dest = append(defaults, "created_at"), append(dest, &p.CreatedAt)
+	} else {
+		columns, args = append(columns, "created_at"), append(args, p.CreatedAt)
+		update = append(update, "created_at = VALUES(created_at)")
+	}
+	// an unset permission_id is generated by the database, or on conflict with
+	// another unique key is that of the stored row, and read back either way
+	if p.PermissionID != 0 {
+		columns, args = append(columns, "permission_id"), append(args, p.PermissionID)
+	} else {
+		update = append(update, "permission_id = LAST_INSERT_ID(permission_id)")
+	}
+	sqlstr := `INSERT INTO Permission (` + strings.Join(columns, ", ") + `) ` +
+		`VALUES (` + bindvars(len(args)) + `) ` +
+		`ON DUPLICATE KEY UPDATE ` + strings.Join(update, ", ")
+	// run
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
+		return logerror(err)
+	}
+	if p.PermissionID == 0 {
+		// retrieve id
+		id, err := res.LastInsertId()
+		if err != nil {
+			return logerror(err)
+		}
+		p.PermissionID = int(id)
+	}
+	// read back the values the database assigned or kept
+	if len(defaults) != 0 {
+		sqlstr := `SELECT ` + strings.Join(defaults, ", ") + ` ` +
+			`FROM Permission ` +
+			`WHERE permission_id = ?`
+		logf(sqlstr, p.PermissionID)
+		if err := db.QueryRowContext(ctx, sqlstr, p.PermissionID).Scan(dest...); err != nil {
+			return logerror(err)
+		}
+	}
+	// set exists
+	p._exists = true
+	// run the AfterInsert hook
+	if err := afterInsert(ctx, db, p); err != nil {
+		return logerror(&ErrUpsertFailed{err})
+	}
+	return p.audit(ctx, db, "upsert", before, p)
+}
+
+// Delete deletes the [Permission] from the database.
+func (p *Permission) Delete(ctx context.Context, db DB) error {
+	switch {
+	case !p._exists: // doesn't exist
+		return nil
+	case p._deleted: // deleted
+		return nil
+	}
+	// run the BeforeDelete hook
+	if err := beforeDelete(ctx, db, p); err != nil {
+		return logerror(err)
+	}
+	before, err := p.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// delete with single primary key
+	const sqlstr = `DELETE FROM Permission ` +
+		`WHERE permission_id = ?`
+	// run
+	logf(sqlstr, p.PermissionID)
+	if _, err := db.ExecContext(ctx, sqlstr, p.PermissionID); err != nil {
+		return logerror(err)
+	}
+	// set deleted
+	p._deleted = true
+	// run the AfterDelete hook
+	if err := afterDelete(ctx, db, p); err != nil {
+		return logerror(err)
+	}
+	return p.audit(ctx, db, "delete", before, nil)
+}
+
+// PermissionColumn is a column name of 'Permission'.
+type PermissionColumn string
+
+// PermissionColumn values.
+const (
+	// PermissionColumnPermissionID is the 'permission_id' column.
+	PermissionColumnPermissionID PermissionColumn = "permission_id"
+	// PermissionColumnPermissionName is the 'permission_name' column.
+	PermissionColumnPermissionName PermissionColumn = "permission_name"
+	// PermissionColumnCreatedAt is the 'created_at' column.
+	PermissionColumnCreatedAt PermissionColumn = "created_at"
+)
+
+// Valid returns true when the [PermissionColumn] is a column of 'Permission'.
+func (c PermissionColumn) Valid() bool {
+	switch c {
+	case PermissionColumnPermissionID, PermissionColumnPermissionName, PermissionColumnCreatedAt:
+		return true
+	}
+	return false
+}
+
+// Nullable returns true when the [PermissionColumn] is a column of
+// 'Permission' that may hold NULL.
+func (c PermissionColumn) Nullable() bool {
+	return false
+}
+
+// ParsePermissionColumn parses a client supplied column name, returning
+// [ErrUnknownColumn] when it is not a column of 'Permission'.
+func ParsePermissionColumn(s string) (PermissionColumn, error) {
+	if c := PermissionColumn(s); c.Valid() {
+		return c, nil
+	}
+	return "", &ErrUnknownColumn{Table: "Permission", Column: s}
+}
+
+// ColumnValue returns the value of column for the [Permission], or nil when
+// column is not a valid [PermissionColumn].
+func (p *Permission) ColumnValue(column PermissionColumn) interface{} {
+	switch column {
+	case PermissionColumnPermissionID:
+		return p.PermissionID
+	case PermissionColumnPermissionName:
+		return p.PermissionName
+	case PermissionColumnCreatedAt:
+		return p.CreatedAt
+	}
+	return nil
+}
+
+// UpdateColumns updates only the listed columns of the [Permission] in the
+// database. Unlisted columns keep their stored values, so columns the database
+// maintains itself, such as ON UPDATE CURRENT_TIMESTAMP, are left to it. The
+// row is then reloaded so the [Permission] reflects the stored values.
+func (p *Permission) UpdateColumns(ctx context.Context, db DB, columns ...PermissionColumn) error {
+	switch {
+	case !p._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case p._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// check the listed columns
+	var listed []PermissionColumn
+	seen := make(map[PermissionColumn]bool)
+	for _, c := range columns {
+		switch {
+		case !c.Valid():
+			return logerror(&ErrUpdateFailed{&ErrUnknownColumn{Table: "Permission", Column: string(c)}})
+		case c == PermissionColumnPermissionID:
+			return logerror(&ErrUpdateFailed{ErrPrimaryKeyColumn})
+		case seen[c]:
+			continue
+		}
+		seen[c] = true
+		listed = append(listed, c)
+	}
+	if len(listed) == 0 {
+		return nil
+	}
+	// run the BeforeUpdate hook
+	if err := beforeUpdate(ctx, db, p); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	// build the set list from the values the hook left
+	var set []string
+	var args []interface{}
+	for _, c := range listed {
+		set = append(set, string(c)+" = ?")
+		args = append(args, p.ColumnValue(c))
+	}
+	before, err := p.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// update with primary key
+	sqlstr := `UPDATE Permission SET ` + strings.Join(set, ", ") + ` ` +
+		`WHERE permission_id = ?`
+	args = append(args, p.PermissionID)
+	// run
+	logf(sqlstr, args...)
+	if _, err := db.ExecContext(ctx, sqlstr, args...); err != nil {
+		return logerror(err)
+	}
+	// reload
+	const selstr = `SELECT ` +
+		`permission_id, permission_name, created_at ` +
+		`FROM Permission ` +
+		`WHERE permission_id = ?`
+	logf(selstr, p.PermissionID)
+	if err := db.QueryRowContext(ctx, selstr, p.PermissionID).Scan(&p.PermissionID, &p.PermissionName, &p.CreatedAt); err != nil {
+		return logerror(err)
+	}
+	// run the AfterUpdate hook
+	if err := afterUpdate(ctx, db, p); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	return p.audit(ctx, db, "update", before, p)
+}
+
+// decodePermissionColumnValue decodes a JSON encoded value of column.
+func decodePermissionColumnValue(column PermissionColumn, buf []byte) (interface{}, error) {
+	switch column {
+	case PermissionColumnPermissionID:
+		var v int
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case PermissionColumnPermissionName:
+		var v string
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case PermissionColumnCreatedAt:
+		var v time.Time
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	}
+	return nil, &ErrUnknownColumn{Table: "Permission", Column: string(column)}
+}
+
+// PermissionFilters holds a typed predicate builder for each column of 'Permission'.
+type PermissionFilters struct {
+	PermissionID   ColumnFilter[Permission, int]
+	PermissionName StringColumnFilter[Permission]
+	CreatedAt      ColumnFilter[Permission, time.Time]
+}
+
+// PermissionFilter builds predicates over 'Permission' for
+// [PermissionKeysetPage], [PermissionCount] and [PermissionDeleteWhere].
+var PermissionFilter = PermissionFilters{
+	PermissionID:   ColumnFilter[Permission, int]{column: "permission_id"},
+	PermissionName: StringColumnFilter[Permission]{ColumnFilter[Permission, string]{column: "permission_name"}},
+	CreatedAt:      ColumnFilter[Permission, time.Time]{column: "created_at"},
+}
+
+// PermissionKeysetPage retrieves a page of [Permission] records using keyset pagination with dynamic filtering.
+//
+// The keyset pagination retrieves results after or before a specific value (`key`)
+// for a given column (`column`) with a limit (`limit`) and order (`ASC` or `DESC`).
+//
+// If `order` is `ASC`, it retrieves records where the value of `column` is greater than `key`.
+// If `order` is `DESC`, it retrieves records where the value of `column` is less than `key`.
+//
+// Rows are further restricted by `where`, built from [PermissionFilter]; the zero
+// [Predicate] applies no filter.
+//
+// `column` must be a valid [PermissionColumn], otherwise [ErrUnknownColumn] is
+// returned before any SQL is built. Nullable columns return [ErrNullableColumn].
+func PermissionKeysetPage(ctx context.Context, db DB, column PermissionColumn, key interface{}, limit int, order string, where Predicate[Permission]) ([]*Permission, *Permission, error) {
+	if order != "ASC" && order != "DESC" {
+		return nil, nil, fmt.Errorf("invalid order: %s", order)
+	}
+
+	// Only known columns may be interpolated into the query
+	if !column.Valid() {
+		return nil, nil, logerror(&ErrUnknownColumn{Table: "Permission", Column: string(column)})
+	}
+	if column.Nullable() {
+		return nil, nil, logerror(ErrNullableColumn)
+	}
+
+	// Start building the query
+	query := fmt.Sprintf(
+		`SELECT permission_id, permission_name, created_at `+
+			`FROM Permission WHERE %s %s ?`,
+		column, condition(order),
+	)
+
+	// Arguments for the query
+	args := []interface{}{key}
+
+	// Add the filter predicate
+	if !where.IsZero() {
+		cond, condArgs := where.SQL()
+		query += " AND (" + cond + ")"
+		args = append(args, condArgs...)
+	}
+
+	// Finalize the query with the order and limit
+	query += fmt.Sprintf(" ORDER BY %s %s LIMIT ?", column, order)
+	args = append(args, limit)
+
+	// Execute the query
+	logf(query, args...)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, logerror(err)
+	}
+	defer rows.Close()
+
+	var results []*Permission
+	var lastItem *Permission // Variable to store the last item
+
+	for rows.Next() {
+		p := Permission{
+			_exists: true,
+		}
+		if err := rows.Scan(
+			&p.PermissionID, &p.PermissionName, &p.CreatedAt,
+		); err != nil {
+			return nil, nil, logerror(err)
+		}
+		results = append(results, &p)
+	}
+
+	// Check for errors during row iteration.
+	if err := rows.Err(); err != nil {
+		return nil, nil, logerror(err)
+	}
+
+	// If we have results, set the lastItem to the last element in results.
+	if len(results) > 0 {
+		lastItem = results[len(results)-1]
+	}
+
+	return results, lastItem, nil
+}
+
+// PermissionPage is a page of [Permission] records returned by [PermissionCursorPage].
+type PermissionPage struct {
+	Items []*Permission
+	// NextCursor retrieves the following page. It is empty on the last page.
+	NextCursor string
+	// PrevCursor retrieves the preceding page. It is empty on the first page.
+	PrevCursor string
+}
+
+// permissionKeysetColumns returns column followed by the primary key columns
+// of 'Permission' that break ties between equal values of column.
+func permissionKeysetColumns(column PermissionColumn) []PermissionColumn {
+	columns := []PermissionColumn{column}
+	if column != PermissionColumnPermissionID {
+		columns = append(columns, PermissionColumnPermissionID)
+	}
+	return columns
+}
+
+// PermissionCursorPage retrieves a page of [Permission] records ordered by
+// (`column`, primary key) in `order` (`ASC` or `DESC`), so that rows sharing a
+// value of `column` are neither skipped nor repeated between pages.
+//
+// An empty `cursor` retrieves the first page. Passing the returned NextCursor or
+// PrevCursor, together with the same `column` and `order`, retrieves the
+// following or preceding page. Cursors are opaque and return [ErrInvalidCursor]
+// when they are malformed or were issued for a different column or order.
+//
+// `column` must not be nullable, otherwise [ErrNullableColumn] is returned.
+// Rows are further restricted by `where`, built from [PermissionFilter].
+func PermissionCursorPage(ctx context.Context, db DB, column PermissionColumn, order, cursor string, limit int, where Predicate[Permission]) (*PermissionPage, error) {
+	switch {
+	case order != "ASC" && order != "DESC":
+		return nil, fmt.Errorf("invalid order: %s", order)
+	case limit <= 0:
+		return nil, fmt.Errorf("invalid limit: %d", limit)
+	case !column.Valid():
+		return nil, logerror(&ErrUnknownColumn{Table: "Permission", Column: string(column)})
+	case column.Nullable():
+		return nil, logerror(ErrNullableColumn)
+	}
+	columns := permissionKeysetColumns(column)
+	// decode the boundary row
+	var backward bool
+	var conds []string
+	var args []interface{}
+	if cursor != "" {
+		c, err := decodeCursor(cursor, string(column), order)
+		if err != nil || len(c.Values) != len(columns) {
+			return nil, logerror(ErrInvalidCursor)
+		}
+		for i, col := range columns {
+			v, err := decodePermissionColumnValue(col, c.Values[i])
+			if err != nil {
+				return nil, logerror(ErrInvalidCursor)
+			}
+			args = append(args, v)
+		}
+		backward = c.Backward
+	}
+	// paging backward walks the index in reverse
+	dir := order
+	if backward {
+		dir = reverse(order)
+	}
+	if cursor != "" {
+		conds = append(conds, keysetCondition(columns, dir))
+	}
+	if !where.IsZero() {
+		cond, condArgs := where.SQL()
+		conds = append(conds, "("+cond+")")
+		args = append(args, condArgs...)
+	}
+	// query
+	sqlstr := `SELECT ` +
+		`permission_id, permission_name, created_at ` +
+		`FROM Permission`
+	if len(conds) != 0 {
+		sqlstr += ` WHERE ` + strings.Join(conds, " AND ")
+	}
+	sqlstr += ` ORDER BY ` + keysetOrderBy(columns, dir) + ` LIMIT ?`
+	// fetch one extra row to learn whether another page follows
+	args = append(args, limit+1)
+	// run
+	logf(sqlstr, args...)
+	rows, err := db.QueryContext(ctx, sqlstr, args...)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*Permission
+	for rows.Next() {
+		p := Permission{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&p.PermissionID, &p.PermissionName, &p.CreatedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	more := len(res) > limit
+	if more {
+		res = res[:limit]
+	}
+	if backward {
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+	page := &PermissionPage{Items: res}
+	if len(res) == 0 {
+		return page, nil
+	}
+	// a backward page always has rows after it, and a forward page from a
+	// cursor always has rows before it
+	hasNext, hasPrev := more, cursor != ""
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+	if hasNext {
+		if page.NextCursor, err = permissionCursor(res[len(res)-1], column, order, false); err != nil {
+			return nil, logerror(err)
+		}
+	}
+	if hasPrev {
+		if page.PrevCursor, err = permissionCursor(res[0], column, order, true); err != nil {
+			return nil, logerror(err)
+		}
+	}
+	return page, nil
+}
+
+// permissionCursor encodes the position of p as a cursor for [PermissionCursorPage].
+func permissionCursor(p *Permission, column PermissionColumn, order string, backward bool) (string, error) {
+	var values []interface{}
+	for _, col := range permissionKeysetColumns(column) {
+		values = append(values, p.ColumnValue(col))
+	}
+	return encodeCursor(string(column), order, backward, values)
+}
+
+// PermissionCount returns the number of [Permission] records matching `where`.
+func PermissionCount(ctx context.Context, db DB, where Predicate[Permission]) (int64, error) {
+	cond, args := where.SQL()
+	sqlstr := `SELECT COUNT(*) FROM Permission WHERE ` + cond
+	// run
+	logf(sqlstr, args...)
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlstr, args...).Scan(&count); err != nil {
+		return 0, logerror(err)
+	}
+	return count, nil
+}
+
+// PermissionDeleteWhere deletes the [Permission] records matching `where`,
+// returning the number of records deleted. The zero [Predicate] is rejected with
+// [ErrEmptyPredicate] rather than deleting every record.
+func PermissionDeleteWhere(ctx context.Context, db DB, where Predicate[Permission]) (int64, error) {
+	if where.IsZero() {
+		return 0, logerror(ErrEmptyPredicate)
+	}
+	cond, args := where.SQL()
+	// with an audit hook or delete hooks the matching rows are locked and
+	// deleted one by one, so that each deletion is audited and hooked
+	if auditHook != nil || hasDeleteHooks(&Permission{}) {
+		sqlstr := `SELECT ` +
+			`permission_id, permission_name, created_at ` +
+			`FROM Permission WHERE (` + cond + `) FOR UPDATE`
+		logf(sqlstr, args...)
+		rows, err := db.QueryContext(ctx, sqlstr, args...)
+		if err != nil {
+			return 0, logerror(err)
+		}
+		var matched []*Permission
+		for rows.Next() {
+			p := Permission{
+				_exists: true,
+			}
+			if err := rows.Scan(&p.PermissionID, &p.PermissionName, &p.CreatedAt); err != nil {
+				rows.Close()
+				return 0, logerror(err)
+			}
+			matched = append(matched, &p)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return 0, logerror(err)
+		}
+		for _, p := range matched {
+			if err := p.Delete(ctx, db); err != nil {
+				return 0, err
+			}
+		}
+		return int64(len(matched)), nil
+	}
+	sqlstr := `DELETE FROM Permission WHERE ` + cond
+	// run
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
+		return 0, logerror(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, logerror(err)
+	}
+	return n, nil
+}
+
+// batchColumns returns the columns and values of the [Permission] in a
+// multi-row insert or upsert. As with [Permission.Insert], unset columns with a
+// database default are left out. Upserts also bind the generated primary key.
+func (p *Permission) batchColumns(upsert bool) ([]string, []interface{}) {
+	var columns []string
+	var args []interface{}
+	if upsert {
+		columns, args = append(columns, "permission_id"), append(args, p.PermissionID)
+	}
+	columns = append(columns, "permission_name")
+	args = append(args, p.PermissionName)
+	if !p.CreatedAt.IsZero() {
+		columns, args = append(columns, "created_at"), append(args, p.CreatedAt)
+	}
+	return columns, args
+}
+
+// readBackPermissions reads the values of the columns with a database default
+// back into rows, after a multi-row insert or upsert left some to the database.
+func readBackPermissions(ctx context.Context, db DB, rows []*Permission) error {
+	type key struct {
+		PermissionID int
+	}
+	byKey := make(map[key]*Permission, len(rows))
+	var args []interface{}
+	for _, p := range rows {
+		byKey[key{p.PermissionID}] = p
+		args = append(args, p.PermissionID)
+	}
+	sqlstr := `SELECT ` +
+		`permission_id, created_at ` +
+		`FROM Permission ` +
+		`WHERE (permission_id) IN (` + batchValues(1, len(rows)) + `)`
+	// run
+	logf(sqlstr, args...)
+	res, err := db.QueryContext(ctx, sqlstr, args...)
+	if err != nil {
+		return logerror(err)
+	}
+	defer res.Close()
+	for res.Next() {
+		var stored Permission
+		if err := res.Scan(&stored.PermissionID, &stored.CreatedAt); err != nil {
+			return logerror(err)
+		}
+		if p := byKey[key{stored.PermissionID}]; p != nil {
+			p.CreatedAt = stored.CreatedAt
+		}
+	}
+	if err := res.Err(); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// InsertPermissions inserts the [Permission] rows to the database with multi-row
+// statements, split under the limits of [SetBatchLimits]. As with
+// [Permission.Insert], the primary keys the database generates are set on
+// the rows, unset columns with a database default are read back, and the
+// hooks and the audit hook run for every row.
+func InsertPermissions(ctx context.Context, db DB, rows []*Permission) error {
+	for _, p := range rows {
+		switch {
+		case p._exists: // already exists
+			return logerror(&ErrInsertFailed{ErrAlreadyExists})
+		case p._deleted: // deleted
+			return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+		}
+		// run the BeforeInsert hook
+		if err := beforeInsert(ctx, db, p); err != nil {
+			return logerror(&ErrInsertFailed{err})
+		}
+	}
+	for _, b := range batches(rows, func(p *Permission) ([]string, []interface{}) {
+		return p.batchColumns(false)
+	}) {
+		// insert
+		sqlstr := `INSERT INTO Permission (` + strings.Join(b.columns, ", ") + `) ` +
+			`VALUES ` + batchValues(len(b.columns), len(b.rows))
+		// run
+		logf(sqlstr, b.args...)
+		res, err := db.ExecContext(ctx, sqlstr, b.args...)
+		if err != nil {
+			return logerror(err)
+		}
+		// retrieve ids, which MySQL generates consecutively for the rows of a
+		// multi-row insert, starting with the returned one, as long as
+		// auto_increment_increment is left at 1
+		id, err := res.LastInsertId()
+		if err != nil {
+			return logerror(err)
+		}
+		for i, p := range b.rows {
+			p.PermissionID = int(id + int64(i))
+		}
+		// read back the values the database assigned
+		if len(b.columns) < 2 {
+			if err := readBackPermissions(ctx, db, b.rows); err != nil {
+				return err
+			}
+		}
+	}
+	for _, p := range rows {
+		// set exists
+		p._exists = true
+		// run the AfterInsert hook
+		if err := afterInsert(ctx, db, p); err != nil {
+			return logerror(&ErrInsertFailed{err})
+		}
+		if err := p.audit(ctx, db, "insert", nil, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpsertPermissions performs an upsert of the [Permission] rows with multi-row
+// statements, split under the limits of [SetBatchLimits]. As with
+// [Permission.Upsert], unset columns with a database default are left to the
+// database or, on conflict, keep their stored values, and are read back. The
+// rows must carry their primary keys, as MySQL reports the generated key of
+// only one row of a multi-row upsert; insert new rows with [InsertPermissions]. With
+// an audit hook set, the stored rows are loaded one by one for their audit
+// entries.
+func UpsertPermissions(ctx context.Context, db DB, rows []*Permission) error {
+	befores := make([]*Permission, len(rows))
+	for i, p := range rows {
+		switch {
+		case p._deleted: // deleted
+			return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+		case p.PermissionID == 0: // primary key unset
+			return logerror(&ErrUpsertFailed{ErrPrimaryKeyUnset})
+		}
+		// run the BeforeInsert hook
+		if err := beforeInsert(ctx, db, p); err != nil {
+			return logerror(&ErrUpsertFailed{err})
+		}
+		before, err := p.auditBefore(ctx, db)
+		if err != nil {
+			return err
+		}
+		befores[i] = before
+	}
+	for _, b := range batches(rows, func(p *Permission) ([]string, []interface{}) {
+		return p.batchColumns(true)
+	}) {
+		// upsert, updating the columns other than the primary key on conflict
+		var update []string
+		for _, c := range b.columns {
+			switch c {
+			case "permission_id":
+			default:
+				update = append(update, c+" = VALUES("+c+")")
+			}
+		}
+		sqlstr := `INSERT INTO Permission (` + strings.Join(b.columns, ", ") + `) ` +
+			`VALUES ` + batchValues(len(b.columns), len(b.rows)) + ` ` +
+			`ON DUPLICATE KEY UPDATE ` + strings.Join(update, ", ")
+		// run
+		logf(sqlstr, b.args...)
+		if _, err := db.ExecContext(ctx, sqlstr, b.args...); err != nil {
+			return logerror(err)
+		}
+		// read back the values the database assigned or kept
+		if len(b.columns) < 3 {
+			if err := readBackPermissions(ctx, db, b.rows); err != nil {
+				return err
+			}
+		}
+	}
+	for i, p := range rows {
+		// set exists
+		p._exists = true
+		// run the AfterInsert hook
+		if err := afterInsert(ctx, db, p); err != nil {
+			return logerror(&ErrUpsertFailed{err})
+		}
+		if err := p.audit(ctx, db, "upsert", befores[i], p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeletePermissionsByIDs deletes the [Permission] records with the primary keys
+// ids, returning the number of records deleted. The ids are split into
+// statements under the limits of [SetBatchLimits], each deleting as
+// [PermissionDeleteWhere] does.
+func DeletePermissionsByIDs(ctx context.Context, db DB, ids []int) (int64, error) {
+	var n int64
+	for _, chunk := range chunks(ids, batchPlaceholders) {
+		deleted, err := PermissionDeleteWhere(ctx, db, PermissionFilter.PermissionID.In(chunk...))
+		n += deleted
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// PermissionByPermissionID retrieves a row from 'Permission' as a [Permission].
+//
+// Generated from index 'Permission_permission_id_pkey'.
+func PermissionByPermissionID(ctx context.Context, db DB, permissionID int) (*Permission, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`permission_id, permission_name, created_at ` +
+		`FROM Permission ` +
+		`WHERE permission_id = ?`
+	// run
+	logf(sqlstr, permissionID)
+	p := Permission{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, permissionID).Scan(&p.PermissionID, &p.PermissionName, &p.CreatedAt); err != nil {
+		return nil, logerror(err)
+	}
+	return &p, nil
+}
+
+// PermissionByPermissionName retrieves a row from 'Permission' as a [Permission].
+//
+// Generated from index 'permission_name'.
+func PermissionByPermissionName(ctx context.Context, db DB, permissionName string) (*Permission, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`permission_id, permission_name, created_at ` +
+		`FROM Permission ` +
+		`WHERE permission_name = ?`
+	// run
+	logf(sqlstr, permissionName)
+	p := Permission{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, permissionName).Scan(&p.PermissionID, &p.PermissionName, &p.CreatedAt); err != nil {
+		return nil, logerror(err)
+	}
+	return &p, nil
+}
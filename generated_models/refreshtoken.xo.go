@@ -0,0 +1,1055 @@
+package generated_models
+
+// Code generated by xo. DO NOT EDIT.
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RefreshToken represents a row from 'RefreshToken'.
+type RefreshToken struct {
+	RefreshTokenID int          `json:"refresh_token_id"` // refresh_token_id
+	UserID         int          `json:"user_id"`          // user_id
+	TokenHash      string       `json:"token_hash"`       // token_hash
+	ExpiresAt      time.Time    `json:"expires_at"`       // expires_at
+	CreatedAt      time.Time    `json:"created_at"`       // created_at
+	RevokedAt      sql.NullTime `json:"revoked_at"`       // revoked_at
+	// xo fields
+	_exists, _deleted bool
+}
+
+// Exists returns true when the [RefreshToken] exists in the database.
+func (rt *RefreshToken) Exists() bool {
+	return rt._exists
+}
+
+// Deleted returns true when the [RefreshToken] has been marked for deletion
+// from the database.
+func (rt *RefreshToken) Deleted() bool {
+	return rt._deleted
+}
+
+// auditBefore loads the stored [RefreshToken] as the before image of an audit
+// entry. It returns nil when no audit hook is set or no row is stored.
+func (rt *RefreshToken) auditBefore(ctx context.Context, db DB) (*RefreshToken, error) {
+	if auditHook == nil {
+		return nil, nil
+	}
+	const sqlstr = `SELECT ` +
+		`refresh_token_id, user_id, token_hash, expires_at, created_at, revoked_at ` +
+		`FROM RefreshToken ` +
+		`WHERE refresh_token_id = ?`
+	logf(sqlstr, rt.RefreshTokenID)
+	before := RefreshToken{
+		_exists: true,
+	}
+	switch err := db.QueryRowContext(ctx, sqlstr, rt.RefreshTokenID).Scan(&before.RefreshTokenID, &before.UserID, &before.TokenHash, &before.ExpiresAt, &before.CreatedAt, &before.RevokedAt); {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, logerror(err)
+	}
+	return &before, nil
+}
+
+// audit passes a mutation of the [RefreshToken] to the audit hook, with the row
+// before and after it, either of which may be nil.
+func (rt *RefreshToken) audit(ctx context.Context, db DB, action string, before, after *RefreshToken) error {
+	if auditHook == nil {
+		return nil
+	}
+	entry := AuditEntry{
+		Table:      "RefreshToken",
+		Action:     action,
+		PrimaryKey: auditKey(rt.RefreshTokenID),
+	}
+	// keep typed nils out of the interfaces
+	if before != nil {
+		entry.Before = before
+	}
+	if after != nil {
+		entry.After = after
+	}
+	if err := auditHook(ctx, db, entry); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// Insert inserts the [RefreshToken] to the database.
+func (rt *RefreshToken) Insert(ctx context.Context, db DB) error {
+	switch {
+	case rt._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case rt._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	// run the BeforeInsert hook
+	if err := beforeInsert(ctx, db, rt); err != nil {
+		return logerror(&ErrInsertFailed{err})
+	}
+	// insert (primary key generated and returned by database), leaving
+	// unset columns with a database default to the database
+	columns := []string{"user_id", "token_hash", "expires_at", "revoked_at"}
+	args := []interface{}{rt.UserID, rt.TokenHash, rt.ExpiresAt, rt.RevokedAt}
+	var defaults []string
+	var dest []interface{}
+	if rt.CreatedAt.IsZero() {
+		defaults, dest = append(defaults, "created_at"), append(dest, &rt.CreatedAt)
+	} else {
+		columns, args = append(columns, "created_at"), append(args, rt.CreatedAt)
+	}
+	sqlstr := `INSERT INTO RefreshToken (` + strings.Join(columns, ", ") + `) ` +
+		`VALUES (` + bindvars(len(args)) + `)`
+	// run
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
+		return logerror(err)
+	}
+	// retrieve id
+	id, err := res.LastInsertId()
+	if err != nil {
+		return logerror(err)
+	}
+	// set primary key
+	rt.RefreshTokenID = int(id)
+	// read back the values the database assigned
+	if len(defaults) != 0 {
+		sqlstr := `SELECT ` + strings.Join(defaults, ", ") + ` ` +
+			`FROM RefreshToken ` +
+			`WHERE refresh_token_id = ?`
+		logf(sqlstr, rt.RefreshTokenID)
+		if err := db.QueryRowContext(ctx, sqlstr, rt.RefreshTokenID).Scan(dest...); err != nil {
+			return logerror(err)
+		}
+	}
+	// set exists
+	rt._exists = true
+	// run the AfterInsert hook
+	if err := afterInsert(ctx, db, rt); err != nil {
+		return logerror(&ErrInsertFailed{err})
+	}
+	return rt.audit(ctx, db, "insert", nil, rt)
+}
+
+// Update updates a [RefreshToken] in the database.
+func (rt *RefreshToken) Update(ctx context.Context, db DB) error {
+	switch {
+	case !rt._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case rt._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// run the BeforeUpdate hook
+	if err := beforeUpdate(ctx, db, rt); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	before, err := rt.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// update with primary key, leaving unset columns
+	// with a database default unchanged
+	set := []string{"user_id = ?", "token_hash = ?", "expires_at = ?", "revoked_at = ?"}
+	args := []interface{}{rt.UserID, rt.TokenHash, rt.ExpiresAt, rt.RevokedAt}
+	if !rt.CreatedAt.IsZero() {
+		set, args = append(set, "created_at = ?"), append(args, rt.CreatedAt)
+	}
+	sqlstr := `UPDATE RefreshToken SET ` + strings.Join(set, ", ") + ` ` +
+		`WHERE refresh_token_id = ?`
+	args = append(args, rt.RefreshTokenID)
+	// run
+	logf(sqlstr, args...)
+	if _, err := db.ExecContext(ctx, sqlstr, args...); err != nil {
+		return logerror(err)
+	}
+	// run the AfterUpdate hook
+	if err := afterUpdate(ctx, db, rt); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	return rt.audit(ctx, db, "update", before, rt)
+}
+
+// Save saves the [RefreshToken] to the database.
+func (rt *RefreshToken) Save(ctx context.Context, db DB) error {
+	if rt.Exists() {
+		return rt.Update(ctx, db)
+	}
+	return rt.Insert(ctx, db)
+}
+
+// Upsert performs an upsert for [RefreshToken].
+func (rt *RefreshToken) Upsert(ctx context.Context, db DB) error {
+	switch {
+	case rt._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	// run the BeforeInsert hook
+	if err := beforeInsert(ctx, db, rt); err != nil {
+		return logerror(&ErrUpsertFailed{err})
+	}
+	before, err := rt.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// upsert, leaving unset columns with a database default to the database
+	// or, on conflict, to their stored values
+	columns := []string{"user_id", "token_hash", "expires_at", "revoked_at"}
+	args := []interface{}{rt.UserID, rt.TokenHash, rt.ExpiresAt, rt.RevokedAt}
+	update := []string{"user_id = VALUES(user_id)", "token_hash = VALUES(token_hash)", "expires_at = VALUES(expires_at)", "revoked_at = VALUES(revoked_at)"}
+	var defaults []string
+	var dest []interface{}
+	if rt.CreatedAt.IsZero() {
+		defaults, dest = append(defaults, "created_at"), append(dest, &rt.CreatedAt)
+	} else {
+		columns, args = append(columns, "created_at"), append(args, rt.CreatedAt)
+		update = append(update, "created_at = VALUES(created_at)")
+	}
+	// an unset refresh_token_id is generated by the database, or on conflict with
+	// another unique key is that of the stored row, and read back either way
+	if rt.RefreshTokenID != 0 {
+		columns, args = append(columns, "refresh_token_id"), append(args, rt.RefreshTokenID)
+	} else {
+		update = append(update, "refresh_token_id = LAST_INSERT_ID(refresh_token_id)")
+	}
+	sqlstr := `INSERT INTO RefreshToken (` + strings.Join(columns, ", ") + `) ` +
+		`VALUES (` + bindvars(len(args)) + `) ` +
+		`ON DUPLICATE KEY UPDATE ` + strings.Join(update, ", ")
+	// run
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
+		return logerror(err)
+	}
+	if rt.RefreshTokenID == 0 {
+		// retrieve id
+		id, err := res.LastInsertId()
+		if err != nil {
+			return logerror(err)
+		}
+		rt.RefreshTokenID = int(id)
+	}
+	// read back the values the database assigned or kept
+	if len(defaults) != 0 {
+		sqlstr := `SELECT ` + strings.Join(defaults, ", ") + ` ` +
+			`FROM RefreshToken ` +
+			`WHERE refresh_token_id = ?`
+		logf(sqlstr, rt.RefreshTokenID)
+		if err := db.QueryRowContext(ctx, sqlstr, rt.RefreshTokenID).Scan(dest...); err != nil {
+			return logerror(err)
+		}
+	}
+	// set exists
+	rt._exists = true
+	// run the AfterInsert hook
+	if err := afterInsert(ctx, db, rt); err != nil {
+		return logerror(&ErrUpsertFailed{err})
+	}
+	return rt.audit(ctx, db, "upsert", before, rt)
+}
+
+// Delete deletes the [RefreshToken] from the database.
+func (rt *RefreshToken) Delete(ctx context.Context, db DB) error {
+	switch {
+	case !rt._exists: // doesn't exist
+		return nil
+	case rt._deleted: // deleted
+		return nil
+	}
+	// run the BeforeDelete hook
+	if err := beforeDelete(ctx, db, rt); err != nil {
+		return logerror(err)
+	}
+	before, err := rt.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// delete with single primary key
+	const sqlstr = `DELETE FROM RefreshToken ` +
+		`WHERE refresh_token_id = ?`
+	// run
+	logf(sqlstr, rt.RefreshTokenID)
+	if _, err := db.ExecContext(ctx, sqlstr, rt.RefreshTokenID); err != nil {
+		return logerror(err)
+	}
+	// set deleted
+	rt._deleted = true
+	// run the AfterDelete hook
+	if err := afterDelete(ctx, db, rt); err != nil {
+		return logerror(err)
+	}
+	return rt.audit(ctx, db, "delete", before, nil)
+}
+
+// RefreshTokenColumn is a column name of 'RefreshToken'.
+type RefreshTokenColumn string
+
+// RefreshTokenColumn values.
+const (
+	// RefreshTokenColumnRefreshTokenID is the 'refresh_token_id' column.
+	RefreshTokenColumnRefreshTokenID RefreshTokenColumn = "refresh_token_id"
+	// RefreshTokenColumnUserID is the 'user_id' column.
+	RefreshTokenColumnUserID RefreshTokenColumn = "user_id"
+	// RefreshTokenColumnTokenHash is the 'token_hash' column.
+	RefreshTokenColumnTokenHash RefreshTokenColumn = "token_hash"
+	// RefreshTokenColumnExpiresAt is the 'expires_at' column.
+	RefreshTokenColumnExpiresAt RefreshTokenColumn = "expires_at"
+	// RefreshTokenColumnCreatedAt is the 'created_at' column.
+	RefreshTokenColumnCreatedAt RefreshTokenColumn = "created_at"
+	// RefreshTokenColumnRevokedAt is the 'revoked_at' column.
+	RefreshTokenColumnRevokedAt RefreshTokenColumn = "revoked_at"
+)
+
+// Valid returns true when the [RefreshTokenColumn] is a column of 'RefreshToken'.
+func (c RefreshTokenColumn) Valid() bool {
+	switch c {
+	case RefreshTokenColumnRefreshTokenID, RefreshTokenColumnUserID, RefreshTokenColumnTokenHash, RefreshTokenColumnExpiresAt, RefreshTokenColumnCreatedAt, RefreshTokenColumnRevokedAt:
+		return true
+	}
+	return false
+}
+
+// Nullable returns true when the [RefreshTokenColumn] is a column of
+// 'RefreshToken' that may hold NULL.
+func (c RefreshTokenColumn) Nullable() bool {
+	switch c {
+	case RefreshTokenColumnRevokedAt:
+		return true
+	}
+	return false
+}
+
+// ParseRefreshTokenColumn parses a client supplied column name, returning
+// [ErrUnknownColumn] when it is not a column of 'RefreshToken'.
+func ParseRefreshTokenColumn(s string) (RefreshTokenColumn, error) {
+	if c := RefreshTokenColumn(s); c.Valid() {
+		return c, nil
+	}
+	return "", &ErrUnknownColumn{Table: "RefreshToken", Column: s}
+}
+
+// ColumnValue returns the value of column for the [RefreshToken], or nil when
+// column is not a valid [RefreshTokenColumn].
+func (rt *RefreshToken) ColumnValue(column RefreshTokenColumn) interface{} {
+	switch column {
+	case RefreshTokenColumnRefreshTokenID:
+		return rt.RefreshTokenID
+	case RefreshTokenColumnUserID:
+		return rt.UserID
+	case RefreshTokenColumnTokenHash:
+		return rt.TokenHash
+	case RefreshTokenColumnExpiresAt:
+		return rt.ExpiresAt
+	case RefreshTokenColumnCreatedAt:
+		return rt.CreatedAt
+	case RefreshTokenColumnRevokedAt:
+		return rt.RevokedAt
+	}
+	return nil
+}
+
+// UpdateColumns updates only the listed columns of the [RefreshToken] in the
+// database. Unlisted columns keep their stored values, so columns the database
+// maintains itself, such as ON UPDATE CURRENT_TIMESTAMP, are left to it. The
+// row is then reloaded so the [RefreshToken] reflects the stored values.
+func (rt *RefreshToken) UpdateColumns(ctx context.Context, db DB, columns ...RefreshTokenColumn) error {
+	switch {
+	case !rt._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case rt._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// check the listed columns
+	var listed []RefreshTokenColumn
+	seen := make(map[RefreshTokenColumn]bool)
+	for _, c := range columns {
+		switch {
+		case !c.Valid():
+			return logerror(&ErrUpdateFailed{&ErrUnknownColumn{Table: "RefreshToken", Column: string(c)}})
+		case c == RefreshTokenColumnRefreshTokenID:
+			return logerror(&ErrUpdateFailed{ErrPrimaryKeyColumn})
+		case seen[c]:
+			continue
+		}
+		seen[c] = true
+		listed = append(listed, c)
+	}
+	if len(listed) == 0 {
+		return nil
+	}
+	// run the BeforeUpdate hook
+	if err := beforeUpdate(ctx, db, rt); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	// build the set list from the values the hook left
+	var set []string
+	var args []interface{}
+	for _, c := range listed {
+		set = append(set, string(c)+" = ?")
+		args = append(args, rt.ColumnValue(c))
+	}
+	before, err := rt.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// update with primary key
+	sqlstr := `UPDATE RefreshToken SET ` + strings.Join(set, ", ") + ` ` +
+		`WHERE refresh_token_id = ?`
+	args = append(args, rt.RefreshTokenID)
+	// run
+	logf(sqlstr, args...)
+	if _, err := db.ExecContext(ctx, sqlstr, args...); err != nil {
+		return logerror(err)
+	}
+	// reload
+	const selstr = `SELECT ` +
+		`refresh_token_id, user_id, token_hash, expires_at, created_at, revoked_at ` +
+		`FROM RefreshToken ` +
+		`WHERE refresh_token_id = ?`
+	logf(selstr, rt.RefreshTokenID)
+	if err := db.QueryRowContext(ctx, selstr, rt.RefreshTokenID).Scan(&rt.RefreshTokenID, &rt.UserID, &rt.TokenHash, &rt.ExpiresAt, &rt.CreatedAt, &rt.RevokedAt); err != nil {
+		return logerror(err)
+	}
+	// run the AfterUpdate hook
+	if err := afterUpdate(ctx, db, rt); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	return rt.audit(ctx, db, "update", before, rt)
+}
+
+// decodeRefreshTokenColumnValue decodes a JSON encoded value of column.
+func decodeRefreshTokenColumnValue(column RefreshTokenColumn, buf []byte) (interface{}, error) {
+	switch column {
+	case RefreshTokenColumnRefreshTokenID:
+		var v int
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case RefreshTokenColumnUserID:
+		var v int
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case RefreshTokenColumnTokenHash:
+		var v string
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case RefreshTokenColumnExpiresAt:
+		var v time.Time
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case RefreshTokenColumnCreatedAt:
+		var v time.Time
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case RefreshTokenColumnRevokedAt:
+		var v sql.NullTime
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	}
+	return nil, &ErrUnknownColumn{Table: "RefreshToken", Column: string(column)}
+}
+
+// RefreshTokenFilters holds a typed predicate builder for each column of 'RefreshToken'.
+type RefreshTokenFilters struct {
+	RefreshTokenID ColumnFilter[RefreshToken, int]
+	UserID         ColumnFilter[RefreshToken, int]
+	TokenHash      StringColumnFilter[RefreshToken]
+	ExpiresAt      ColumnFilter[RefreshToken, time.Time]
+	CreatedAt      ColumnFilter[RefreshToken, time.Time]
+	RevokedAt      ColumnFilter[RefreshToken, time.Time]
+}
+
+// RefreshTokenFilter builds predicates over 'RefreshToken' for
+// [RefreshTokenKeysetPage], [RefreshTokenCount] and [RefreshTokenDeleteWhere].
+var RefreshTokenFilter = RefreshTokenFilters{
+	RefreshTokenID: ColumnFilter[RefreshToken, int]{column: "refresh_token_id"},
+	UserID:         ColumnFilter[RefreshToken, int]{column: "user_id"},
+	TokenHash:      StringColumnFilter[RefreshToken]{ColumnFilter[RefreshToken, string]{column: "token_hash"}},
+	ExpiresAt:      ColumnFilter[RefreshToken, time.Time]{column: "expires_at"},
+	CreatedAt:      ColumnFilter[RefreshToken, time.Time]{column: "created_at"},
+	RevokedAt:      ColumnFilter[RefreshToken, time.Time]{column: "revoked_at"},
+}
+
+// RefreshTokenKeysetPage retrieves a page of [RefreshToken] records using keyset pagination with dynamic filtering.
+//
+// The keyset pagination retrieves results after or before a specific value (`key`)
+// for a given column (`column`) with a limit (`limit`) and order (`ASC` or `DESC`).
+//
+// If `order` is `ASC`, it retrieves records where the value of `column` is greater than `key`.
+// If `order` is `DESC`, it retrieves records where the value of `column` is less than `key`.
+//
+// Rows are further restricted by `where`, built from [RefreshTokenFilter]; the zero
+// [Predicate] applies no filter.
+//
+// `column` must be a valid [RefreshTokenColumn], otherwise [ErrUnknownColumn] is
+// returned before any SQL is built. Nullable columns return [ErrNullableColumn].
+func RefreshTokenKeysetPage(ctx context.Context, db DB, column RefreshTokenColumn, key interface{}, limit int, order string, where Predicate[RefreshToken]) ([]*RefreshToken, *RefreshToken, error) {
+	if order != "ASC" && order != "DESC" {
+		return nil, nil, fmt.Errorf("invalid order: %s", order)
+	}
+
+	// Only known columns may be interpolated into the query
+	if !column.Valid() {
+		return nil, nil, logerror(&ErrUnknownColumn{Table: "RefreshToken", Column: string(column)})
+	}
+	if column.Nullable() {
+		return nil, nil, logerror(ErrNullableColumn)
+	}
+
+	// Start building the query
+	query := fmt.Sprintf(
+		`SELECT refresh_token_id, user_id, token_hash, expires_at, created_at, revoked_at `+
+			`FROM RefreshToken WHERE %s %s ?`,
+		column, condition(order),
+	)
+
+	// Arguments for the query
+	args := []interface{}{key}
+
+	// Add the filter predicate
+	if !where.IsZero() {
+		cond, condArgs := where.SQL()
+		query += " AND (" + cond + ")"
+		args = append(args, condArgs...)
+	}
+
+	// Finalize the query with the order and limit
+	query += fmt.Sprintf(" ORDER BY %s %s LIMIT ?", column, order)
+	args = append(args, limit)
+
+	// Execute the query
+	logf(query, args...)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, logerror(err)
+	}
+	defer rows.Close()
+
+	var results []*RefreshToken
+	var lastItem *RefreshToken // Variable to store the last item
+
+	for rows.Next() {
+		rt := RefreshToken{
+			_exists: true,
+		}
+		if err := rows.Scan(
+			&rt.RefreshTokenID, &rt.UserID, &rt.TokenHash, &rt.ExpiresAt, &rt.CreatedAt, &rt.RevokedAt,
+		); err != nil {
+			return nil, nil, logerror(err)
+		}
+		results = append(results, &rt)
+	}
+
+	// Check for errors during row iteration.
+	if err := rows.Err(); err != nil {
+		return nil, nil, logerror(err)
+	}
+
+	// If we have results, set the lastItem to the last element in results.
+	if len(results) > 0 {
+		lastItem = results[len(results)-1]
+	}
+
+	return results, lastItem, nil
+}
+
+// RefreshTokenPage is a page of [RefreshToken] records returned by [RefreshTokenCursorPage].
+type RefreshTokenPage struct {
+	Items []*RefreshToken
+	// NextCursor retrieves the following page. It is empty on the last page.
+	NextCursor string
+	// PrevCursor retrieves the preceding page. It is empty on the first page.
+	PrevCursor string
+}
+
+// refreshTokenKeysetColumns returns column followed by the primary key columns
+// of 'RefreshToken' that break ties between equal values of column.
+func refreshTokenKeysetColumns(column RefreshTokenColumn) []RefreshTokenColumn {
+	columns := []RefreshTokenColumn{column}
+	if column != RefreshTokenColumnRefreshTokenID {
+		columns = append(columns, RefreshTokenColumnRefreshTokenID)
+	}
+	return columns
+}
+
+// RefreshTokenCursorPage retrieves a page of [RefreshToken] records ordered by
+// (`column`, primary key) in `order` (`ASC` or `DESC`), so that rows sharing a
+// value of `column` are neither skipped nor repeated between pages.
+//
+// An empty `cursor` retrieves the first page. Passing the returned NextCursor or
+// PrevCursor, together with the same `column` and `order`, retrieves the
+// following or preceding page. Cursors are opaque and return [ErrInvalidCursor]
+// when they are malformed or were issued for a different column or order.
+//
+// `column` must not be nullable, otherwise [ErrNullableColumn] is returned.
+// Rows are further restricted by `where`, built from [RefreshTokenFilter].
+func RefreshTokenCursorPage(ctx context.Context, db DB, column RefreshTokenColumn, order, cursor string, limit int, where Predicate[RefreshToken]) (*RefreshTokenPage, error) {
+	switch {
+	case order != "ASC" && order != "DESC":
+		return nil, fmt.Errorf("invalid order: %s", order)
+	case limit <= 0:
+		return nil, fmt.Errorf("invalid limit: %d", limit)
+	case !column.Valid():
+		return nil, logerror(&ErrUnknownColumn{Table: "RefreshToken", Column: string(column)})
+	case column.Nullable():
+		return nil, logerror(ErrNullableColumn)
+	}
+	columns := refreshTokenKeysetColumns(column)
+	// decode the boundary row
+	var backward bool
+	var conds []string
+	var args []interface{}
+	if cursor != "" {
+		c, err := decodeCursor(cursor, string(column), order)
+		if err != nil || len(c.Values) != len(columns) {
+			return nil, logerror(ErrInvalidCursor)
+		}
+		for i, col := range columns {
+			v, err := decodeRefreshTokenColumnValue(col, c.Values[i])
+			if err != nil {
+				return nil, logerror(ErrInvalidCursor)
+			}
+			args = append(args, v)
+		}
+		backward = c.Backward
+	}
+	// paging backward walks the index in reverse
+	dir := order
+	if backward {
+		dir = reverse(order)
+	}
+	if cursor != "" {
+		conds = append(conds, keysetCondition(columns, dir))
+	}
+	if !where.IsZero() {
+		cond, condArgs := where.SQL()
+		conds = append(conds, "("+cond+")")
+		args = append(args, condArgs...)
+	}
+	// query
+	sqlstr := `SELECT ` +
+		`refresh_token_id, user_id, token_hash, expires_at, created_at, revoked_at ` +
+		`FROM RefreshToken`
+	if len(conds) != 0 {
+		sqlstr += ` WHERE ` + strings.Join(conds, " AND ")
+	}
+	sqlstr += ` ORDER BY ` + keysetOrderBy(columns, dir) + ` LIMIT ?`
+	// fetch one extra row to learn whether another page follows
+	args = append(args, limit+1)
+	// run
+	logf(sqlstr, args...)
+	rows, err := db.QueryContext(ctx, sqlstr, args...)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*RefreshToken
+	for rows.Next() {
+		rt := RefreshToken{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&rt.RefreshTokenID, &rt.UserID, &rt.TokenHash, &rt.ExpiresAt, &rt.CreatedAt, &rt.RevokedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &rt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	more := len(res) > limit
+	if more {
+		res = res[:limit]
+	}
+	if backward {
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+	page := &RefreshTokenPage{Items: res}
+	if len(res) == 0 {
+		return page, nil
+	}
+	// a backward page always has rows after it, and a forward page from a
+	// cursor always has rows before it
+	hasNext, hasPrev := more, cursor != ""
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+	if hasNext {
+		if page.NextCursor, err = refreshTokenCursor(res[len(res)-1], column, order, false); err != nil {
+			return nil, logerror(err)
+		}
+	}
+	if hasPrev {
+		if page.PrevCursor, err = refreshTokenCursor(res[0], column, order, true); err != nil {
+			return nil, logerror(err)
+		}
+	}
+	return page, nil
+}
+
+// refreshTokenCursor encodes the position of rt as a cursor for [RefreshTokenCursorPage].
+func refreshTokenCursor(rt *RefreshToken, column RefreshTokenColumn, order string, backward bool) (string, error) {
+	var values []interface{}
+	for _, col := range refreshTokenKeysetColumns(column) {
+		values = append(values, rt.ColumnValue(col))
+	}
+	return encodeCursor(string(column), order, backward, values)
+}
+
+// RefreshTokenCount returns the number of [RefreshToken] records matching `where`.
+func RefreshTokenCount(ctx context.Context, db DB, where Predicate[RefreshToken]) (int64, error) {
+	cond, args := where.SQL()
+	sqlstr := `SELECT COUNT(*) FROM RefreshToken WHERE ` + cond
+	// run
+	logf(sqlstr, args...)
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlstr, args...).Scan(&count); err != nil {
+		return 0, logerror(err)
+	}
+	return count, nil
+}
+
+// RefreshTokenDeleteWhere deletes the [RefreshToken] records matching `where`,
+// returning the number of records deleted. The zero [Predicate] is rejected with
+// [ErrEmptyPredicate] rather than deleting every record.
+func RefreshTokenDeleteWhere(ctx context.Context, db DB, where Predicate[RefreshToken]) (int64, error) {
+	if where.IsZero() {
+		return 0, logerror(ErrEmptyPredicate)
+	}
+	cond, args := where.SQL()
+	// with an audit hook or delete hooks the matching rows are locked and
+	// deleted one by one, so that each deletion is audited and hooked
+	if auditHook != nil || hasDeleteHooks(&RefreshToken{}) {
+		sqlstr := `SELECT ` +
+			`refresh_token_id, user_id, token_hash, expires_at, created_at, revoked_at ` +
+			`FROM RefreshToken WHERE (` + cond + `) FOR UPDATE`
+		logf(sqlstr, args...)
+		rows, err := db.QueryContext(ctx, sqlstr, args...)
+		if err != nil {
+			return 0, logerror(err)
+		}
+		var matched []*RefreshToken
+		for rows.Next() {
+			rt := RefreshToken{
+				_exists: true,
+			}
+			if err := rows.Scan(&rt.RefreshTokenID, &rt.UserID, &rt.TokenHash, &rt.ExpiresAt, &rt.CreatedAt, &rt.RevokedAt); err != nil {
+				rows.Close()
+				return 0, logerror(err)
+			}
+			matched = append(matched, &rt)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return 0, logerror(err)
+		}
+		for _, rt := range matched {
+			if err := rt.Delete(ctx, db); err != nil {
+				return 0, err
+			}
+		}
+		return int64(len(matched)), nil
+	}
+	sqlstr := `DELETE FROM RefreshToken WHERE ` + cond
+	// run
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
+		return 0, logerror(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, logerror(err)
+	}
+	return n, nil
+}
+
+// batchColumns returns the columns and values of the [RefreshToken] in a
+// multi-row insert or upsert. As with [RefreshToken.Insert], unset columns with a
+// database default are left out. Upserts also bind the generated primary key.
+func (rt *RefreshToken) batchColumns(upsert bool) ([]string, []interface{}) {
+	var columns []string
+	var args []interface{}
+	if upsert {
+		columns, args = append(columns, "refresh_token_id"), append(args, rt.RefreshTokenID)
+	}
+	columns = append(columns, "user_id", "token_hash", "expires_at", "revoked_at")
+	args = append(args, rt.UserID, rt.TokenHash, rt.ExpiresAt, rt.RevokedAt)
+	if !rt.CreatedAt.IsZero() {
+		columns, args = append(columns, "created_at"), append(args, rt.CreatedAt)
+	}
+	return columns, args
+}
+
+// readBackRefreshTokens reads the values of the columns with a database default
+// back into rows, after a multi-row insert or upsert left some to the database.
+func readBackRefreshTokens(ctx context.Context, db DB, rows []*RefreshToken) error {
+	type key struct {
+		RefreshTokenID int
+	}
+	byKey := make(map[key]*RefreshToken, len(rows))
+	var args []interface{}
+	for _, rt := range rows {
+		byKey[key{rt.RefreshTokenID}] = rt
+		args = append(args, rt.RefreshTokenID)
+	}
+	sqlstr := `SELECT ` +
+		`refresh_token_id, created_at ` +
+		`FROM RefreshToken ` +
+		`WHERE (refresh_token_id) IN (` + batchValues(1, len(rows)) + `)`
+	// run
+	logf(sqlstr, args...)
+	res, err := db.QueryContext(ctx, sqlstr, args...)
+	if err != nil {
+		return logerror(err)
+	}
+	defer res.Close()
+	for res.Next() {
+		var stored RefreshToken
+		if err := res.Scan(&stored.RefreshTokenID, &stored.CreatedAt); err != nil {
+			return logerror(err)
+		}
+		if rt := byKey[key{stored.RefreshTokenID}]; rt != nil {
+			rt.CreatedAt = stored.CreatedAt
+		}
+	}
+	if err := res.Err(); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// InsertRefreshTokens inserts the [RefreshToken] rows to the database with multi-row
+// statements, split under the limits of [SetBatchLimits]. As with
+// [RefreshToken.Insert], the primary keys the database generates are set on
+// the rows, unset columns with a database default are read back, and the
+// hooks and the audit hook run for every row.
+func InsertRefreshTokens(ctx context.Context, db DB, rows []*RefreshToken) error {
+	for _, rt := range rows {
+		switch {
+		case rt._exists: // already exists
+			return logerror(&ErrInsertFailed{ErrAlreadyExists})
+		case rt._deleted: // deleted
+			return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+		}
+		// run the BeforeInsert hook
+		if err := beforeInsert(ctx, db, rt); err != nil {
+			return logerror(&ErrInsertFailed{err})
+		}
+	}
+	for _, b := range batches(rows, func(rt *RefreshToken) ([]string, []interface{}) {
+		return rt.batchColumns(false)
+	}) {
+		// insert
+		sqlstr := `INSERT INTO RefreshToken (` + strings.Join(b.columns, ", ") + `) ` +
+			`VALUES ` + batchValues(len(b.columns), len(b.rows))
+		// run
+		logf(sqlstr, b.args...)
+		res, err := db.ExecContext(ctx, sqlstr, b.args...)
+		if err != nil {
+			return logerror(err)
+		}
+		// retrieve ids, which MySQL generates consecutively for the rows of a
+		// multi-row insert, starting with the returned one, as long as
+		// auto_increment_increment is left at 1
+		id, err := res.LastInsertId()
+		if err != nil {
+			return logerror(err)
+		}
+		for i, rt := range b.rows {
+			rt.RefreshTokenID = int(id + int64(i))
+		}
+		// read back the values the database assigned
+		if len(b.columns) < 5 {
+			if err := readBackRefreshTokens(ctx, db, b.rows); err != nil {
+				return err
+			}
+		}
+	}
+	for _, rt := range rows {
+		// set exists
+		rt._exists = true
+		// run the AfterInsert hook
+		if err := afterInsert(ctx, db, rt); err != nil {
+			return logerror(&ErrInsertFailed{err})
+		}
+		if err := rt.audit(ctx, db, "insert", nil, rt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpsertRefreshTokens performs an upsert of the [RefreshToken] rows with multi-row
+// statements, split under the limits of [SetBatchLimits]. As with
+// [RefreshToken.Upsert], unset columns with a database default are left to the
+// database or, on conflict, keep their stored values, and are read back. The
+// rows must carry their primary keys, as MySQL reports the generated key of
+// only one row of a multi-row upsert; insert new rows with [InsertRefreshTokens]. With
+// an audit hook set, the stored rows are loaded one by one for their audit
+// entries.
+func UpsertRefreshTokens(ctx context.Context, db DB, rows []*RefreshToken) error {
+	befores := make([]*RefreshToken, len(rows))
+	for i, rt := range rows {
+		switch {
+		case rt._deleted: // deleted
+			return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+		case rt.RefreshTokenID == 0: // primary key unset
+			return logerror(&ErrUpsertFailed{ErrPrimaryKeyUnset})
+		}
+		// run the BeforeInsert hook
+		if err := beforeInsert(ctx, db, rt); err != nil {
+			return logerror(&ErrUpsertFailed{err})
+		}
+		before, err := rt.auditBefore(ctx, db)
+		if err != nil {
+			return err
+		}
+		befores[i] = before
+	}
+	for _, b := range batches(rows, func(rt *RefreshToken) ([]string, []interface{}) {
+		return rt.batchColumns(true)
+	}) {
+		// upsert, updating the columns other than the primary key on conflict
+		var update []string
+		for _, c := range b.columns {
+			switch c {
+			case "refresh_token_id":
+			default:
+				update = append(update, c+" = VALUES("+c+")")
+			}
+		}
+		sqlstr := `INSERT INTO RefreshToken (` + strings.Join(b.columns, ", ") + `) ` +
+			`VALUES ` + batchValues(len(b.columns), len(b.rows)) + ` ` +
+			`ON DUPLICATE KEY UPDATE ` + strings.Join(update, ", ")
+		// run
+		logf(sqlstr, b.args...)
+		if _, err := db.ExecContext(ctx, sqlstr, b.args...); err != nil {
+			return logerror(err)
+		}
+		// read back the values the database assigned or kept
+		if len(b.columns) < 6 {
+			if err := readBackRefreshTokens(ctx, db, b.rows); err != nil {
+				return err
+			}
+		}
+	}
+	for i, rt := range rows {
+		// set exists
+		rt._exists = true
+		// run the AfterInsert hook
+		if err := afterInsert(ctx, db, rt); err != nil {
+			return logerror(&ErrUpsertFailed{err})
+		}
+		if err := rt.audit(ctx, db, "upsert", befores[i], rt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteRefreshTokensByIDs deletes the [RefreshToken] records with the primary keys
+// ids, returning the number of records deleted. The ids are split into
+// statements under the limits of [SetBatchLimits], each deleting as
+// [RefreshTokenDeleteWhere] does.
+func DeleteRefreshTokensByIDs(ctx context.Context, db DB, ids []int) (int64, error) {
+	var n int64
+	for _, chunk := range chunks(ids, batchPlaceholders) {
+		deleted, err := RefreshTokenDeleteWhere(ctx, db, RefreshTokenFilter.RefreshTokenID.In(chunk...))
+		n += deleted
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// RefreshTokenByRefreshTokenID retrieves a row from 'RefreshToken' as a [RefreshToken].
+//
+// Generated from index 'RefreshToken_refresh_token_id_pkey'.
+func RefreshTokenByRefreshTokenID(ctx context.Context, db DB, refreshTokenID int) (*RefreshToken, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`refresh_token_id, user_id, token_hash, expires_at, created_at, revoked_at ` +
+		`FROM RefreshToken ` +
+		`WHERE refresh_token_id = ?`
+	// run
+	logf(sqlstr, refreshTokenID)
+	rt := RefreshToken{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, refreshTokenID).Scan(&rt.RefreshTokenID, &rt.UserID, &rt.TokenHash, &rt.ExpiresAt, &rt.CreatedAt, &rt.RevokedAt); err != nil {
+		return nil, logerror(err)
+	}
+	return &rt, nil
+}
+
+// RefreshTokenByTokenHash retrieves a row from 'RefreshToken' as a [RefreshToken].
+//
+// Generated from index 'token_hash'.
+func RefreshTokenByTokenHash(ctx context.Context, db DB, tokenHash string) (*RefreshToken, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`refresh_token_id, user_id, token_hash, expires_at, created_at, revoked_at ` +
+		`FROM RefreshToken ` +
+		`WHERE token_hash = ?`
+	// run
+	logf(sqlstr, tokenHash)
+	rt := RefreshToken{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, tokenHash).Scan(&rt.RefreshTokenID, &rt.UserID, &rt.TokenHash, &rt.ExpiresAt, &rt.CreatedAt, &rt.RevokedAt); err != nil {
+		return nil, logerror(err)
+	}
+	return &rt, nil
+}
+
+// RefreshTokenByUserID retrieves a row from 'RefreshToken' as a [RefreshToken].
+//
+// Generated from index 'user_id'.
+func RefreshTokenByUserID(ctx context.Context, db DB, userID int) ([]*RefreshToken, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`refresh_token_id, user_id, token_hash, expires_at, created_at, revoked_at ` +
+		`FROM RefreshToken ` +
+		`WHERE user_id = ?`
+	// run
+	logf(sqlstr, userID)
+	rows, err := db.QueryContext(ctx, sqlstr, userID)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*RefreshToken
+	for rows.Next() {
+		rt := RefreshToken{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&rt.RefreshTokenID, &rt.UserID, &rt.TokenHash, &rt.ExpiresAt, &rt.CreatedAt, &rt.RevokedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &rt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	return res, nil
+}
+
+// User returns the User associated with the [RefreshToken]'s (UserID).
+//
+// Generated from foreign key 'refreshtoken_ibfk_1'.
+func (rt *RefreshToken) User(ctx context.Context, db DB) (*User, error) {
+	return UserByUserID(ctx, db, rt.UserID)
+}
@@ -4,18 +4,22 @@ package generated_models
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"strings"
 	"time"
 )
 
 // Role represents a row from 'Role'.
 type Role struct {
-	RoleID    int       `json:"role_id"`    // role_id
-	RoleName  string    `json:"role_name"`  // role_name
-	CreatedAt time.Time `json:"created_at"` // created_at
-	UpdatedAt time.Time `json:"updated_at"` // updated_at
+	RoleID    int          `json:"role_id"`    // role_id
+	RoleName  string       `json:"role_name"`  // role_name
+	CreatedAt time.Time    `json:"created_at"` // created_at
+	UpdatedAt time.Time    `json:"updated_at"` // updated_at
+	DeletedAt sql.NullTime `json:"deleted_at"` // deleted_at
+	Version   int          `json:"version"`    // version
 	// xo fields
 	_exists, _deleted bool
 }
@@ -31,6 +35,53 @@ func (r *Role) Deleted() bool {
 	return r._deleted
 }
 
+// auditBefore loads the stored [Role] as the before image of an audit
+// entry. It returns nil when no audit hook is set or no row is stored.
+func (r *Role) auditBefore(ctx context.Context, db DB) (*Role, error) {
+	if auditHook == nil {
+		return nil, nil
+	}
+	const sqlstr = `SELECT ` +
+		`role_id, role_name, created_at, updated_at, deleted_at, version ` +
+		`FROM Role ` +
+		`WHERE role_id = ?`
+	logf(sqlstr, r.RoleID)
+	before := Role{
+		_exists: true,
+	}
+	switch err := db.QueryRowContext(ctx, sqlstr, r.RoleID).Scan(&before.RoleID, &before.RoleName, &before.CreatedAt, &before.UpdatedAt, &before.DeletedAt, &before.Version); {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, logerror(err)
+	}
+	return &before, nil
+}
+
+// audit passes a mutation of the [Role] to the audit hook, with the row
+// before and after it, either of which may be nil.
+func (r *Role) audit(ctx context.Context, db DB, action string, before, after *Role) error {
+	if auditHook == nil {
+		return nil
+	}
+	entry := AuditEntry{
+		Table:      "Role",
+		Action:     action,
+		PrimaryKey: auditKey(r.RoleID),
+	}
+	// keep typed nils out of the interfaces
+	if before != nil {
+		entry.Before = before
+	}
+	if after != nil {
+		entry.After = after
+	}
+	if err := auditHook(ctx, db, entry); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
 // Insert inserts the [Role] to the database.
 func (r *Role) Insert(ctx context.Context, db DB) error {
 	switch {
@@ -39,15 +90,36 @@ func (r *Role) Insert(ctx context.Context, db DB) error {
 	case r._deleted: // deleted
 		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
 	}
-	// insert (primary key generated and returned by database)
-	const sqlstr = `INSERT INTO Role (` +
-		`role_name, created_at, updated_at` +
-		`) VALUES (` +
-		`?, ?, ?` +
-		`)`
+	// run the BeforeInsert hook
+	if err := beforeInsert(ctx, db, r); err != nil {
+		return logerror(&ErrInsertFailed{err})
+	}
+	// insert (primary key generated and returned by database), leaving
+	// unset columns with a database default to the database
+	columns := []string{"role_name", "deleted_at"}
+	args := []interface{}{r.RoleName, r.DeletedAt}
+	var defaults []string
+	var dest []interface{}
+	if r.CreatedAt.IsZero() {
+		defaults, dest = append(defaults, "created_at"), append(dest, &r.CreatedAt)
+	} else {
+		columns, args = append(columns, "created_at"), append(args, r.CreatedAt)
+	}
+	if r.UpdatedAt.IsZero() {
+		defaults, dest = append(defaults, "updated_at"), append(dest, &r.UpdatedAt)
+	} else {
+		columns, args = append(columns, "updated_at"), append(args, r.UpdatedAt)
+	}
+	if r.Version == 0 {
+		defaults, dest = append(defaults, "version"), append(dest, &r.Version)
+	} else {
+		columns, args = append(columns, "version"), append(args, r.Version)
+	}
+	sqlstr := `INSERT INTO Role (` + strings.Join(columns, ", ") + `) ` +
+		`VALUES (` + bindvars(len(args)) + `)`
 	// run
-	logf(sqlstr, r.RoleName, r.CreatedAt, r.UpdatedAt)
-	res, err := db.ExecContext(ctx, sqlstr, r.RoleName, r.CreatedAt, r.UpdatedAt)
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
 	if err != nil {
 		return logerror(err)
 	}
@@ -55,11 +127,26 @@ func (r *Role) Insert(ctx context.Context, db DB) error {
 	id, err := res.LastInsertId()
 	if err != nil {
 		return logerror(err)
-	} // set primary key
+	}
+	// set primary key
 	r.RoleID = int(id)
+	// read back the values the database assigned
+	if len(defaults) != 0 {
+		sqlstr := `SELECT ` + strings.Join(defaults, ", ") + ` ` +
+			`FROM Role ` +
+			`WHERE role_id = ?`
+		logf(sqlstr, r.RoleID)
+		if err := db.QueryRowContext(ctx, sqlstr, r.RoleID).Scan(dest...); err != nil {
+			return logerror(err)
+		}
+	}
 	// set exists
 	r._exists = true
-	return nil
+	// run the AfterInsert hook
+	if err := afterInsert(ctx, db, r); err != nil {
+		return logerror(&ErrInsertFailed{err})
+	}
+	return r.audit(ctx, db, "insert", nil, r)
 }
 
 // Update updates a [Role] in the database.
@@ -70,16 +157,51 @@ func (r *Role) Update(ctx context.Context, db DB) error {
 	case r._deleted: // deleted
 		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
 	}
-	// update with primary key
-	const sqlstr = `UPDATE Role SET ` +
-		`role_name = ?, created_at = ?, updated_at = ? ` +
-		`WHERE role_id = ?`
+	// run the BeforeUpdate hook
+	if err := beforeUpdate(ctx, db, r); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	before, err := r.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// update with primary key and version, leaving unset columns
+	// with a database default unchanged
+	set := []string{"role_name = ?", "deleted_at = ?"}
+	args := []interface{}{r.RoleName, r.DeletedAt}
+	if !r.CreatedAt.IsZero() {
+		set, args = append(set, "created_at = ?"), append(args, r.CreatedAt)
+	}
+	set = append(set, "version = version + 1")
+	sqlstr := `UPDATE Role SET ` + strings.Join(set, ", ") + ` ` +
+		`WHERE role_id = ? AND version = ?`
+	args = append(args, r.RoleID, r.Version)
 	// run
-	logf(sqlstr, r.RoleName, r.CreatedAt, r.UpdatedAt, r.RoleID)
-	if _, err := db.ExecContext(ctx, sqlstr, r.RoleName, r.CreatedAt, r.UpdatedAt, r.RoleID); err != nil {
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
 		return logerror(err)
 	}
-	return nil
+	// no match means the row changed since it was read
+	switch n, err := res.RowsAffected(); {
+	case err != nil:
+		return logerror(err)
+	case n == 0:
+		return logerror(&ErrUpdateFailed{ErrStaleVersion})
+	}
+	r.Version++
+	// read back the updated_at the database set
+	const selstr = `SELECT updated_at FROM Role ` +
+		`WHERE role_id = ?`
+	logf(selstr, r.RoleID)
+	if err := db.QueryRowContext(ctx, selstr, r.RoleID).Scan(&r.UpdatedAt); err != nil {
+		return logerror(err)
+	}
+	// run the AfterUpdate hook
+	if err := afterUpdate(ctx, db, r); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	return r.audit(ctx, db, "update", before, r)
 }
 
 // Save saves the [Role] to the database.
@@ -96,25 +218,90 @@ func (r *Role) Upsert(ctx context.Context, db DB) error {
 	case r._deleted: // deleted
 		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
 	}
-	// upsert
-	const sqlstr = `INSERT INTO Role (` +
-		`role_id, role_name, created_at, updated_at` +
-		`) VALUES (` +
-		`?, ?, ?, ?` +
-		`)` +
-		` ON DUPLICATE KEY UPDATE ` +
-		`role_name = VALUES(role_name), created_at = VALUES(created_at), updated_at = VALUES(updated_at)`
+	// run the BeforeInsert hook
+	if err := beforeInsert(ctx, db, r); err != nil {
+		return logerror(&ErrUpsertFailed{err})
+	}
+	before, err := r.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// upsert, leaving unset columns with a database default to the database
+	// or, on conflict, to their stored values
+	columns := []string{"role_name", "deleted_at"}
+	args := []interface{}{r.RoleName, r.DeletedAt}
+	update := []string{"role_name = VALUES(role_name)"}
+	var defaults []string
+	var dest []interface{}
+	if r.CreatedAt.IsZero() {
+		defaults, dest = append(defaults, "created_at"), append(dest, &r.CreatedAt)
+	} else {
+		columns, args = append(columns, "created_at"), append(args, r.CreatedAt)
+		update = append(update, "created_at = VALUES(created_at)")
+	}
+	if r.UpdatedAt.IsZero() {
+		defaults, dest = append(defaults, "updated_at"), append(dest, &r.UpdatedAt)
+	} else {
+		columns, args = append(columns, "updated_at"), append(args, r.UpdatedAt)
+		// on conflict the database sets it instead
+		defaults, dest = append(defaults, "updated_at"), append(dest, &r.UpdatedAt)
+	}
+	if r.Version == 0 {
+		defaults, dest = append(defaults, "version"), append(dest, &r.Version)
+	} else {
+		columns, args = append(columns, "version"), append(args, r.Version)
+		update = append(update, "version = VALUES(version)")
+	}
+	// a conflicting soft deleted row stays deleted, see [Role.Restore]
+	defaults, dest = append(defaults, "deleted_at"), append(dest, &r.DeletedAt)
+	// an unset role_id is generated by the database, or on conflict with
+	// another unique key is that of the stored row, and read back either way
+	if r.RoleID != 0 {
+		columns, args = append(columns, "role_id"), append(args, r.RoleID)
+	} else {
+		update = append(update, "role_id = LAST_INSERT_ID(role_id)")
+	}
+	sqlstr := `INSERT INTO Role (` + strings.Join(columns, ", ") + `) ` +
+		`VALUES (` + bindvars(len(args)) + `) ` +
+		`ON DUPLICATE KEY UPDATE ` + strings.Join(update, ", ")
 	// run
-	logf(sqlstr, r.RoleID, r.RoleName, r.CreatedAt, r.UpdatedAt)
-	if _, err := db.ExecContext(ctx, sqlstr, r.RoleID, r.RoleName, r.CreatedAt, r.UpdatedAt); err != nil {
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
 		return logerror(err)
 	}
+	if r.RoleID == 0 {
+		// retrieve id
+		id, err := res.LastInsertId()
+		if err != nil {
+			return logerror(err)
+		}
+		r.RoleID = int(id)
+	}
+	// read back the values the database assigned or kept
+	if len(defaults) != 0 {
+		sqlstr := `SELECT ` + strings.Join(defaults, ", ") + ` ` +
+			`FROM Role ` +
+			`WHERE role_id = ?`
+		logf(sqlstr, r.RoleID)
+		if err := db.QueryRowContext(ctx, sqlstr, r.RoleID).Scan(dest...); err != nil {
+			return logerror(err)
+		}
+	}
+	r._deleted = r.DeletedAt.Valid
 	// set exists
 	r._exists = true
-	return nil
+	// run the AfterInsert hook
+	if err := afterInsert(ctx, db, r); err != nil {
+		return logerror(&ErrUpsertFailed{err})
+	}
+	return r.audit(ctx, db, "upsert", before, r)
 }
 
-// Delete deletes the [Role] from the database.
+// Delete soft deletes the [Role], setting its deleted_at to
+// the current time. Soft deleted rows are left out of lookups, pages and counts
+// until restored with [Role.Restore]. See [Role.HardDelete].
+// It returns sql.ErrNoRows when no stored row was left to delete.
 func (r *Role) Delete(ctx context.Context, db DB) error {
 	switch {
 	case !r._exists: // doesn't exist
@@ -122,6 +309,55 @@ func (r *Role) Delete(ctx context.Context, db DB) error {
 	case r._deleted: // deleted
 		return nil
 	}
+	// run the BeforeDelete hook
+	if err := beforeDelete(ctx, db, r); err != nil {
+		return logerror(err)
+	}
+	before, err := r.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// soft delete with primary key
+	const sqlstr = `UPDATE Role SET deleted_at = ? ` +
+		`WHERE role_id = ? AND deleted_at IS NULL`
+	now := time.Now()
+	// run
+	logf(sqlstr, now, r.RoleID)
+	res, err := db.ExecContext(ctx, sqlstr, now, r.RoleID)
+	if err != nil {
+		return logerror(err)
+	}
+	// no match means the row is gone or already soft deleted
+	switch n, err := res.RowsAffected(); {
+	case err != nil:
+		return logerror(err)
+	case n == 0:
+		return logerror(sql.ErrNoRows)
+	}
+	// set deleted
+	r.DeletedAt = sql.NullTime{Time: now, Valid: true}
+	r._deleted = true
+	// run the AfterDelete hook
+	if err := afterDelete(ctx, db, r); err != nil {
+		return logerror(err)
+	}
+	return r.audit(ctx, db, "delete", before, r)
+}
+
+// HardDelete permanently deletes the [Role] from the database, whether or not
+// it is soft deleted.
+func (r *Role) HardDelete(ctx context.Context, db DB) error {
+	if !r._exists { // doesn't exist
+		return nil
+	}
+	// run the BeforeDelete hook
+	if err := beforeDelete(ctx, db, r); err != nil {
+		return logerror(err)
+	}
+	before, err := r.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
 	// delete with single primary key
 	const sqlstr = `DELETE FROM Role ` +
 		`WHERE role_id = ?`
@@ -132,9 +368,256 @@ func (r *Role) Delete(ctx context.Context, db DB) error {
 	}
 	// set deleted
 	r._deleted = true
+	// run the AfterDelete hook
+	if err := afterDelete(ctx, db, r); err != nil {
+		return logerror(err)
+	}
+	return r.audit(ctx, db, "delete", before, nil)
+}
+
+// Restore undeletes the soft deleted [Role], clearing its deleted_at. Soft
+// deleted rows are retrieved with [RoleByRoleIDWithDeleted].
+func (r *Role) Restore(ctx context.Context, db DB) error {
+	if !r._exists { // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	}
+	before, err := r.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// restore with primary key
+	const sqlstr = `UPDATE Role SET deleted_at = NULL ` +
+		`WHERE role_id = ?`
+	// run
+	logf(sqlstr, r.RoleID)
+	if _, err := db.ExecContext(ctx, sqlstr, r.RoleID); err != nil {
+		return logerror(err)
+	}
+	// set restored
+	r.DeletedAt = sql.NullTime{}
+	r._deleted = false
+	return r.audit(ctx, db, "restore", before, r)
+}
+
+// RoleByRoleIDWithDeleted retrieves a row from 'Role' as a [Role]
+// by primary key, including a soft deleted row, which is marked as deleted.
+func RoleByRoleIDWithDeleted(ctx context.Context, db DB, roleID int) (*Role, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`role_id, role_name, created_at, updated_at, deleted_at, version ` +
+		`FROM Role ` +
+		`WHERE role_id = ?`
+	// run
+	logf(sqlstr, roleID)
+	r := Role{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, roleID).Scan(&r.RoleID, &r.RoleName, &r.CreatedAt, &r.UpdatedAt, &r.DeletedAt, &r.Version); err != nil {
+		return nil, logerror(err)
+	}
+	r._deleted = r.DeletedAt.Valid
+	return &r, nil
+}
+
+// RoleColumn is a column name of 'Role'.
+type RoleColumn string
+
+// RoleColumn values.
+const (
+	// RoleColumnRoleID is the 'role_id' column.
+	RoleColumnRoleID RoleColumn = "role_id"
+	// RoleColumnRoleName is the 'role_name' column.
+	RoleColumnRoleName RoleColumn = "role_name"
+	// RoleColumnCreatedAt is the 'created_at' column.
+	RoleColumnCreatedAt RoleColumn = "created_at"
+	// RoleColumnUpdatedAt is the 'updated_at' column.
+	RoleColumnUpdatedAt RoleColumn = "updated_at"
+	// RoleColumnDeletedAt is the 'deleted_at' column.
+	RoleColumnDeletedAt RoleColumn = "deleted_at"
+	// RoleColumnVersion is the 'version' column.
+	RoleColumnVersion RoleColumn = "version"
+)
+
+// Valid returns true when the [RoleColumn] is a column of 'Role'.
+func (c RoleColumn) Valid() bool {
+	switch c {
+	case RoleColumnRoleID, RoleColumnRoleName, RoleColumnCreatedAt, RoleColumnUpdatedAt, RoleColumnDeletedAt, RoleColumnVersion:
+		return true
+	}
+	return false
+}
+
+// Nullable returns true when the [RoleColumn] is a column of
+// 'Role' that may hold NULL.
+func (c RoleColumn) Nullable() bool {
+	switch c {
+	case RoleColumnDeletedAt:
+		return true
+	}
+	return false
+}
+
+// ParseRoleColumn parses a client supplied column name, returning
+// [ErrUnknownColumn] when it is not a column of 'Role'.
+func ParseRoleColumn(s string) (RoleColumn, error) {
+	if c := RoleColumn(s); c.Valid() {
+		return c, nil
+	}
+	return "", &ErrUnknownColumn{Table: "Role", Column: s}
+}
+
+// ColumnValue returns the value of column for the [Role], or nil when
+// column is not a valid [RoleColumn].
+func (r *Role) ColumnValue(column RoleColumn) interface{} {
+	switch column {
+	case RoleColumnRoleID:
+		return r.RoleID
+	case RoleColumnRoleName:
+		return r.RoleName
+	case RoleColumnCreatedAt:
+		return r.CreatedAt
+	case RoleColumnUpdatedAt:
+		return r.UpdatedAt
+	case RoleColumnDeletedAt:
+		return r.DeletedAt
+	case RoleColumnVersion:
+		return r.Version
+	}
 	return nil
 }
 
+// UpdateColumns updates only the listed columns of the [Role] in the
+// database. Unlisted columns keep their stored values, so columns the database
+// maintains itself, such as ON UPDATE CURRENT_TIMESTAMP, are left to it. The
+// row is then reloaded so the [Role] reflects the stored values.
+func (r *Role) UpdateColumns(ctx context.Context, db DB, columns ...RoleColumn) error {
+	switch {
+	case !r._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case r._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// check the listed columns
+	var listed []RoleColumn
+	seen := make(map[RoleColumn]bool)
+	for _, c := range columns {
+		switch {
+		case !c.Valid():
+			return logerror(&ErrUpdateFailed{&ErrUnknownColumn{Table: "Role", Column: string(c)}})
+		case c == RoleColumnRoleID:
+			return logerror(&ErrUpdateFailed{ErrPrimaryKeyColumn})
+		case c == RoleColumnVersion:
+			return logerror(&ErrUpdateFailed{ErrVersionColumn})
+		case seen[c]:
+			continue
+		}
+		seen[c] = true
+		listed = append(listed, c)
+	}
+	if len(listed) == 0 {
+		return nil
+	}
+	// run the BeforeUpdate hook
+	if err := beforeUpdate(ctx, db, r); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	// build the set list from the values the hook left
+	var set []string
+	var args []interface{}
+	for _, c := range listed {
+		set = append(set, string(c)+" = ?")
+		args = append(args, r.ColumnValue(c))
+	}
+	before, err := r.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	set = append(set, "version = version + 1")
+	// update with primary key and version
+	sqlstr := `UPDATE Role SET ` + strings.Join(set, ", ") + ` ` +
+		`WHERE role_id = ? AND version = ?`
+	args = append(args, r.RoleID, r.Version)
+	// run
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
+		return logerror(err)
+	}
+	// no match means the row changed since it was read
+	switch n, err := res.RowsAffected(); {
+	case err != nil:
+		return logerror(err)
+	case n == 0:
+		return logerror(&ErrUpdateFailed{ErrStaleVersion})
+	}
+	// reload
+	const selstr = `SELECT ` +
+		`role_id, role_name, created_at, updated_at, deleted_at, version ` +
+		`FROM Role ` +
+		`WHERE role_id = ?`
+	logf(selstr, r.RoleID)
+	if err := db.QueryRowContext(ctx, selstr, r.RoleID).Scan(&r.RoleID, &r.RoleName, &r.CreatedAt, &r.UpdatedAt, &r.DeletedAt, &r.Version); err != nil {
+		return logerror(err)
+	}
+	// run the AfterUpdate hook
+	if err := afterUpdate(ctx, db, r); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	return r.audit(ctx, db, "update", before, r)
+}
+
+// decodeRoleColumnValue decodes a JSON encoded value of column.
+func decodeRoleColumnValue(column RoleColumn, buf []byte) (interface{}, error) {
+	switch column {
+	case RoleColumnRoleID:
+		var v int
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case RoleColumnRoleName:
+		var v string
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case RoleColumnCreatedAt:
+		var v time.Time
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case RoleColumnUpdatedAt:
+		var v time.Time
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case RoleColumnDeletedAt:
+		var v sql.NullTime
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case RoleColumnVersion:
+		var v int
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	}
+	return nil, &ErrUnknownColumn{Table: "Role", Column: string(column)}
+}
+
+// RoleFilters holds a typed predicate builder for each column of 'Role'.
+type RoleFilters struct {
+	RoleID    ColumnFilter[Role, int]
+	RoleName  StringColumnFilter[Role]
+	CreatedAt ColumnFilter[Role, time.Time]
+	UpdatedAt ColumnFilter[Role, time.Time]
+	DeletedAt ColumnFilter[Role, time.Time]
+	Version   ColumnFilter[Role, int]
+}
+
+// RoleFilter builds predicates over 'Role' for
+// [RoleKeysetPage], [RoleCount] and [RoleDeleteWhere].
+var RoleFilter = RoleFilters{
+	RoleID:    ColumnFilter[Role, int]{column: "role_id"},
+	RoleName:  StringColumnFilter[Role]{ColumnFilter[Role, string]{column: "role_name"}},
+	CreatedAt: ColumnFilter[Role, time.Time]{column: "created_at"},
+	UpdatedAt: ColumnFilter[Role, time.Time]{column: "updated_at"},
+	DeletedAt: ColumnFilter[Role, time.Time]{column: "deleted_at"},
+	Version:   ColumnFilter[Role, int]{column: "version"},
+}
+
 // RoleKeysetPage retrieves a page of [Role] records using keyset pagination with dynamic filtering.
 //
 // The keyset pagination retrieves results after or before a specific value (`key`)
@@ -143,64 +626,50 @@ func (r *Role) Delete(ctx context.Context, db DB) error {
 // If `order` is `ASC`, it retrieves records where the value of `column` is greater than `key`.
 // If `order` is `DESC`, it retrieves records where the value of `column` is less than `key`.
 //
-// Filters are dynamically provided via a `filters` map, where keys are column names and values are either single values or slices for `IN` clauses.
-func RoleKeysetPage(ctx context.Context, db DB, column string, key interface{}, limit int, order string, filters map[string]interface{}) ([]*Role, *Role, error) {
+// Rows are further restricted by `where`, built from [RoleFilter]; the zero
+// [Predicate] applies no filter.
+//
+// `column` must be a valid [RoleColumn], otherwise [ErrUnknownColumn] is
+// returned before any SQL is built. Nullable columns return [ErrNullableColumn].
+func RoleKeysetPage(ctx context.Context, db DB, column RoleColumn, key interface{}, limit int, order string, where Predicate[Role]) ([]*Role, *Role, error) {
 	if order != "ASC" && order != "DESC" {
 		return nil, nil, fmt.Errorf("invalid order: %s", order)
 	}
 
+	// Only known columns may be interpolated into the query
+	if !column.Valid() {
+		return nil, nil, logerror(&ErrUnknownColumn{Table: "Role", Column: string(column)})
+	}
+	if column.Nullable() {
+		return nil, nil, logerror(ErrNullableColumn)
+	}
+
 	// Start building the query
 	query := fmt.Sprintf(
-		`SELECT * FROM Role 
-         WHERE %s %s ?`,
+		`SELECT role_id, role_name, created_at, updated_at, deleted_at, version `+
+			`FROM Role WHERE %s %s ?`,
 		column, condition(order),
 	)
 
+	// Leave out soft deleted rows
+	query += " AND deleted_at IS NULL"
+
 	// Arguments for the query
 	args := []interface{}{key}
 
-	// Dynamically add filters from the `filters` map to the query
-	for field, value := range filters {
-		switch v := value.(type) {
-		case []int:
-			if len(v) > 0 {
-				placeholders := make([]string, len(v))
-				for i := range v {
-					placeholders[i] = "?"
-					args = append(args, v[i])
-				}
-				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
-			}
-		case []string:
-			if len(v) > 0 {
-				placeholders := make([]string, len(v))
-				for i := range v {
-					placeholders[i] = "?"
-					args = append(args, v[i])
-				}
-				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
-			}
-		default:
-			// Handle NULL and NOT NULL checks
-			if value == nil {
-				query += fmt.Sprintf(" AND %s IS NULL", field)
-			} else if value == "NOT NULL" {
-				query += fmt.Sprintf(" AND %s IS NOT NULL", field)
-			} else {
-				query += fmt.Sprintf(" AND %s = ?", field)
-				args = append(args, value)
-			}
-		}
+	// Add the filter predicate
+	if !where.IsZero() {
+		cond, condArgs := where.SQL()
+		query += " AND (" + cond + ")"
+		args = append(args, condArgs...)
 	}
 
 	// Finalize the query with the order and limit
 	query += fmt.Sprintf(" ORDER BY %s %s LIMIT ?", column, order)
 	args = append(args, limit)
 
-	// Log the final query for debugging purposes
-	log.Printf("Executing query: %s with args: %v", query, args)
-
 	// Execute the query
+	logf(query, args...)
 	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, nil, logerror(err)
@@ -215,7 +684,7 @@ func RoleKeysetPage(ctx context.Context, db DB, column string, key interface{},
 			_exists: true,
 		}
 		if err := rows.Scan(
-			&r.RoleID, &r.RoleName, &r.CreatedAt, &r.UpdatedAt,
+			&r.RoleID, &r.RoleName, &r.CreatedAt, &r.UpdatedAt, &r.DeletedAt, &r.Version,
 		); err != nil {
 			return nil, nil, logerror(err)
 		}
@@ -235,21 +704,452 @@ func RoleKeysetPage(ctx context.Context, db DB, column string, key interface{},
 	return results, lastItem, nil
 }
 
+// RolePage is a page of [Role] records returned by [RoleCursorPage].
+type RolePage struct {
+	Items []*Role
+	// NextCursor retrieves the following page. It is empty on the last page.
+	NextCursor string
+	// PrevCursor retrieves the preceding page. It is empty on the first page.
+	PrevCursor string
+}
+
+// roleKeysetColumns returns column followed by the primary key columns
+// of 'Role' that break ties between equal values of column.
+func roleKeysetColumns(column RoleColumn) []RoleColumn {
+	columns := []RoleColumn{column}
+	if column != RoleColumnRoleID {
+		columns = append(columns, RoleColumnRoleID)
+	}
+	return columns
+}
+
+// RoleCursorPage retrieves a page of [Role] records ordered by
+// (`column`, primary key) in `order` (`ASC` or `DESC`), so that rows sharing a
+// value of `column` are neither skipped nor repeated between pages.
+//
+// An empty `cursor` retrieves the first page. Passing the returned NextCursor or
+// PrevCursor, together with the same `column` and `order`, retrieves the
+// following or preceding page. Cursors are opaque and return [ErrInvalidCursor]
+// when they are malformed or were issued for a different column or order.
+//
+// `column` must not be nullable, otherwise [ErrNullableColumn] is returned.
+// Rows are further restricted by `where`, built from [RoleFilter].
+func RoleCursorPage(ctx context.Context, db DB, column RoleColumn, order, cursor string, limit int, where Predicate[Role]) (*RolePage, error) {
+	switch {
+	case order != "ASC" && order != "DESC":
+		return nil, fmt.Errorf("invalid order: %s", order)
+	case limit <= 0:
+		return nil, fmt.Errorf("invalid limit: %d", limit)
+	case !column.Valid():
+		return nil, logerror(&ErrUnknownColumn{Table: "Role", Column: string(column)})
+	case column.Nullable():
+		return nil, logerror(ErrNullableColumn)
+	}
+	columns := roleKeysetColumns(column)
+	// decode the boundary row
+	var backward bool
+	var conds []string
+	var args []interface{}
+	if cursor != "" {
+		c, err := decodeCursor(cursor, string(column), order)
+		if err != nil || len(c.Values) != len(columns) {
+			return nil, logerror(ErrInvalidCursor)
+		}
+		for i, col := range columns {
+			v, err := decodeRoleColumnValue(col, c.Values[i])
+			if err != nil {
+				return nil, logerror(ErrInvalidCursor)
+			}
+			args = append(args, v)
+		}
+		backward = c.Backward
+	}
+	// paging backward walks the index in reverse
+	dir := order
+	if backward {
+		dir = reverse(order)
+	}
+	if cursor != "" {
+		conds = append(conds, keysetCondition(columns, dir))
+	}
+	// leave out soft deleted rows
+	conds = append(conds, "deleted_at IS NULL")
+	if !where.IsZero() {
+		cond, condArgs := where.SQL()
+		conds = append(conds, "("+cond+")")
+		args = append(args, condArgs...)
+	}
+	// query
+	sqlstr := `SELECT ` +
+		`role_id, role_name, created_at, updated_at, deleted_at, version ` +
+		`FROM Role`
+	if len(conds) != 0 {
+		sqlstr += ` WHERE ` + strings.Join(conds, " AND ")
+	}
+	sqlstr += ` ORDER BY ` + keysetOrderBy(columns, dir) + ` LIMIT ?`
+	// fetch one extra row to learn whether another page follows
+	args = append(args, limit+1)
+	// run
+	logf(sqlstr, args...)
+	rows, err := db.QueryContext(ctx, sqlstr, args...)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*Role
+	for rows.Next() {
+		r := Role{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&r.RoleID, &r.RoleName, &r.CreatedAt, &r.UpdatedAt, &r.DeletedAt, &r.Version); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	more := len(res) > limit
+	if more {
+		res = res[:limit]
+	}
+	if backward {
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+	page := &RolePage{Items: res}
+	if len(res) == 0 {
+		return page, nil
+	}
+	// a backward page always has rows after it, and a forward page from a
+	// cursor always has rows before it
+	hasNext, hasPrev := more, cursor != ""
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+	if hasNext {
+		if page.NextCursor, err = roleCursor(res[len(res)-1], column, order, false); err != nil {
+			return nil, logerror(err)
+		}
+	}
+	if hasPrev {
+		if page.PrevCursor, err = roleCursor(res[0], column, order, true); err != nil {
+			return nil, logerror(err)
+		}
+	}
+	return page, nil
+}
+
+// roleCursor encodes the position of r as a cursor for [RoleCursorPage].
+func roleCursor(r *Role, column RoleColumn, order string, backward bool) (string, error) {
+	var values []interface{}
+	for _, col := range roleKeysetColumns(column) {
+		values = append(values, r.ColumnValue(col))
+	}
+	return encodeCursor(string(column), order, backward, values)
+}
+
+// RoleCount returns the number of [Role] records matching `where`.
+func RoleCount(ctx context.Context, db DB, where Predicate[Role]) (int64, error) {
+	cond, args := where.SQL()
+	// leave out soft deleted rows
+	sqlstr := `SELECT COUNT(*) FROM Role WHERE (` + cond + `) AND deleted_at IS NULL`
+	// run
+	logf(sqlstr, args...)
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlstr, args...).Scan(&count); err != nil {
+		return 0, logerror(err)
+	}
+	return count, nil
+}
+
+// RoleDeleteWhere deletes the [Role] records matching `where`,
+// returning the number of records deleted. The zero [Predicate] is rejected with
+// [ErrEmptyPredicate] rather than deleting every record.
+//
+// Records are soft deleted, as with [Role.Delete].
+func RoleDeleteWhere(ctx context.Context, db DB, where Predicate[Role]) (int64, error) {
+	if where.IsZero() {
+		return 0, logerror(ErrEmptyPredicate)
+	}
+	cond, args := where.SQL()
+	// with an audit hook or delete hooks the matching rows are locked and
+	// deleted one by one, so that each deletion is audited and hooked
+	if auditHook != nil || hasDeleteHooks(&Role{}) {
+		sqlstr := `SELECT ` +
+			`role_id, role_name, created_at, updated_at, deleted_at, version ` +
+			`FROM Role WHERE (` + cond + `) AND deleted_at IS NULL FOR UPDATE`
+		logf(sqlstr, args...)
+		rows, err := db.QueryContext(ctx, sqlstr, args...)
+		if err != nil {
+			return 0, logerror(err)
+		}
+		var matched []*Role
+		for rows.Next() {
+			r := Role{
+				_exists: true,
+			}
+			if err := rows.Scan(&r.RoleID, &r.RoleName, &r.CreatedAt, &r.UpdatedAt, &r.DeletedAt, &r.Version); err != nil {
+				rows.Close()
+				return 0, logerror(err)
+			}
+			matched = append(matched, &r)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return 0, logerror(err)
+		}
+		for _, r := range matched {
+			if err := r.Delete(ctx, db); err != nil {
+				return 0, err
+			}
+		}
+		return int64(len(matched)), nil
+	}
+	// soft delete
+	sqlstr := `UPDATE Role SET deleted_at = ? WHERE (` + cond + `) AND deleted_at IS NULL`
+	args = append([]interface{}{time.Now()}, args...)
+	// run
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
+		return 0, logerror(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, logerror(err)
+	}
+	return n, nil
+}
+
+// batchColumns returns the columns and values of the [Role] in a
+// multi-row insert or upsert. As with [Role.Insert], unset columns with a
+// database default are left out. Upserts also bind the generated primary key.
+func (r *Role) batchColumns(upsert bool) ([]string, []interface{}) {
+	var columns []string
+	var args []interface{}
+	if upsert {
+		columns, args = append(columns, "role_id"), append(args, r.RoleID)
+	}
+	columns = append(columns, "role_name", "deleted_at")
+	args = append(args, r.RoleName, r.DeletedAt)
+	if !r.CreatedAt.IsZero() {
+		columns, args = append(columns, "created_at"), append(args, r.CreatedAt)
+	}
+	if !r.UpdatedAt.IsZero() {
+		columns, args = append(columns, "updated_at"), append(args, r.UpdatedAt)
+	}
+	if r.Version != 0 {
+		columns, args = append(columns, "version"), append(args, r.Version)
+	}
+	return columns, args
+}
+
+// readBackRoles reads the values of the columns with a database default
+// back into rows, after a multi-row insert or upsert left some to the database.
+// The soft delete column is read back too, as upserts keep that of a
+// conflicting row.
+func readBackRoles(ctx context.Context, db DB, rows []*Role) error {
+	type key struct {
+		RoleID int
+	}
+	byKey := make(map[key]*Role, len(rows))
+	var args []interface{}
+	for _, r := range rows {
+		byKey[key{r.RoleID}] = r
+		args = append(args, r.RoleID)
+	}
+	sqlstr := `SELECT ` +
+		`role_id, created_at, updated_at, version, deleted_at ` +
+		`FROM Role ` +
+		`WHERE (role_id) IN (` + batchValues(1, len(rows)) + `)`
+	// run
+	logf(sqlstr, args...)
+	res, err := db.QueryContext(ctx, sqlstr, args...)
+	if err != nil {
+		return logerror(err)
+	}
+	defer res.Close()
+	for res.Next() {
+		var stored Role
+		if err := res.Scan(&stored.RoleID, &stored.CreatedAt, &stored.UpdatedAt, &stored.Version, &stored.DeletedAt); err != nil {
+			return logerror(err)
+		}
+		if r := byKey[key{stored.RoleID}]; r != nil {
+			r.CreatedAt, r.UpdatedAt, r.Version, r.DeletedAt = stored.CreatedAt, stored.UpdatedAt, stored.Version, stored.DeletedAt
+		}
+	}
+	if err := res.Err(); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// InsertRoles inserts the [Role] rows to the database with multi-row
+// statements, split under the limits of [SetBatchLimits]. As with
+// [Role.Insert], the primary keys the database generates are set on
+// the rows, unset columns with a database default are read back, and the
+// hooks and the audit hook run for every row.
+func InsertRoles(ctx context.Context, db DB, rows []*Role) error {
+	for _, r := range rows {
+		switch {
+		case r._exists: // already exists
+			return logerror(&ErrInsertFailed{ErrAlreadyExists})
+		case r._deleted: // deleted
+			return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+		}
+		// run the BeforeInsert hook
+		if err := beforeInsert(ctx, db, r); err != nil {
+			return logerror(&ErrInsertFailed{err})
+		}
+	}
+	for _, b := range batches(rows, func(r *Role) ([]string, []interface{}) {
+		return r.batchColumns(false)
+	}) {
+		// insert
+		sqlstr := `INSERT INTO Role (` + strings.Join(b.columns, ", ") + `) ` +
+			`VALUES ` + batchValues(len(b.columns), len(b.rows))
+		// run
+		logf(sqlstr, b.args...)
+		res, err := db.ExecContext(ctx, sqlstr, b.args...)
+		if err != nil {
+			return logerror(err)
+		}
+		// retrieve ids, which MySQL generates consecutively for the rows of a
+		// multi-row insert, starting with the returned one, as long as
+		// auto_increment_increment is left at 1
+		id, err := res.LastInsertId()
+		if err != nil {
+			return logerror(err)
+		}
+		for i, r := range b.rows {
+			r.RoleID = int(id + int64(i))
+		}
+		// read back the values the database assigned
+		if len(b.columns) < 5 {
+			if err := readBackRoles(ctx, db, b.rows); err != nil {
+				return err
+			}
+		}
+	}
+	for _, r := range rows {
+		// set exists
+		r._exists = true
+		// run the AfterInsert hook
+		if err := afterInsert(ctx, db, r); err != nil {
+			return logerror(&ErrInsertFailed{err})
+		}
+		if err := r.audit(ctx, db, "insert", nil, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpsertRoles performs an upsert of the [Role] rows with multi-row
+// statements, split under the limits of [SetBatchLimits]. As with
+// [Role.Upsert], unset columns with a database default are left to the
+// database or, on conflict, keep their stored values, and are read back. The
+// rows must carry their primary keys, as MySQL reports the generated key of
+// only one row of a multi-row upsert; insert new rows with [InsertRoles]. With
+// an audit hook set, the stored rows are loaded one by one for their audit
+// entries.
+func UpsertRoles(ctx context.Context, db DB, rows []*Role) error {
+	befores := make([]*Role, len(rows))
+	for i, r := range rows {
+		switch {
+		case r._deleted: // deleted
+			return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+		case r.RoleID == 0: // primary key unset
+			return logerror(&ErrUpsertFailed{ErrPrimaryKeyUnset})
+		}
+		// run the BeforeInsert hook
+		if err := beforeInsert(ctx, db, r); err != nil {
+			return logerror(&ErrUpsertFailed{err})
+		}
+		before, err := r.auditBefore(ctx, db)
+		if err != nil {
+			return err
+		}
+		befores[i] = before
+	}
+	for _, b := range batches(rows, func(r *Role) ([]string, []interface{}) {
+		return r.batchColumns(true)
+	}) {
+		// upsert, updating the columns other than the primary key on conflict
+		var update []string
+		for _, c := range b.columns {
+			switch c {
+			case "role_id":
+			case "updated_at": // on conflict the database sets it instead
+			case "deleted_at": // a conflicting soft deleted row stays deleted
+			default:
+				update = append(update, c+" = VALUES("+c+")")
+			}
+		}
+		sqlstr := `INSERT INTO Role (` + strings.Join(b.columns, ", ") + `) ` +
+			`VALUES ` + batchValues(len(b.columns), len(b.rows)) + ` ` +
+			`ON DUPLICATE KEY UPDATE ` + strings.Join(update, ", ")
+		// run
+		logf(sqlstr, b.args...)
+		if _, err := db.ExecContext(ctx, sqlstr, b.args...); err != nil {
+			return logerror(err)
+		}
+		// read back the values the database assigned or kept
+		if err := readBackRoles(ctx, db, b.rows); err != nil {
+			return err
+		}
+	}
+	for i, r := range rows {
+		// set exists
+		r._exists = true
+		r._deleted = r.DeletedAt.Valid
+		// run the AfterInsert hook
+		if err := afterInsert(ctx, db, r); err != nil {
+			return logerror(&ErrUpsertFailed{err})
+		}
+		if err := r.audit(ctx, db, "upsert", befores[i], r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteRolesByIDs deletes the [Role] records with the primary keys
+// ids, returning the number of records deleted. The ids are split into
+// statements under the limits of [SetBatchLimits], each deleting as
+// [RoleDeleteWhere] does.
+func DeleteRolesByIDs(ctx context.Context, db DB, ids []int) (int64, error) {
+	var n int64
+	// leave a placeholder for the deleted_at of the soft delete
+	for _, chunk := range chunks(ids, batchPlaceholders-1) {
+		deleted, err := RoleDeleteWhere(ctx, db, RoleFilter.RoleID.In(chunk...))
+		n += deleted
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
 // RoleByRoleID retrieves a row from 'Role' as a [Role].
 //
 // Generated from index 'Role_role_id_pkey'.
 func RoleByRoleID(ctx context.Context, db DB, roleID int) (*Role, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`role_id, role_name, created_at, updated_at ` +
+		`role_id, role_name, created_at, updated_at, deleted_at, version ` +
 		`FROM Role ` +
-		`WHERE role_id = ?`
+		`WHERE role_id = ? AND deleted_at IS NULL`
 	// run
 	logf(sqlstr, roleID)
 	r := Role{
 		_exists: true,
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, roleID).Scan(&r.RoleID, &r.RoleName, &r.CreatedAt, &r.UpdatedAt); err != nil {
+	if err := db.QueryRowContext(ctx, sqlstr, roleID).Scan(&r.RoleID, &r.RoleName, &r.CreatedAt, &r.UpdatedAt, &r.DeletedAt, &r.Version); err != nil {
 		return nil, logerror(err)
 	}
 	return &r, nil
@@ -261,15 +1161,15 @@ func RoleByRoleID(ctx context.Context, db DB, roleID int) (*Role, error) {
 func RoleByRoleName(ctx context.Context, db DB, roleName string) (*Role, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`role_id, role_name, created_at, updated_at ` +
+		`role_id, role_name, created_at, updated_at, deleted_at, version ` +
 		`FROM Role ` +
-		`WHERE role_name = ?`
+		`WHERE role_name = ? AND deleted_at IS NULL`
 	// run
 	logf(sqlstr, roleName)
 	r := Role{
 		_exists: true,
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, roleName).Scan(&r.RoleID, &r.RoleName, &r.CreatedAt, &r.UpdatedAt); err != nil {
+	if err := db.QueryRowContext(ctx, sqlstr, roleName).Scan(&r.RoleID, &r.RoleName, &r.CreatedAt, &r.UpdatedAt, &r.DeletedAt, &r.Version); err != nil {
 		return nil, logerror(err)
 	}
 	return &r, nil
@@ -0,0 +1,1014 @@
+package generated_models
+
+// Code generated by xo. DO NOT EDIT.
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RolePermission represents a row from 'RolePermission'.
+type RolePermission struct {
+	RoleID       int       `json:"role_id"`       // role_id
+	PermissionID int       `json:"permission_id"` // permission_id
+	GrantedAt    time.Time `json:"granted_at"`    // granted_at
+	// xo fields
+	_exists, _deleted bool
+}
+
+// Exists returns true when the [RolePermission] exists in the database.
+func (rp *RolePermission) Exists() bool {
+	return rp._exists
+}
+
+// Deleted returns true when the [RolePermission] has been marked for deletion
+// from the database.
+func (rp *RolePermission) Deleted() bool {
+	return rp._deleted
+}
+
+// auditBefore loads the stored [RolePermission] as the before image of an audit
+// entry. It returns nil when no audit hook is set or no row is stored.
+func (rp *RolePermission) auditBefore(ctx context.Context, db DB) (*RolePermission, error) {
+	if auditHook == nil {
+		return nil, nil
+	}
+	const sqlstr = `SELECT ` +
+		`role_id, permission_id, granted_at ` +
+		`FROM RolePermission ` +
+		`WHERE role_id = ? AND permission_id = ?`
+	logf(sqlstr, rp.RoleID, rp.PermissionID)
+	before := RolePermission{
+		_exists: true,
+	}
+	switch err := db.QueryRowContext(ctx, sqlstr, rp.RoleID, rp.PermissionID).Scan(&before.RoleID, &before.PermissionID, &before.GrantedAt); {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, logerror(err)
+	}
+	return &before, nil
+}
+
+// audit passes a mutation of the [RolePermission] to the audit hook, with the row
+// before and after it, either of which may be nil.
+func (rp *RolePermission) audit(ctx context.Context, db DB, action string, before, after *RolePermission) error {
+	if auditHook == nil {
+		return nil
+	}
+	entry := AuditEntry{
+		Table:      "RolePermission",
+		Action:     action,
+		PrimaryKey: auditKey(rp.RoleID, rp.PermissionID),
+	}
+	// keep typed nils out of the interfaces
+	if before != nil {
+		entry.Before = before
+	}
+	if after != nil {
+		entry.After = after
+	}
+	if err := auditHook(ctx, db, entry); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// Insert inserts the [RolePermission] to the database.
+func (rp *RolePermission) Insert(ctx context.Context, db DB) error {
+	switch {
+	case rp._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case rp._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	// run the BeforeInsert hook
+	if err := beforeInsert(ctx, db, rp); err != nil {
+		return logerror(&ErrInsertFailed{err})
+	}
+	// insert (manual), leaving
+	// unset columns with a database default to the database
+	columns := []string{"role_id", "permission_id"}
+	args := []interface{}{rp.RoleID, rp.PermissionID}
+	var defaults []string
+	var dest []interface{}
+	if rp.GrantedAt.IsZero() {
+		defaults, dest = append(defaults, "granted_at"), append(dest, &rp.GrantedAt)
+	} else {
+		columns, args = append(columns, "granted_at"), append(args, rp.GrantedAt)
+	}
+	sqlstr := `INSERT INTO RolePermission (` + strings.Join(columns, ", ") + `) ` +
+		`VALUES (` + bindvars(len(args)) + `)`
+	// run
+	logf(sqlstr, args...)
+	if _, err := db.ExecContext(ctx, sqlstr, args...); err != nil {
+		return logerror(err)
+	}
+	// read back the values the database assigned
+	if len(defaults) != 0 {
+		sqlstr := `SELECT ` + strings.Join(defaults, ", ") + ` ` +
+			`FROM RolePermission ` +
+			`WHERE role_id = ? AND permission_id = ?`
+		logf(sqlstr, rp.RoleID, rp.PermissionID)
+		if err := db.QueryRowContext(ctx, sqlstr, rp.RoleID, rp.PermissionID).Scan(dest...); err != nil {
+			return logerror(err)
+		}
+	}
+	// set exists
+	rp._exists = true
+	// run the AfterInsert hook
+	if err := afterInsert(ctx, db, rp); err != nil {
+		return logerror(&ErrInsertFailed{err})
+	}
+	return rp.audit(ctx, db, "insert", nil, rp)
+}
+
+// Update updates a [RolePermission] in the database.
+func (rp *RolePermission) Update(ctx context.Context, db DB) error {
+	switch {
+	case !rp._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case rp._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// run the BeforeUpdate hook
+	if err := beforeUpdate(ctx, db, rp); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	before, err := rp.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// update with primary key, leaving unset columns
+	// with a database default unchanged
+	set := []string{}
+	args := []interface{}{}
+	if !rp.GrantedAt.IsZero() {
+		set, args = append(set, "granted_at = ?"), append(args, rp.GrantedAt)
+	}
+	sqlstr := `UPDATE RolePermission SET ` + strings.Join(set, ", ") + ` ` +
+		`WHERE role_id = ? AND permission_id = ?`
+	args = append(args, rp.RoleID, rp.PermissionID)
+	// run
+	if len(set) != 0 {
+		logf(sqlstr, args...)
+		if _, err := db.ExecContext(ctx, sqlstr, args...); err != nil {
+			return logerror(err)
+		}
+	}
+	// run the AfterUpdate hook
+	if err := afterUpdate(ctx, db, rp); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	return rp.audit(ctx, db, "update", before, rp)
+}
+
+// Save saves the [RolePermission] to the database.
+func (rp *RolePermission) Save(ctx context.Context, db DB) error {
+	if rp.Exists() {
+		return rp.Update(ctx, db)
+	}
+	return rp.Insert(ctx, db)
+}
+
+// Upsert performs an upsert for [RolePermission].
+func (rp *RolePermission) Upsert(ctx context.Context, db DB) error {
+	switch {
+	case rp._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	// run the BeforeInsert hook
+	if err := beforeInsert(ctx, db, rp); err != nil {
+		return logerror(&ErrUpsertFailed{err})
+	}
+	before, err := rp.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// upsert, leaving unset columns with a database default to the database
+	// or, on conflict, to their stored values
+	columns := []string{"role_id", "permission_id"}
+	args := []interface{}{rp.RoleID, rp.PermissionID}
+	update := []string{}
+	var defaults []string
+	var dest []interface{}
+	if rp.GrantedAt.IsZero() {
+		defaults, dest = append(defaults, "granted_at"), append(dest, &rp.GrantedAt)
+	} else {
+		columns, args = append(columns, "granted_at"), append(args, rp.GrantedAt)
+		update = append(update, "granted_at = VALUES(granted_at)")
+	}
+	if len(update) == 0 { // keep the stored row
+		update = append(update, "role_id = role_id")
+	}
+	sqlstr := `INSERT INTO RolePermission (` + strings.Join(columns, ", ") + `) ` +
+		`VALUES (` + bindvars(len(args)) + `) ` +
+		`ON DUPLICATE KEY UPDATE ` + strings.Join(update, ", ")
+	// run
+	logf(sqlstr, args...)
+	if _, err := db.ExecContext(ctx, sqlstr, args...); err != nil {
+		return logerror(err)
+	}
+	// read back the values the database assigned or kept
+	if len(defaults) != 0 {
+		sqlstr := `SELECT ` + strings.Join(defaults, ", ") + ` ` +
+			`FROM RolePermission ` +
+			`WHERE role_id = ? AND permission_id = ?`
+		logf(sqlstr, rp.RoleID, rp.PermissionID)
+		if err := db.QueryRowContext(ctx, sqlstr, rp.RoleID, rp.PermissionID).Scan(dest...); err != nil {
+			return logerror(err)
+		}
+	}
+	// set exists
+	rp._exists = true
+	// run the AfterInsert hook
+	if err := afterInsert(ctx, db, rp); err != nil {
+		return logerror(&ErrUpsertFailed{err})
+	}
+	return rp.audit(ctx, db, "upsert", before, rp)
+}
+
+// Delete deletes the [RolePermission] from the database.
+func (rp *RolePermission) Delete(ctx context.Context, db DB) error {
+	switch {
+	case !rp._exists: // doesn't exist
+		return nil
+	case rp._deleted: // deleted
+		return nil
+	}
+	// run the BeforeDelete hook
+	if err := beforeDelete(ctx, db, rp); err != nil {
+		return logerror(err)
+	}
+	before, err := rp.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// delete with composite primary key
+	const sqlstr = `DELETE FROM RolePermission ` +
+		`WHERE role_id = ? AND permission_id = ?`
+	// run
+	logf(sqlstr, rp.RoleID, rp.PermissionID)
+	if _, err := db.ExecContext(ctx, sqlstr, rp.RoleID, rp.PermissionID); err != nil {
+		return logerror(err)
+	}
+	// set deleted
+	rp._deleted = true
+	// run the AfterDelete hook
+	if err := afterDelete(ctx, db, rp); err != nil {
+		return logerror(err)
+	}
+	return rp.audit(ctx, db, "delete", before, nil)
+}
+
+// RolePermissionColumn is a column name of 'RolePermission'.
+type RolePermissionColumn string
+
+// RolePermissionColumn values.
+const (
+	// RolePermissionColumnRoleID is the 'role_id' column.
+	RolePermissionColumnRoleID RolePermissionColumn = "role_id"
+	// RolePermissionColumnPermissionID is the 'permission_id' column.
+	RolePermissionColumnPermissionID RolePermissionColumn = "permission_id"
+	// RolePermissionColumnGrantedAt is the 'granted_at' column.
+	RolePermissionColumnGrantedAt RolePermissionColumn = "granted_at"
+)
+
+// Valid returns true when the [RolePermissionColumn] is a column of 'RolePermission'.
+func (c RolePermissionColumn) Valid() bool {
+	switch c {
+	case RolePermissionColumnRoleID, RolePermissionColumnPermissionID, RolePermissionColumnGrantedAt:
+		return true
+	}
+	return false
+}
+
+// Nullable returns true when the [RolePermissionColumn] is a column of
+// 'RolePermission' that may hold NULL.
+func (c RolePermissionColumn) Nullable() bool {
+	return false
+}
+
+// ParseRolePermissionColumn parses a client supplied column name, returning
+// [ErrUnknownColumn] when it is not a column of 'RolePermission'.
+func ParseRolePermissionColumn(s string) (RolePermissionColumn, error) {
+	if c := RolePermissionColumn(s); c.Valid() {
+		return c, nil
+	}
+	return "", &ErrUnknownColumn{Table: "RolePermission", Column: s}
+}
+
+// ColumnValue returns the value of column for the [RolePermission], or nil when
+// column is not a valid [RolePermissionColumn].
+func (rp *RolePermission) ColumnValue(column RolePermissionColumn) interface{} {
+	switch column {
+	case RolePermissionColumnRoleID:
+		return rp.RoleID
+	case RolePermissionColumnPermissionID:
+		return rp.PermissionID
+	case RolePermissionColumnGrantedAt:
+		return rp.GrantedAt
+	}
+	return nil
+}
+
+// UpdateColumns updates only the listed columns of the [RolePermission] in the
+// database. Unlisted columns keep their stored values, so columns the database
+// maintains itself, such as ON UPDATE CURRENT_TIMESTAMP, are left to it. The
+// row is then reloaded so the [RolePermission] reflects the stored values.
+func (rp *RolePermission) UpdateColumns(ctx context.Context, db DB, columns ...RolePermissionColumn) error {
+	switch {
+	case !rp._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case rp._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// check the listed columns
+	var listed []RolePermissionColumn
+	seen := make(map[RolePermissionColumn]bool)
+	for _, c := range columns {
+		switch {
+		case !c.Valid():
+			return logerror(&ErrUpdateFailed{&ErrUnknownColumn{Table: "RolePermission", Column: string(c)}})
+		case c == RolePermissionColumnRoleID || c == RolePermissionColumnPermissionID:
+			return logerror(&ErrUpdateFailed{ErrPrimaryKeyColumn})
+		case seen[c]:
+			continue
+		}
+		seen[c] = true
+		listed = append(listed, c)
+	}
+	if len(listed) == 0 {
+		return nil
+	}
+	// run the BeforeUpdate hook
+	if err := beforeUpdate(ctx, db, rp); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	// build the set list from the values the hook left
+	var set []string
+	var args []interface{}
+	for _, c := range listed {
+		set = append(set, string(c)+" = ?")
+		args = append(args, rp.ColumnValue(c))
+	}
+	before, err := rp.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// update with primary key
+	sqlstr := `UPDATE RolePermission SET ` + strings.Join(set, ", ") + ` ` +
+		`WHERE role_id = ? AND permission_id = ?`
+	args = append(args, rp.RoleID, rp.PermissionID)
+	// run
+	logf(sqlstr, args...)
+	if _, err := db.ExecContext(ctx, sqlstr, args...); err != nil {
+		return logerror(err)
+	}
+	// reload
+	const selstr = `SELECT ` +
+		`role_id, permission_id, granted_at ` +
+		`FROM RolePermission ` +
+		`WHERE role_id = ? AND permission_id = ?`
+	logf(selstr, rp.RoleID, rp.PermissionID)
+	if err := db.QueryRowContext(ctx, selstr, rp.RoleID, rp.PermissionID).Scan(&rp.RoleID, &rp.PermissionID, &rp.GrantedAt); err != nil {
+		return logerror(err)
+	}
+	// run the AfterUpdate hook
+	if err := afterUpdate(ctx, db, rp); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	return rp.audit(ctx, db, "update", before, rp)
+}
+
+// decodeRolePermissionColumnValue decodes a JSON encoded value of column.
+func decodeRolePermissionColumnValue(column RolePermissionColumn, buf []byte) (interface{}, error) {
+	switch column {
+	case RolePermissionColumnRoleID:
+		var v int
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case RolePermissionColumnPermissionID:
+		var v int
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case RolePermissionColumnGrantedAt:
+		var v time.Time
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	}
+	return nil, &ErrUnknownColumn{Table: "RolePermission", Column: string(column)}
+}
+
+// RolePermissionFilters holds a typed predicate builder for each column of 'RolePermission'.
+type RolePermissionFilters struct {
+	RoleID       ColumnFilter[RolePermission, int]
+	PermissionID ColumnFilter[RolePermission, int]
+	GrantedAt    ColumnFilter[RolePermission, time.Time]
+}
+
+// RolePermissionFilter builds predicates over 'RolePermission' for
+// [RolePermissionKeysetPage], [RolePermissionCount] and [RolePermissionDeleteWhere].
+var RolePermissionFilter = RolePermissionFilters{
+	RoleID:       ColumnFilter[RolePermission, int]{column: "role_id"},
+	PermissionID: ColumnFilter[RolePermission, int]{column: "permission_id"},
+	GrantedAt:    ColumnFilter[RolePermission, time.Time]{column: "granted_at"},
+}
+
+// RolePermissionKeysetPage retrieves a page of [RolePermission] records using keyset pagination with dynamic filtering.
+//
+// The keyset pagination retrieves results after or before a specific value (`key`)
+// for a given column (`column`) with a limit (`limit`) and order (`ASC` or `DESC`).
+//
+// If `order` is `ASC`, it retrieves records where the value of `column` is greater than `key`.
+// If `order` is `DESC`, it retrieves records where the value of `column` is less than `key`.
+//
+// Rows are further restricted by `where`, built from [RolePermissionFilter]; the zero
+// [Predicate] applies no filter.
+//
+// `column` must be a valid [RolePermissionColumn], otherwise [ErrUnknownColumn] is
+// returned before any SQL is built. Nullable columns return [ErrNullableColumn].
+func RolePermissionKeysetPage(ctx context.Context, db DB, column RolePermissionColumn, key interface{}, limit int, order string, where Predicate[RolePermission]) ([]*RolePermission, *RolePermission, error) {
+	if order != "ASC" && order != "DESC" {
+		return nil, nil, fmt.Errorf("invalid order: %s", order)
+	}
+
+	// Only known columns may be interpolated into the query
+	if !column.Valid() {
+		return nil, nil, logerror(&ErrUnknownColumn{Table: "RolePermission", Column: string(column)})
+	}
+	if column.Nullable() {
+		return nil, nil, logerror(ErrNullableColumn)
+	}
+
+	// Start building the query
+	query := fmt.Sprintf(
+		`SELECT role_id, permission_id, granted_at `+
+			`FROM RolePermission WHERE %s %s ?`,
+		column, condition(order),
+	)
+
+	// Arguments for the query
+	args := []interface{}{key}
+
+	// Add the filter predicate
+	if !where.IsZero() {
+		cond, condArgs := where.SQL()
+		query += " AND (" + cond + ")"
+		args = append(args, condArgs...)
+	}
+
+	// Finalize the query with the order and limit
+	query += fmt.Sprintf(" ORDER BY %s %s LIMIT ?", column, order)
+	args = append(args, limit)
+
+	// Execute the query
+	logf(query, args...)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, logerror(err)
+	}
+	defer rows.Close()
+
+	var results []*RolePermission
+	var lastItem *RolePermission // Variable to store the last item
+
+	for rows.Next() {
+		rp := RolePermission{
+			_exists: true,
+		}
+		if err := rows.Scan(
+			&rp.RoleID, &rp.PermissionID, &rp.GrantedAt,
+		); err != nil {
+			return nil, nil, logerror(err)
+		}
+		results = append(results, &rp)
+	}
+
+	// Check for errors during row iteration.
+	if err := rows.Err(); err != nil {
+		return nil, nil, logerror(err)
+	}
+
+	// If we have results, set the lastItem to the last element in results.
+	if len(results) > 0 {
+		lastItem = results[len(results)-1]
+	}
+
+	return results, lastItem, nil
+}
+
+// RolePermissionPage is a page of [RolePermission] records returned by [RolePermissionCursorPage].
+type RolePermissionPage struct {
+	Items []*RolePermission
+	// NextCursor retrieves the following page. It is empty on the last page.
+	NextCursor string
+	// PrevCursor retrieves the preceding page. It is empty on the first page.
+	PrevCursor string
+}
+
+// rolePermissionKeysetColumns returns column followed by the primary key columns
+// of 'RolePermission' that break ties between equal values of column.
+func rolePermissionKeysetColumns(column RolePermissionColumn) []RolePermissionColumn {
+	columns := []RolePermissionColumn{column}
+	if column != RolePermissionColumnRoleID {
+		columns = append(columns, RolePermissionColumnRoleID)
+	}
+	if column != RolePermissionColumnPermissionID {
+		columns = append(columns, RolePermissionColumnPermissionID)
+	}
+	return columns
+}
+
+// RolePermissionCursorPage retrieves a page of [RolePermission] records ordered by
+// (`column`, primary key) in `order` (`ASC` or `DESC`), so that rows sharing a
+// value of `column` are neither skipped nor repeated between pages.
+//
+// An empty `cursor` retrieves the first page. Passing the returned NextCursor or
+// PrevCursor, together with the same `column` and `order`, retrieves the
+// following or preceding page. Cursors are opaque and return [ErrInvalidCursor]
+// when they are malformed or were issued for a different column or order.
+//
+// `column` must not be nullable, otherwise [ErrNullableColumn] is returned.
+// Rows are further restricted by `where`, built from [RolePermissionFilter].
+func RolePermissionCursorPage(ctx context.Context, db DB, column RolePermissionColumn, order, cursor string, limit int, where Predicate[RolePermission]) (*RolePermissionPage, error) {
+	switch {
+	case order != "ASC" && order != "DESC":
+		return nil, fmt.Errorf("invalid order: %s", order)
+	case limit <= 0:
+		return nil, fmt.Errorf("invalid limit: %d", limit)
+	case !column.Valid():
+		return nil, logerror(&ErrUnknownColumn{Table: "RolePermission", Column: string(column)})
+	case column.Nullable():
+		return nil, logerror(ErrNullableColumn)
+	}
+	columns := rolePermissionKeysetColumns(column)
+	// decode the boundary row
+	var backward bool
+	var conds []string
+	var args []interface{}
+	if cursor != "" {
+		c, err := decodeCursor(cursor, string(column), order)
+		if err != nil || len(c.Values) != len(columns) {
+			return nil, logerror(ErrInvalidCursor)
+		}
+		for i, col := range columns {
+			v, err := decodeRolePermissionColumnValue(col, c.Values[i])
+			if err != nil {
+				return nil, logerror(ErrInvalidCursor)
+			}
+			args = append(args, v)
+		}
+		backward = c.Backward
+	}
+	// paging backward walks the index in reverse
+	dir := order
+	if backward {
+		dir = reverse(order)
+	}
+	if cursor != "" {
+		conds = append(conds, keysetCondition(columns, dir))
+	}
+	if !where.IsZero() {
+		cond, condArgs := where.SQL()
+		conds = append(conds, "("+cond+")")
+		args = append(args, condArgs...)
+	}
+	// query
+	sqlstr := `SELECT ` +
+		`role_id, permission_id, granted_at ` +
+		`FROM RolePermission`
+	if len(conds) != 0 {
+		sqlstr += ` WHERE ` + strings.Join(conds, " AND ")
+	}
+	sqlstr += ` ORDER BY ` + keysetOrderBy(columns, dir) + ` LIMIT ?`
+	// fetch one extra row to learn whether another page follows
+	args = append(args, limit+1)
+	// run
+	logf(sqlstr, args...)
+	rows, err := db.QueryContext(ctx, sqlstr, args...)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*RolePermission
+	for rows.Next() {
+		rp := RolePermission{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&rp.RoleID, &rp.PermissionID, &rp.GrantedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &rp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	more := len(res) > limit
+	if more {
+		res = res[:limit]
+	}
+	if backward {
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+	page := &RolePermissionPage{Items: res}
+	if len(res) == 0 {
+		return page, nil
+	}
+	// a backward page always has rows after it, and a forward page from a
+	// cursor always has rows before it
+	hasNext, hasPrev := more, cursor != ""
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+	if hasNext {
+		if page.NextCursor, err = rolePermissionCursor(res[len(res)-1], column, order, false); err != nil {
+			return nil, logerror(err)
+		}
+	}
+	if hasPrev {
+		if page.PrevCursor, err = rolePermissionCursor(res[0], column, order, true); err != nil {
+			return nil, logerror(err)
+		}
+	}
+	return page, nil
+}
+
+// rolePermissionCursor encodes the position of rp as a cursor for [RolePermissionCursorPage].
+func rolePermissionCursor(rp *RolePermission, column RolePermissionColumn, order string, backward bool) (string, error) {
+	var values []interface{}
+	for _, col := range rolePermissionKeysetColumns(column) {
+		values = append(values, rp.ColumnValue(col))
+	}
+	return encodeCursor(string(column), order, backward, values)
+}
+
+// RolePermissionCount returns the number of [RolePermission] records matching `where`.
+func RolePermissionCount(ctx context.Context, db DB, where Predicate[RolePermission]) (int64, error) {
+	cond, args := where.SQL()
+	sqlstr := `SELECT COUNT(*) FROM RolePermission WHERE ` + cond
+	// run
+	logf(sqlstr, args...)
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlstr, args...).Scan(&count); err != nil {
+		return 0, logerror(err)
+	}
+	return count, nil
+}
+
+// RolePermissionDeleteWhere deletes the [RolePermission] records matching `where`,
+// returning the number of records deleted. The zero [Predicate] is rejected with
+// [ErrEmptyPredicate] rather than deleting every record.
+func RolePermissionDeleteWhere(ctx context.Context, db DB, where Predicate[RolePermission]) (int64, error) {
+	if where.IsZero() {
+		return 0, logerror(ErrEmptyPredicate)
+	}
+	cond, args := where.SQL()
+	// with an audit hook or delete hooks the matching rows are locked and
+	// deleted one by one, so that each deletion is audited and hooked
+	if auditHook != nil || hasDeleteHooks(&RolePermission{}) {
+		sqlstr := `SELECT ` +
+			`role_id, permission_id, granted_at ` +
+			`FROM RolePermission WHERE (` + cond + `) FOR UPDATE`
+		logf(sqlstr, args...)
+		rows, err := db.QueryContext(ctx, sqlstr, args...)
+		if err != nil {
+			return 0, logerror(err)
+		}
+		var matched []*RolePermission
+		for rows.Next() {
+			rp := RolePermission{
+				_exists: true,
+			}
+			if err := rows.Scan(&rp.RoleID, &rp.PermissionID, &rp.GrantedAt); err != nil {
+				rows.Close()
+				return 0, logerror(err)
+			}
+			matched = append(matched, &rp)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return 0, logerror(err)
+		}
+		for _, rp := range matched {
+			if err := rp.Delete(ctx, db); err != nil {
+				return 0, err
+			}
+		}
+		return int64(len(matched)), nil
+	}
+	sqlstr := `DELETE FROM RolePermission WHERE ` + cond
+	// run
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
+		return 0, logerror(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, logerror(err)
+	}
+	return n, nil
+}
+
+// batchColumns returns the columns and values of the [RolePermission] in a
+// multi-row insert or upsert. As with [RolePermission.Insert], unset columns with a
+// database default are left out.
+func (rp *RolePermission) batchColumns(upsert bool) ([]string, []interface{}) {
+	columns := []string{"role_id", "permission_id"}
+	args := []interface{}{rp.RoleID, rp.PermissionID}
+	if !rp.GrantedAt.IsZero() {
+		columns, args = append(columns, "granted_at"), append(args, rp.GrantedAt)
+	}
+	return columns, args
+}
+
+// readBackRolePermissions reads the values of the columns with a database default
+// back into rows, after a multi-row insert or upsert left some to the database.
+func readBackRolePermissions(ctx context.Context, db DB, rows []*RolePermission) error {
+	type key struct {
+		RoleID       int
+		PermissionID int
+	}
+	byKey := make(map[key]*RolePermission, len(rows))
+	var args []interface{}
+	for _, rp := range rows {
+		byKey[key{rp.RoleID, rp.PermissionID}] = rp
+		args = append(args, rp.RoleID, rp.PermissionID)
+	}
+	sqlstr := `SELECT ` +
+		`role_id, permission_id, granted_at ` +
+		`FROM RolePermission ` +
+		`WHERE (role_id, permission_id) IN (` + batchValues(2, len(rows)) + `)`
+	// run
+	logf(sqlstr, args...)
+	res, err := db.QueryContext(ctx, sqlstr, args...)
+	if err != nil {
+		return logerror(err)
+	}
+	defer res.Close()
+	for res.Next() {
+		var stored RolePermission
+		if err := res.Scan(&stored.RoleID, &stored.PermissionID, &stored.GrantedAt); err != nil {
+			return logerror(err)
+		}
+		if rp := byKey[key{stored.RoleID, stored.PermissionID}]; rp != nil {
+			rp.GrantedAt = stored.GrantedAt
+		}
+	}
+	if err := res.Err(); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// InsertRolePermissions inserts the [RolePermission] rows to the database with multi-row
+// statements, split under the limits of [SetBatchLimits]. As with
+// [RolePermission.Insert], unset columns with a database default are read back, and the
+// hooks and the audit hook run for every row.
+func InsertRolePermissions(ctx context.Context, db DB, rows []*RolePermission) error {
+	for _, rp := range rows {
+		switch {
+		case rp._exists: // already exists
+			return logerror(&ErrInsertFailed{ErrAlreadyExists})
+		case rp._deleted: // deleted
+			return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+		}
+		// run the BeforeInsert hook
+		if err := beforeInsert(ctx, db, rp); err != nil {
+			return logerror(&ErrInsertFailed{err})
+		}
+	}
+	for _, b := range batches(rows, func(rp *RolePermission) ([]string, []interface{}) {
+		return rp.batchColumns(false)
+	}) {
+		// insert
+		sqlstr := `INSERT INTO RolePermission (` + strings.Join(b.columns, ", ") + `) ` +
+			`VALUES ` + batchValues(len(b.columns), len(b.rows))
+		// run
+		logf(sqlstr, b.args...)
+		if _, err := db.ExecContext(ctx, sqlstr, b.args...); err != nil {
+			return logerror(err)
+		}
+		// read back the values the database assigned
+		if len(b.columns) < 3 {
+			if err := readBackRolePermissions(ctx, db, b.rows); err != nil {
+				return err
+			}
+		}
+	}
+	for _, rp := range rows {
+		// set exists
+		rp._exists = true
+		// run the AfterInsert hook
+		if err := afterInsert(ctx, db, rp); err != nil {
+			return logerror(&ErrInsertFailed{err})
+		}
+		if err := rp.audit(ctx, db, "insert", nil, rp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpsertRolePermissions performs an upsert of the [RolePermission] rows with multi-row
+// statements, split under the limits of [SetBatchLimits]. As with
+// [RolePermission.Upsert], unset columns with a database default are left to the
+// database or, on conflict, keep their stored values, and are read back. With
+// an audit hook set, the stored rows are loaded one by one for their audit
+// entries.
+func UpsertRolePermissions(ctx context.Context, db DB, rows []*RolePermission) error {
+	befores := make([]*RolePermission, len(rows))
+	for i, rp := range rows {
+		switch {
+		case rp._deleted: // deleted
+			return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+		}
+		// run the BeforeInsert hook
+		if err := beforeInsert(ctx, db, rp); err != nil {
+			return logerror(&ErrUpsertFailed{err})
+		}
+		before, err := rp.auditBefore(ctx, db)
+		if err != nil {
+			return err
+		}
+		befores[i] = before
+	}
+	for _, b := range batches(rows, func(rp *RolePermission) ([]string, []interface{}) {
+		return rp.batchColumns(true)
+	}) {
+		// upsert, updating the columns other than the primary key on conflict
+		var update []string
+		for _, c := range b.columns {
+			switch c {
+			case "role_id", "permission_id":
+			default:
+				update = append(update, c+" = VALUES("+c+")")
+			}
+		}
+		if len(update) == 0 { // keep the stored rows
+			update = append(update, "role_id = role_id")
+		}
+		sqlstr := `INSERT INTO RolePermission (` + strings.Join(b.columns, ", ") + `) ` +
+			`VALUES ` + batchValues(len(b.columns), len(b.rows)) + ` ` +
+			`ON DUPLICATE KEY UPDATE ` + strings.Join(update, ", ")
+		// run
+		logf(sqlstr, b.args...)
+		if _, err := db.ExecContext(ctx, sqlstr, b.args...); err != nil {
+			return logerror(err)
+		}
+		// read back the values the database assigned or kept
+		if len(b.columns) < 3 {
+			if err := readBackRolePermissions(ctx, db, b.rows); err != nil {
+				return err
+			}
+		}
+	}
+	for i, rp := range rows {
+		// set exists
+		rp._exists = true
+		// run the AfterInsert hook
+		if err := afterInsert(ctx, db, rp); err != nil {
+			return logerror(&ErrUpsertFailed{err})
+		}
+		if err := rp.audit(ctx, db, "upsert", befores[i], rp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteRolePermissions deletes the [RolePermission] records with the primary keys of
+// rows, returning the number of records deleted. The rows are split into
+// statements under the limits of [SetBatchLimits], each deleting as
+// [RolePermissionDeleteWhere] does.
+func DeleteRolePermissions(ctx context.Context, db DB, rows []*RolePermission) (int64, error) {
+	var n int64
+	for _, chunk := range chunks(rows, batchPlaceholders/2) {
+		var args []interface{}
+		for _, rp := range chunk {
+			args = append(args, rp.RoleID, rp.PermissionID)
+		}
+		where := Predicate[RolePermission]{
+			sql:  `(role_id, permission_id) IN (` + batchValues(2, len(chunk)) + `)`,
+			args: args,
+		}
+		deleted, err := RolePermissionDeleteWhere(ctx, db, where)
+		n += deleted
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// RolePermissionByPermissionID retrieves a row from 'RolePermission' as a [RolePermission].
+//
+// Generated from index 'permission_id'.
+func RolePermissionByPermissionID(ctx context.Context, db DB, permissionID int) ([]*RolePermission, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`role_id, permission_id, granted_at ` +
+		`FROM RolePermission ` +
+		`WHERE permission_id = ?`
+	// run
+	logf(sqlstr, permissionID)
+	rows, err := db.QueryContext(ctx, sqlstr, permissionID)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*RolePermission
+	for rows.Next() {
+		rp := RolePermission{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&rp.RoleID, &rp.PermissionID, &rp.GrantedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &rp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	return res, nil
+}
+
+// RolePermissionByRoleIDPermissionID retrieves a row from 'RolePermission' as a [RolePermission].
+//
+// Generated from index 'role_id'.
+func RolePermissionByRoleIDPermissionID(ctx context.Context, db DB, roleID, permissionID int) (*RolePermission, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`role_id, permission_id, granted_at ` +
+		`FROM RolePermission ` +
+		`WHERE role_id = ? AND permission_id = ?`
+	// run
+	logf(sqlstr, roleID, permissionID)
+	rp := RolePermission{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, roleID, permissionID).Scan(&rp.RoleID, &rp.PermissionID, &rp.GrantedAt); err != nil {
+		return nil, logerror(err)
+	}
+	return &rp, nil
+}
+
+// RolePermissionByRoleID retrieves a row from 'RolePermission' as a [RolePermission].
+//
+// Generated from index 'role_id'.
+func RolePermissionByRoleID(ctx context.Context, db DB, roleID int) ([]*RolePermission, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`role_id, permission_id, granted_at ` +
+		`FROM RolePermission ` +
+		`WHERE role_id = ?`
+	// run
+	logf(sqlstr, roleID)
+	rows, err := db.QueryContext(ctx, sqlstr, roleID)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*RolePermission
+	for rows.Next() {
+		rp := RolePermission{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&rp.RoleID, &rp.PermissionID, &rp.GrantedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &rp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	return res, nil
+}
+
+// Role returns the Role associated with the [RolePermission]'s (RoleID).
+//
+// Generated from foreign key 'rolepermission_ibfk_1'.
+func (rp *RolePermission) Role(ctx context.Context, db DB) (*Role, error) {
+	return RoleByRoleID(ctx, db, rp.RoleID)
+}
+
+// Permission returns the Permission associated with the [RolePermission]'s (PermissionID).
+//
+// Generated from foreign key 'rolepermission_ibfk_2'.
+func (rp *RolePermission) Permission(ctx context.Context, db DB) (*Permission, error) {
+	return PermissionByPermissionID(ctx, db, rp.PermissionID)
+}
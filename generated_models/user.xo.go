@@ -4,19 +4,24 @@ package generated_models
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"strings"
 	"time"
 )
 
 // User represents a row from 'User'.
 type User struct {
-	UserID    int       `json:"user_id"`    // user_id
-	Username  string    `json:"username"`   // username
-	Email     string    `json:"email"`      // email
-	CreatedAt time.Time `json:"created_at"` // created_at
-	UpdatedAt time.Time `json:"updated_at"` // updated_at
+	UserID       int          `json:"user_id"`       // user_id
+	Username     string       `json:"username"`      // username
+	Email        string       `json:"email"`         // email
+	CreatedAt    time.Time    `json:"created_at"`    // created_at
+	UpdatedAt    time.Time    `json:"updated_at"`    // updated_at
+	PasswordHash string       `json:"password_hash"` // password_hash
+	DeletedAt    sql.NullTime `json:"deleted_at"`    // deleted_at
+	Version      int          `json:"version"`       // version
 	// xo fields
 	_exists, _deleted bool
 }
@@ -32,6 +37,53 @@ func (u *User) Deleted() bool {
 	return u._deleted
 }
 
+// auditBefore loads the stored [User] as the before image of an audit
+// entry. It returns nil when no audit hook is set or no row is stored.
+func (u *User) auditBefore(ctx context.Context, db DB) (*User, error) {
+	if auditHook == nil {
+		return nil, nil
+	}
+	const sqlstr = `SELECT ` +
+		`user_id, username, email, created_at, updated_at, password_hash, deleted_at, version ` +
+		`FROM User ` +
+		`WHERE user_id = ?`
+	logf(sqlstr, u.UserID)
+	before := User{
+		_exists: true,
+	}
+	switch err := db.QueryRowContext(ctx, sqlstr, u.UserID).Scan(&before.UserID, &before.Username, &before.Email, &before.CreatedAt, &before.UpdatedAt, &before.PasswordHash, &before.DeletedAt, &before.Version); {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, logerror(err)
+	}
+	return &before, nil
+}
+
+// audit passes a mutation of the [User] to the audit hook, with the row
+// before and after it, either of which may be nil.
+func (u *User) audit(ctx context.Context, db DB, action string, before, after *User) error {
+	if auditHook == nil {
+		return nil
+	}
+	entry := AuditEntry{
+		Table:      "User",
+		Action:     action,
+		PrimaryKey: auditKey(u.UserID),
+	}
+	// keep typed nils out of the interfaces
+	if before != nil {
+		entry.Before = before
+	}
+	if after != nil {
+		entry.After = after
+	}
+	if err := auditHook(ctx, db, entry); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
 // Insert inserts the [User] to the database.
 func (u *User) Insert(ctx context.Context, db DB) error {
 	switch {
@@ -40,15 +92,36 @@ func (u *User) Insert(ctx context.Context, db DB) error {
 	case u._deleted: // deleted
 		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
 	}
-	// insert (primary key generated and returned by database)
-	const sqlstr = `INSERT INTO User (` +
-		`username, email, created_at, updated_at` +
-		`) VALUES (` +
-		`?, ?, ?, ?` +
-		`)`
+	// run the BeforeInsert hook
+	if err := beforeInsert(ctx, db, u); err != nil {
+		return logerror(&ErrInsertFailed{err})
+	}
+	// insert (primary key generated and returned by database), leaving
+	// unset columns with a database default to the database
+	columns := []string{"username", "email", "password_hash", "deleted_at"}
+	args := []interface{}{u.Username, u.Email, u.PasswordHash, u.DeletedAt}
+	var defaults []string
+	var dest []interface{}
+	if u.CreatedAt.IsZero() {
+		defaults, dest = append(defaults, "created_at"), append(dest, &u.CreatedAt)
+	} else {
+		columns, args = append(columns, "created_at"), append(args, u.CreatedAt)
+	}
+	if u.UpdatedAt.IsZero() {
+		defaults, dest = append(defaults, "updated_at"), append(dest, &u.UpdatedAt)
+	} else {
+		columns, args = append(columns, "updated_at"), append(args, u.UpdatedAt)
+	}
+	if u.Version == 0 {
+		defaults, dest = append(defaults, "version"), append(dest, &u.Version)
+	} else {
+		columns, args = append(columns, "version"), append(args, u.Version)
+	}
+	sqlstr := `INSERT INTO User (` + strings.Join(columns, ", ") + `) ` +
+		`VALUES (` + bindvars(len(args)) + `)`
 	// run
-	logf(sqlstr, u.Username, u.Email, u.CreatedAt, u.UpdatedAt)
-	res, err := db.ExecContext(ctx, sqlstr, u.Username, u.Email, u.CreatedAt, u.UpdatedAt)
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
 	if err != nil {
 		return logerror(err)
 	}
@@ -56,11 +129,26 @@ func (u *User) Insert(ctx context.Context, db DB) error {
 	id, err := res.LastInsertId()
 	if err != nil {
 		return logerror(err)
-	} // set primary key
+	}
+	// set primary key
 	u.UserID = int(id)
+	// read back the values the database assigned
+	if len(defaults) != 0 {
+		sqlstr := `SELECT ` + strings.Join(defaults, ", ") + ` ` +
+			`FROM User ` +
+			`WHERE user_id = ?`
+		logf(sqlstr, u.UserID)
+		if err := db.QueryRowContext(ctx, sqlstr, u.UserID).Scan(dest...); err != nil {
+			return logerror(err)
+		}
+	}
 	// set exists
 	u._exists = true
-	return nil
+	// run the AfterInsert hook
+	if err := afterInsert(ctx, db, u); err != nil {
+		return logerror(&ErrInsertFailed{err})
+	}
+	return u.audit(ctx, db, "insert", nil, u)
 }
 
 // Update updates a [User] in the database.
@@ -71,16 +159,51 @@ func (u *User) Update(ctx context.Context, db DB) error {
 	case u._deleted: // deleted
 		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
 	}
-	// update with primary key
-	const sqlstr = `UPDATE User SET ` +
-		`username = ?, email = ?, created_at = ?, updated_at = ? ` +
-		`WHERE user_id = ?`
+	// run the BeforeUpdate hook
+	if err := beforeUpdate(ctx, db, u); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	before, err := u.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// update with primary key and version, leaving unset columns
+	// with a database default unchanged
+	set := []string{"username = ?", "email = ?", "password_hash = ?", "deleted_at = ?"}
+	args := []interface{}{u.Username, u.Email, u.PasswordHash, u.DeletedAt}
+	if !u.CreatedAt.IsZero() {
+		set, args = append(set, "created_at = ?"), append(args, u.CreatedAt)
+	}
+	set = append(set, "version = version + 1")
+	sqlstr := `UPDATE User SET ` + strings.Join(set, ", ") + ` ` +
+		`WHERE user_id = ? AND version = ?`
+	args = append(args, u.UserID, u.Version)
 	// run
-	logf(sqlstr, u.Username, u.Email, u.CreatedAt, u.UpdatedAt, u.UserID)
-	if _, err := db.ExecContext(ctx, sqlstr, u.Username, u.Email, u.CreatedAt, u.UpdatedAt, u.UserID); err != nil {
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
 		return logerror(err)
 	}
-	return nil
+	// no match means the row changed since it was read
+	switch n, err := res.RowsAffected(); {
+	case err != nil:
+		return logerror(err)
+	case n == 0:
+		return logerror(&ErrUpdateFailed{ErrStaleVersion})
+	}
+	u.Version++
+	// read back the updated_at the database set
+	const selstr = `SELECT updated_at FROM User ` +
+		`WHERE user_id = ?`
+	logf(selstr, u.UserID)
+	if err := db.QueryRowContext(ctx, selstr, u.UserID).Scan(&u.UpdatedAt); err != nil {
+		return logerror(err)
+	}
+	// run the AfterUpdate hook
+	if err := afterUpdate(ctx, db, u); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	return u.audit(ctx, db, "update", before, u)
 }
 
 // Save saves the [User] to the database.
@@ -97,25 +220,90 @@ func (u *User) Upsert(ctx context.Context, db DB) error {
 	case u._deleted: // deleted
 		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
 	}
-	// upsert
-	const sqlstr = `INSERT INTO User (` +
-		`user_id, username, email, created_at, updated_at` +
-		`) VALUES (` +
-		`?, ?, ?, ?, ?` +
-		`)` +
-		` ON DUPLICATE KEY UPDATE ` +
-		`username = VALUES(username), email = VALUES(email), created_at = VALUES(created_at), updated_at = VALUES(updated_at)`
+	// run the BeforeInsert hook
+	if err := beforeInsert(ctx, db, u); err != nil {
+		return logerror(&ErrUpsertFailed{err})
+	}
+	before, err := u.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// upsert, leaving unset columns with a database default to the database
+	// or, on conflict, to their stored values
+	columns := []string{"username", "email", "password_hash", "deleted_at"}
+	args := []interface{}{u.Username, u.Email, u.PasswordHash, u.DeletedAt}
+	update := []string{"username = VALUES(username)", "email = VALUES(email)", "password_hash = VALUES(password_hash)"}
+	var defaults []string
+	var dest []interface{}
+	if u.CreatedAt.IsZero() {
+		defaults, dest = append(defaults, "created_at"), append(dest, &u.CreatedAt)
+	} else {
+		columns, args = append(columns, "created_at"), append(args, u.CreatedAt)
+		update = append(update, "created_at = VALUES(created_at)")
+	}
+	if u.UpdatedAt.IsZero() {
+		defaults, dest = append(defaults, "updated_at"), append(dest, &u.UpdatedAt)
+	} else {
+		columns, args = append(columns, "updated_at"), append(args, u.UpdatedAt)
+		// on conflict the database sets it instead
+		defaults, dest = append(defaults, "updated_at"), append(dest, &u.UpdatedAt)
+	}
+	if u.Version == 0 {
+		defaults, dest = append(defaults, "version"), append(dest, &u.Version)
+	} else {
+		columns, args = append(columns, "version"), append(args, u.Version)
+		update = append(update, "version = VALUES(version)")
+	}
+	// a conflicting soft deleted row stays deleted, see [User.Restore]
+	defaults, dest = append(defaults, "deleted_at"), append(dest, &u.DeletedAt)
+	// an unset user_id is generated by the database, or on conflict with
+	// another unique key is that of the stored row, and read back either way
+	if u.UserID != 0 {
+		columns, args = append(columns, "user_id"), append(args, u.UserID)
+	} else {
+		update = append(update, "user_id = LAST_INSERT_ID(user_id)")
+	}
+	sqlstr := `INSERT INTO User (` + strings.Join(columns, ", ") + `) ` +
+		`VALUES (` + bindvars(len(args)) + `) ` +
+		`ON DUPLICATE KEY UPDATE ` + strings.Join(update, ", ")
 	// run
-	logf(sqlstr, u.UserID, u.Username, u.Email, u.CreatedAt, u.UpdatedAt)
-	if _, err := db.ExecContext(ctx, sqlstr, u.UserID, u.Username, u.Email, u.CreatedAt, u.UpdatedAt); err != nil {
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
 		return logerror(err)
 	}
+	if u.UserID == 0 {
+		// retrieve id
+		id, err := res.LastInsertId()
+		if err != nil {
+			return logerror(err)
+		}
+		u.UserID = int(id)
+	}
+	// read back the values the database assigned or kept
+	if len(defaults) != 0 {
+		sqlstr := `SELECT ` + strings.Join(defaults, ", ") + ` ` +
+			`FROM User ` +
+			`WHERE user_id = ?`
+		logf(sqlstr, u.UserID)
+		if err := db.QueryRowContext(ctx, sqlstr, u.UserID).Scan(dest...); err != nil {
+			return logerror(err)
+		}
+	}
+	u._deleted = u.DeletedAt.Valid
 	// set exists
 	u._exists = true
-	return nil
+	// run the AfterInsert hook
+	if err := afterInsert(ctx, db, u); err != nil {
+		return logerror(&ErrUpsertFailed{err})
+	}
+	return u.audit(ctx, db, "upsert", before, u)
 }
 
-// Delete deletes the [User] from the database.
+// Delete soft deletes the [User], setting its deleted_at to
+// the current time. Soft deleted rows are left out of lookups, pages and counts
+// until restored with [User.Restore]. See [User.HardDelete].
+// It returns sql.ErrNoRows when no stored row was left to delete.
 func (u *User) Delete(ctx context.Context, db DB) error {
 	switch {
 	case !u._exists: // doesn't exist
@@ -123,6 +311,55 @@ func (u *User) Delete(ctx context.Context, db DB) error {
 	case u._deleted: // deleted
 		return nil
 	}
+	// run the BeforeDelete hook
+	if err := beforeDelete(ctx, db, u); err != nil {
+		return logerror(err)
+	}
+	before, err := u.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// soft delete with primary key
+	const sqlstr = `UPDATE User SET deleted_at = ? ` +
+		`WHERE user_id = ? AND deleted_at IS NULL`
+	now := time.Now()
+	// run
+	logf(sqlstr, now, u.UserID)
+	res, err := db.ExecContext(ctx, sqlstr, now, u.UserID)
+	if err != nil {
+		return logerror(err)
+	}
+	// no match means the row is gone or already soft deleted
+	switch n, err := res.RowsAffected(); {
+	case err != nil:
+		return logerror(err)
+	case n == 0:
+		return logerror(sql.ErrNoRows)
+	}
+	// set deleted
+	u.DeletedAt = sql.NullTime{Time: now, Valid: true}
+	u._deleted = true
+	// run the AfterDelete hook
+	if err := afterDelete(ctx, db, u); err != nil {
+		return logerror(err)
+	}
+	return u.audit(ctx, db, "delete", before, u)
+}
+
+// HardDelete permanently deletes the [User] from the database, whether or not
+// it is soft deleted.
+func (u *User) HardDelete(ctx context.Context, db DB) error {
+	if !u._exists { // doesn't exist
+		return nil
+	}
+	// run the BeforeDelete hook
+	if err := beforeDelete(ctx, db, u); err != nil {
+		return logerror(err)
+	}
+	before, err := u.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
 	// delete with single primary key
 	const sqlstr = `DELETE FROM User ` +
 		`WHERE user_id = ?`
@@ -133,9 +370,276 @@ func (u *User) Delete(ctx context.Context, db DB) error {
 	}
 	// set deleted
 	u._deleted = true
+	// run the AfterDelete hook
+	if err := afterDelete(ctx, db, u); err != nil {
+		return logerror(err)
+	}
+	return u.audit(ctx, db, "delete", before, nil)
+}
+
+// Restore undeletes the soft deleted [User], clearing its deleted_at. Soft
+// deleted rows are retrieved with [UserByUserIDWithDeleted].
+func (u *User) Restore(ctx context.Context, db DB) error {
+	if !u._exists { // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	}
+	before, err := u.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// restore with primary key
+	const sqlstr = `UPDATE User SET deleted_at = NULL ` +
+		`WHERE user_id = ?`
+	// run
+	logf(sqlstr, u.UserID)
+	if _, err := db.ExecContext(ctx, sqlstr, u.UserID); err != nil {
+		return logerror(err)
+	}
+	// set restored
+	u.DeletedAt = sql.NullTime{}
+	u._deleted = false
+	return u.audit(ctx, db, "restore", before, u)
+}
+
+// UserByUserIDWithDeleted retrieves a row from 'User' as a [User]
+// by primary key, including a soft deleted row, which is marked as deleted.
+func UserByUserIDWithDeleted(ctx context.Context, db DB, userID int) (*User, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`user_id, username, email, created_at, updated_at, password_hash, deleted_at, version ` +
+		`FROM User ` +
+		`WHERE user_id = ?`
+	// run
+	logf(sqlstr, userID)
+	u := User{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, userID).Scan(&u.UserID, &u.Username, &u.Email, &u.CreatedAt, &u.UpdatedAt, &u.PasswordHash, &u.DeletedAt, &u.Version); err != nil {
+		return nil, logerror(err)
+	}
+	u._deleted = u.DeletedAt.Valid
+	return &u, nil
+}
+
+// UserColumn is a column name of 'User'.
+type UserColumn string
+
+// UserColumn values.
+const (
+	// UserColumnUserID is the 'user_id' column.
+	UserColumnUserID UserColumn = "user_id"
+	// UserColumnUsername is the 'username' column.
+	UserColumnUsername UserColumn = "username"
+	// UserColumnEmail is the 'email' column.
+	UserColumnEmail UserColumn = "email"
+	// UserColumnCreatedAt is the 'created_at' column.
+	UserColumnCreatedAt UserColumn = "created_at"
+	// UserColumnUpdatedAt is the 'updated_at' column.
+	UserColumnUpdatedAt UserColumn = "updated_at"
+	// UserColumnPasswordHash is the 'password_hash' column.
+	UserColumnPasswordHash UserColumn = "password_hash"
+	// UserColumnDeletedAt is the 'deleted_at' column.
+	UserColumnDeletedAt UserColumn = "deleted_at"
+	// UserColumnVersion is the 'version' column.
+	UserColumnVersion UserColumn = "version"
+)
+
+// Valid returns true when the [UserColumn] is a column of 'User'.
+func (c UserColumn) Valid() bool {
+	switch c {
+	case UserColumnUserID, UserColumnUsername, UserColumnEmail, UserColumnCreatedAt, UserColumnUpdatedAt, UserColumnPasswordHash, UserColumnDeletedAt, UserColumnVersion:
+		return true
+	}
+	return false
+}
+
+// Nullable returns true when the [UserColumn] is a column of
+// 'User' that may hold NULL.
+func (c UserColumn) Nullable() bool {
+	switch c {
+	case UserColumnDeletedAt:
+		return true
+	}
+	return false
+}
+
+// ParseUserColumn parses a client supplied column name, returning
+// [ErrUnknownColumn] when it is not a column of 'User'.
+func ParseUserColumn(s string) (UserColumn, error) {
+	if c := UserColumn(s); c.Valid() {
+		return c, nil
+	}
+	return "", &ErrUnknownColumn{Table: "User", Column: s}
+}
+
+// ColumnValue returns the value of column for the [User], or nil when
+// column is not a valid [UserColumn].
+func (u *User) ColumnValue(column UserColumn) interface{} {
+	switch column {
+	case UserColumnUserID:
+		return u.UserID
+	case UserColumnUsername:
+		return u.Username
+	case UserColumnEmail:
+		return u.Email
+	case UserColumnCreatedAt:
+		return u.CreatedAt
+	case UserColumnUpdatedAt:
+		return u.UpdatedAt
+	case UserColumnPasswordHash:
+		return u.PasswordHash
+	case UserColumnDeletedAt:
+		return u.DeletedAt
+	case UserColumnVersion:
+		return u.Version
+	}
 	return nil
 }
 
+// UpdateColumns updates only the listed columns of the [User] in the
+// database. Unlisted columns keep their stored values, so columns the database
+// maintains itself, such as ON UPDATE CURRENT_TIMESTAMP, are left to it. The
+// row is then reloaded so the [User] reflects the stored values.
+func (u *User) UpdateColumns(ctx context.Context, db DB, columns ...UserColumn) error {
+	switch {
+	case !u._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case u._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// check the listed columns
+	var listed []UserColumn
+	seen := make(map[UserColumn]bool)
+	for _, c := range columns {
+		switch {
+		case !c.Valid():
+			return logerror(&ErrUpdateFailed{&ErrUnknownColumn{Table: "User", Column: string(c)}})
+		case c == UserColumnUserID:
+			return logerror(&ErrUpdateFailed{ErrPrimaryKeyColumn})
+		case c == UserColumnVersion:
+			return logerror(&ErrUpdateFailed{ErrVersionColumn})
+		case seen[c]:
+			continue
+		}
+		seen[c] = true
+		listed = append(listed, c)
+	}
+	if len(listed) == 0 {
+		return nil
+	}
+	// run the BeforeUpdate hook
+	if err := beforeUpdate(ctx, db, u); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	// build the set list from the values the hook left
+	var set []string
+	var args []interface{}
+	for _, c := range listed {
+		set = append(set, string(c)+" = ?")
+		args = append(args, u.ColumnValue(c))
+	}
+	before, err := u.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	set = append(set, "version = version + 1")
+	// update with primary key and version
+	sqlstr := `UPDATE User SET ` + strings.Join(set, ", ") + ` ` +
+		`WHERE user_id = ? AND version = ?`
+	args = append(args, u.UserID, u.Version)
+	// run
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
+		return logerror(err)
+	}
+	// no match means the row changed since it was read
+	switch n, err := res.RowsAffected(); {
+	case err != nil:
+		return logerror(err)
+	case n == 0:
+		return logerror(&ErrUpdateFailed{ErrStaleVersion})
+	}
+	// reload
+	const selstr = `SELECT ` +
+		`user_id, username, email, created_at, updated_at, password_hash, deleted_at, version ` +
+		`FROM User ` +
+		`WHERE user_id = ?`
+	logf(selstr, u.UserID)
+	if err := db.QueryRowContext(ctx, selstr, u.UserID).Scan(&u.UserID, &u.Username, &u.Email, &u.CreatedAt, &u.UpdatedAt, &u.PasswordHash, &u.DeletedAt, &u.Version); err != nil {
+		return logerror(err)
+	}
+	// run the AfterUpdate hook
+	if err := afterUpdate(ctx, db, u); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	return u.audit(ctx, db, "update", before, u)
+}
+
+// decodeUserColumnValue decodes a JSON encoded value of column.
+func decodeUserColumnValue(column UserColumn, buf []byte) (interface{}, error) {
+	switch column {
+	case UserColumnUserID:
+		var v int
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case UserColumnUsername:
+		var v string
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case UserColumnEmail:
+		var v string
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case UserColumnCreatedAt:
+		var v time.Time
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case UserColumnUpdatedAt:
+		var v time.Time
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case UserColumnPasswordHash:
+		var v string
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case UserColumnDeletedAt:
+		var v sql.NullTime
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case UserColumnVersion:
+		var v int
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	}
+	return nil, &ErrUnknownColumn{Table: "User", Column: string(column)}
+}
+
+// UserFilters holds a typed predicate builder for each column of 'User'.
+type UserFilters struct {
+	UserID       ColumnFilter[User, int]
+	Username     StringColumnFilter[User]
+	Email        StringColumnFilter[User]
+	CreatedAt    ColumnFilter[User, time.Time]
+	UpdatedAt    ColumnFilter[User, time.Time]
+	PasswordHash StringColumnFilter[User]
+	DeletedAt    ColumnFilter[User, time.Time]
+	Version      ColumnFilter[User, int]
+}
+
+// UserFilter builds predicates over 'User' for
+// [UserKeysetPage], [UserCount] and [UserDeleteWhere].
+var UserFilter = UserFilters{
+	UserID:       ColumnFilter[User, int]{column: "user_id"},
+	Username:     StringColumnFilter[User]{ColumnFilter[User, string]{column: "username"}},
+	Email:        StringColumnFilter[User]{ColumnFilter[User, string]{column: "email"}},
+	CreatedAt:    ColumnFilter[User, time.Time]{column: "created_at"},
+	UpdatedAt:    ColumnFilter[User, time.Time]{column: "updated_at"},
+	PasswordHash: StringColumnFilter[User]{ColumnFilter[User, string]{column: "password_hash"}},
+	DeletedAt:    ColumnFilter[User, time.Time]{column: "deleted_at"},
+	Version:      ColumnFilter[User, int]{column: "version"},
+}
+
 // UserKeysetPage retrieves a page of [User] records using keyset pagination with dynamic filtering.
 //
 // The keyset pagination retrieves results after or before a specific value (`key`)
@@ -144,64 +648,50 @@ func (u *User) Delete(ctx context.Context, db DB) error {
 // If `order` is `ASC`, it retrieves records where the value of `column` is greater than `key`.
 // If `order` is `DESC`, it retrieves records where the value of `column` is less than `key`.
 //
-// Filters are dynamically provided via a `filters` map, where keys are column names and values are either single values or slices for `IN` clauses.
-func UserKeysetPage(ctx context.Context, db DB, column string, key interface{}, limit int, order string, filters map[string]interface{}) ([]*User, *User, error) {
+// Rows are further restricted by `where`, built from [UserFilter]; the zero
+// [Predicate] applies no filter.
+//
+// `column` must be a valid [UserColumn], otherwise [ErrUnknownColumn] is
+// returned before any SQL is built. Nullable columns return [ErrNullableColumn].
+func UserKeysetPage(ctx context.Context, db DB, column UserColumn, key interface{}, limit int, order string, where Predicate[User]) ([]*User, *User, error) {
 	if order != "ASC" && order != "DESC" {
 		return nil, nil, fmt.Errorf("invalid order: %s", order)
 	}
 
+	// Only known columns may be interpolated into the query
+	if !column.Valid() {
+		return nil, nil, logerror(&ErrUnknownColumn{Table: "User", Column: string(column)})
+	}
+	if column.Nullable() {
+		return nil, nil, logerror(ErrNullableColumn)
+	}
+
 	// Start building the query
 	query := fmt.Sprintf(
-		`SELECT * FROM User 
-         WHERE %s %s ?`,
+		`SELECT user_id, username, email, created_at, updated_at, password_hash, deleted_at, version `+
+			`FROM User WHERE %s %s ?`,
 		column, condition(order),
 	)
 
+	// Leave out soft deleted rows
+	query += " AND deleted_at IS NULL"
+
 	// Arguments for the query
 	args := []interface{}{key}
 
-	// Dynamically add filters from the `filters` map to the query
-	for field, value := range filters {
-		switch v := value.(type) {
-		case []int:
-			if len(v) > 0 {
-				placeholders := make([]string, len(v))
-				for i := range v {
-					placeholders[i] = "?"
-					args = append(args, v[i])
-				}
-				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
-			}
-		case []string:
-			if len(v) > 0 {
-				placeholders := make([]string, len(v))
-				for i := range v {
-					placeholders[i] = "?"
-					args = append(args, v[i])
-				}
-				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
-			}
-		default:
-			// Handle NULL and NOT NULL checks
-			if value == nil {
-				query += fmt.Sprintf(" AND %s IS NULL", field)
-			} else if value == "NOT NULL" {
-				query += fmt.Sprintf(" AND %s IS NOT NULL", field)
-			} else {
-				query += fmt.Sprintf(" AND %s = ?", field)
-				args = append(args, value)
-			}
-		}
+	// Add the filter predicate
+	if !where.IsZero() {
+		cond, condArgs := where.SQL()
+		query += " AND (" + cond + ")"
+		args = append(args, condArgs...)
 	}
 
 	// Finalize the query with the order and limit
 	query += fmt.Sprintf(" ORDER BY %s %s LIMIT ?", column, order)
 	args = append(args, limit)
 
-	// Log the final query for debugging purposes
-	log.Printf("Executing query: %s with args: %v", query, args)
-
 	// Execute the query
+	logf(query, args...)
 	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, nil, logerror(err)
@@ -216,7 +706,7 @@ func UserKeysetPage(ctx context.Context, db DB, column string, key interface{},
 			_exists: true,
 		}
 		if err := rows.Scan(
-			&u.UserID, &u.Username, &u.Email, &u.CreatedAt, &u.UpdatedAt,
+			&u.UserID, &u.Username, &u.Email, &u.CreatedAt, &u.UpdatedAt, &u.PasswordHash, &u.DeletedAt, &u.Version,
 		); err != nil {
 			return nil, nil, logerror(err)
 		}
@@ -236,21 +726,452 @@ func UserKeysetPage(ctx context.Context, db DB, column string, key interface{},
 	return results, lastItem, nil
 }
 
+// UserPage is a page of [User] records returned by [UserCursorPage].
+type UserPage struct {
+	Items []*User
+	// NextCursor retrieves the following page. It is empty on the last page.
+	NextCursor string
+	// PrevCursor retrieves the preceding page. It is empty on the first page.
+	PrevCursor string
+}
+
+// userKeysetColumns returns column followed by the primary key columns
+// of 'User' that break ties between equal values of column.
+func userKeysetColumns(column UserColumn) []UserColumn {
+	columns := []UserColumn{column}
+	if column != UserColumnUserID {
+		columns = append(columns, UserColumnUserID)
+	}
+	return columns
+}
+
+// UserCursorPage retrieves a page of [User] records ordered by
+// (`column`, primary key) in `order` (`ASC` or `DESC`), so that rows sharing a
+// value of `column` are neither skipped nor repeated between pages.
+//
+// An empty `cursor` retrieves the first page. Passing the returned NextCursor or
+// PrevCursor, together with the same `column` and `order`, retrieves the
+// following or preceding page. Cursors are opaque and return [ErrInvalidCursor]
+// when they are malformed or were issued for a different column or order.
+//
+// `column` must not be nullable, otherwise [ErrNullableColumn] is returned.
+// Rows are further restricted by `where`, built from [UserFilter].
+func UserCursorPage(ctx context.Context, db DB, column UserColumn, order, cursor string, limit int, where Predicate[User]) (*UserPage, error) {
+	switch {
+	case order != "ASC" && order != "DESC":
+		return nil, fmt.Errorf("invalid order: %s", order)
+	case limit <= 0:
+		return nil, fmt.Errorf("invalid limit: %d", limit)
+	case !column.Valid():
+		return nil, logerror(&ErrUnknownColumn{Table: "User", Column: string(column)})
+	case column.Nullable():
+		return nil, logerror(ErrNullableColumn)
+	}
+	columns := userKeysetColumns(column)
+	// decode the boundary row
+	var backward bool
+	var conds []string
+	var args []interface{}
+	if cursor != "" {
+		c, err := decodeCursor(cursor, string(column), order)
+		if err != nil || len(c.Values) != len(columns) {
+			return nil, logerror(ErrInvalidCursor)
+		}
+		for i, col := range columns {
+			v, err := decodeUserColumnValue(col, c.Values[i])
+			if err != nil {
+				return nil, logerror(ErrInvalidCursor)
+			}
+			args = append(args, v)
+		}
+		backward = c.Backward
+	}
+	// paging backward walks the index in reverse
+	dir := order
+	if backward {
+		dir = reverse(order)
+	}
+	if cursor != "" {
+		conds = append(conds, keysetCondition(columns, dir))
+	}
+	// leave out soft deleted rows
+	conds = append(conds, "deleted_at IS NULL")
+	if !where.IsZero() {
+		cond, condArgs := where.SQL()
+		conds = append(conds, "("+cond+")")
+		args = append(args, condArgs...)
+	}
+	// query
+	sqlstr := `SELECT ` +
+		`user_id, username, email, created_at, updated_at, password_hash, deleted_at, version ` +
+		`FROM User`
+	if len(conds) != 0 {
+		sqlstr += ` WHERE ` + strings.Join(conds, " AND ")
+	}
+	sqlstr += ` ORDER BY ` + keysetOrderBy(columns, dir) + ` LIMIT ?`
+	// fetch one extra row to learn whether another page follows
+	args = append(args, limit+1)
+	// run
+	logf(sqlstr, args...)
+	rows, err := db.QueryContext(ctx, sqlstr, args...)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*User
+	for rows.Next() {
+		u := User{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&u.UserID, &u.Username, &u.Email, &u.CreatedAt, &u.UpdatedAt, &u.PasswordHash, &u.DeletedAt, &u.Version); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	more := len(res) > limit
+	if more {
+		res = res[:limit]
+	}
+	if backward {
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+	page := &UserPage{Items: res}
+	if len(res) == 0 {
+		return page, nil
+	}
+	// a backward page always has rows after it, and a forward page from a
+	// cursor always has rows before it
+	hasNext, hasPrev := more, cursor != ""
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+	if hasNext {
+		if page.NextCursor, err = userCursor(res[len(res)-1], column, order, false); err != nil {
+			return nil, logerror(err)
+		}
+	}
+	if hasPrev {
+		if page.PrevCursor, err = userCursor(res[0], column, order, true); err != nil {
+			return nil, logerror(err)
+		}
+	}
+	return page, nil
+}
+
+// userCursor encodes the position of u as a cursor for [UserCursorPage].
+func userCursor(u *User, column UserColumn, order string, backward bool) (string, error) {
+	var values []interface{}
+	for _, col := range userKeysetColumns(column) {
+		values = append(values, u.ColumnValue(col))
+	}
+	return encodeCursor(string(column), order, backward, values)
+}
+
+// UserCount returns the number of [User] records matching `where`.
+func UserCount(ctx context.Context, db DB, where Predicate[User]) (int64, error) {
+	cond, args := where.SQL()
+	// leave out soft deleted rows
+	sqlstr := `SELECT COUNT(*) FROM User WHERE (` + cond + `) AND deleted_at IS NULL`
+	// run
+	logf(sqlstr, args...)
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlstr, args...).Scan(&count); err != nil {
+		return 0, logerror(err)
+	}
+	return count, nil
+}
+
+// UserDeleteWhere deletes the [User] records matching `where`,
+// returning the number of records deleted. The zero [Predicate] is rejected with
+// [ErrEmptyPredicate] rather than deleting every record.
+//
+// Records are soft deleted, as with [User.Delete].
+func UserDeleteWhere(ctx context.Context, db DB, where Predicate[User]) (int64, error) {
+	if where.IsZero() {
+		return 0, logerror(ErrEmptyPredicate)
+	}
+	cond, args := where.SQL()
+	// with an audit hook or delete hooks the matching rows are locked and
+	// deleted one by one, so that each deletion is audited and hooked
+	if auditHook != nil || hasDeleteHooks(&User{}) {
+		sqlstr := `SELECT ` +
+			`user_id, username, email, created_at, updated_at, password_hash, deleted_at, version ` +
+			`FROM User WHERE (` + cond + `) AND deleted_at IS NULL FOR UPDATE`
+		logf(sqlstr, args...)
+		rows, err := db.QueryContext(ctx, sqlstr, args...)
+		if err != nil {
+			return 0, logerror(err)
+		}
+		var matched []*User
+		for rows.Next() {
+			u := User{
+				_exists: true,
+			}
+			if err := rows.Scan(&u.UserID, &u.Username, &u.Email, &u.CreatedAt, &u.UpdatedAt, &u.PasswordHash, &u.DeletedAt, &u.Version); err != nil {
+				rows.Close()
+				return 0, logerror(err)
+			}
+			matched = append(matched, &u)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return 0, logerror(err)
+		}
+		for _, u := range matched {
+			if err := u.Delete(ctx, db); err != nil {
+				return 0, err
+			}
+		}
+		return int64(len(matched)), nil
+	}
+	// soft delete
+	sqlstr := `UPDATE User SET deleted_at = ? WHERE (` + cond + `) AND deleted_at IS NULL`
+	args = append([]interface{}{time.Now()}, args...)
+	// run
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
+		return 0, logerror(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, logerror(err)
+	}
+	return n, nil
+}
+
+// batchColumns returns the columns and values of the [User] in a
+// multi-row insert or upsert. As with [User.Insert], unset columns with a
+// database default are left out. Upserts also bind the generated primary key.
+func (u *User) batchColumns(upsert bool) ([]string, []interface{}) {
+	var columns []string
+	var args []interface{}
+	if upsert {
+		columns, args = append(columns, "user_id"), append(args, u.UserID)
+	}
+	columns = append(columns, "username", "email", "password_hash", "deleted_at")
+	args = append(args, u.Username, u.Email, u.PasswordHash, u.DeletedAt)
+	if !u.CreatedAt.IsZero() {
+		columns, args = append(columns, "created_at"), append(args, u.CreatedAt)
+	}
+	if !u.UpdatedAt.IsZero() {
+		columns, args = append(columns, "updated_at"), append(args, u.UpdatedAt)
+	}
+	if u.Version != 0 {
+		columns, args = append(columns, "version"), append(args, u.Version)
+	}
+	return columns, args
+}
+
+// readBackUsers reads the values of the columns with a database default
+// back into rows, after a multi-row insert or upsert left some to the database.
+// The soft delete column is read back too, as upserts keep that of a
+// conflicting row.
+func readBackUsers(ctx context.Context, db DB, rows []*User) error {
+	type key struct {
+		UserID int
+	}
+	byKey := make(map[key]*User, len(rows))
+	var args []interface{}
+	for _, u := range rows {
+		byKey[key{u.UserID}] = u
+		args = append(args, u.UserID)
+	}
+	sqlstr := `SELECT ` +
+		`user_id, created_at, updated_at, version, deleted_at ` +
+		`FROM User ` +
+		`WHERE (user_id) IN (` + batchValues(1, len(rows)) + `)`
+	// run
+	logf(sqlstr, args...)
+	res, err := db.QueryContext(ctx, sqlstr, args...)
+	if err != nil {
+		return logerror(err)
+	}
+	defer res.Close()
+	for res.Next() {
+		var stored User
+		if err := res.Scan(&stored.UserID, &stored.CreatedAt, &stored.UpdatedAt, &stored.Version, &stored.DeletedAt); err != nil {
+			return logerror(err)
+		}
+		if u := byKey[key{stored.UserID}]; u != nil {
+			u.CreatedAt, u.UpdatedAt, u.Version, u.DeletedAt = stored.CreatedAt, stored.UpdatedAt, stored.Version, stored.DeletedAt
+		}
+	}
+	if err := res.Err(); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// InsertUsers inserts the [User] rows to the database with multi-row
+// statements, split under the limits of [SetBatchLimits]. As with
+// [User.Insert], the primary keys the database generates are set on
+// the rows, unset columns with a database default are read back, and the
+// hooks and the audit hook run for every row.
+func InsertUsers(ctx context.Context, db DB, rows []*User) error {
+	for _, u := range rows {
+		switch {
+		case u._exists: // already exists
+			return logerror(&ErrInsertFailed{ErrAlreadyExists})
+		case u._deleted: // deleted
+			return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+		}
+		// run the BeforeInsert hook
+		if err := beforeInsert(ctx, db, u); err != nil {
+			return logerror(&ErrInsertFailed{err})
+		}
+	}
+	for _, b := range batches(rows, func(u *User) ([]string, []interface{}) {
+		return u.batchColumns(false)
+	}) {
+		// insert
+		sqlstr := `INSERT INTO User (` + strings.Join(b.columns, ", ") + `) ` +
+			`VALUES ` + batchValues(len(b.columns), len(b.rows))
+		// run
+		logf(sqlstr, b.args...)
+		res, err := db.ExecContext(ctx, sqlstr, b.args...)
+		if err != nil {
+			return logerror(err)
+		}
+		// retrieve ids, which MySQL generates consecutively for the rows of a
+		// multi-row insert, starting with the returned one, as long as
+		// auto_increment_increment is left at 1
+		id, err := res.LastInsertId()
+		if err != nil {
+			return logerror(err)
+		}
+		for i, u := range b.rows {
+			u.UserID = int(id + int64(i))
+		}
+		// read back the values the database assigned
+		if len(b.columns) < 7 {
+			if err := readBackUsers(ctx, db, b.rows); err != nil {
+				return err
+			}
+		}
+	}
+	for _, u := range rows {
+		// set exists
+		u._exists = true
+		// run the AfterInsert hook
+		if err := afterInsert(ctx, db, u); err != nil {
+			return logerror(&ErrInsertFailed{err})
+		}
+		if err := u.audit(ctx, db, "insert", nil, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpsertUsers performs an upsert of the [User] rows with multi-row
+// statements, split under the limits of [SetBatchLimits]. As with
+// [User.Upsert], unset columns with a database default are left to the
+// database or, on conflict, keep their stored values, and are read back. The
+// rows must carry their primary keys, as MySQL reports the generated key of
+// only one row of a multi-row upsert; insert new rows with [InsertUsers]. With
+// an audit hook set, the stored rows are loaded one by one for their audit
+// entries.
+func UpsertUsers(ctx context.Context, db DB, rows []*User) error {
+	befores := make([]*User, len(rows))
+	for i, u := range rows {
+		switch {
+		case u._deleted: // deleted
+			return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+		case u.UserID == 0: // primary key unset
+			return logerror(&ErrUpsertFailed{ErrPrimaryKeyUnset})
+		}
+		// run the BeforeInsert hook
+		if err := beforeInsert(ctx, db, u); err != nil {
+			return logerror(&ErrUpsertFailed{err})
+		}
+		before, err := u.auditBefore(ctx, db)
+		if err != nil {
+			return err
+		}
+		befores[i] = before
+	}
+	for _, b := range batches(rows, func(u *User) ([]string, []interface{}) {
+		return u.batchColumns(true)
+	}) {
+		// upsert, updating the columns other than the primary key on conflict
+		var update []string
+		for _, c := range b.columns {
+			switch c {
+			case "user_id":
+			case "updated_at": // on conflict the database sets it instead
+			case "deleted_at": // a conflicting soft deleted row stays deleted
+			default:
+				update = append(update, c+" = VALUES("+c+")")
+			}
+		}
+		sqlstr := `INSERT INTO User (` + strings.Join(b.columns, ", ") + `) ` +
+			`VALUES ` + batchValues(len(b.columns), len(b.rows)) + ` ` +
+			`ON DUPLICATE KEY UPDATE ` + strings.Join(update, ", ")
+		// run
+		logf(sqlstr, b.args...)
+		if _, err := db.ExecContext(ctx, sqlstr, b.args...); err != nil {
+			return logerror(err)
+		}
+		// read back the values the database assigned or kept
+		if err := readBackUsers(ctx, db, b.rows); err != nil {
+			return err
+		}
+	}
+	for i, u := range rows {
+		// set exists
+		u._exists = true
+		u._deleted = u.DeletedAt.Valid
+		// run the AfterInsert hook
+		if err := afterInsert(ctx, db, u); err != nil {
+			return logerror(&ErrUpsertFailed{err})
+		}
+		if err := u.audit(ctx, db, "upsert", befores[i], u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteUsersByIDs deletes the [User] records with the primary keys
+// ids, returning the number of records deleted. The ids are split into
+// statements under the limits of [SetBatchLimits], each deleting as
+// [UserDeleteWhere] does.
+func DeleteUsersByIDs(ctx context.Context, db DB, ids []int) (int64, error) {
+	var n int64
+	// leave a placeholder for the deleted_at of the soft delete
+	for _, chunk := range chunks(ids, batchPlaceholders-1) {
+		deleted, err := UserDeleteWhere(ctx, db, UserFilter.UserID.In(chunk...))
+		n += deleted
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
 // UserByUserID retrieves a row from 'User' as a [User].
 //
 // Generated from index 'User_user_id_pkey'.
 func UserByUserID(ctx context.Context, db DB, userID int) (*User, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`user_id, username, email, created_at, updated_at ` +
+		`user_id, username, email, created_at, updated_at, password_hash, deleted_at, version ` +
 		`FROM User ` +
-		`WHERE user_id = ?`
+		`WHERE user_id = ? AND deleted_at IS NULL`
 	// run
 	logf(sqlstr, userID)
 	u := User{
 		_exists: true,
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, userID).Scan(&u.UserID, &u.Username, &u.Email, &u.CreatedAt, &u.UpdatedAt); err != nil {
+	if err := db.QueryRowContext(ctx, sqlstr, userID).Scan(&u.UserID, &u.Username, &u.Email, &u.CreatedAt, &u.UpdatedAt, &u.PasswordHash, &u.DeletedAt, &u.Version); err != nil {
 		return nil, logerror(err)
 	}
 	return &u, nil
@@ -262,15 +1183,15 @@ func UserByUserID(ctx context.Context, db DB, userID int) (*User, error) {
 func UserByEmail(ctx context.Context, db DB, email string) (*User, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`user_id, username, email, created_at, updated_at ` +
+		`user_id, username, email, created_at, updated_at, password_hash, deleted_at, version ` +
 		`FROM User ` +
-		`WHERE email = ?`
+		`WHERE email = ? AND deleted_at IS NULL`
 	// run
 	logf(sqlstr, email)
 	u := User{
 		_exists: true,
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, email).Scan(&u.UserID, &u.Username, &u.Email, &u.CreatedAt, &u.UpdatedAt); err != nil {
+	if err := db.QueryRowContext(ctx, sqlstr, email).Scan(&u.UserID, &u.Username, &u.Email, &u.CreatedAt, &u.UpdatedAt, &u.PasswordHash, &u.DeletedAt, &u.Version); err != nil {
 		return nil, logerror(err)
 	}
 	return &u, nil
@@ -282,15 +1203,15 @@ func UserByEmail(ctx context.Context, db DB, email string) (*User, error) {
 func UserByUsername(ctx context.Context, db DB, username string) (*User, error) {
 	// query
 	const sqlstr = `SELECT ` +
-		`user_id, username, email, created_at, updated_at ` +
+		`user_id, username, email, created_at, updated_at, password_hash, deleted_at, version ` +
 		`FROM User ` +
-		`WHERE username = ?`
+		`WHERE username = ? AND deleted_at IS NULL`
 	// run
 	logf(sqlstr, username)
 	u := User{
 		_exists: true,
 	}
-	if err := db.QueryRowContext(ctx, sqlstr, username).Scan(&u.UserID, &u.Username, &u.Email, &u.CreatedAt, &u.UpdatedAt); err != nil {
+	if err := db.QueryRowContext(ctx, sqlstr, username).Scan(&u.UserID, &u.Username, &u.Email, &u.CreatedAt, &u.UpdatedAt, &u.PasswordHash, &u.DeletedAt, &u.Version); err != nil {
 		return nil, logerror(err)
 	}
 	return &u, nil
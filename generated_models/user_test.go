@@ -0,0 +1,373 @@
+package generated_models
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imran31415/example-project-proto-db/internal/fakedb"
+)
+
+// The values the database assigns to created_at and updated_at.
+var (
+	storedCreatedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	storedUpdatedAt = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+)
+
+// storedUser returns the row of a stored user at version.
+func storedUser(id, version int64, deletedAt driver.Value) []driver.Value {
+	return []driver.Value{id, "stored", "stored@example.com", storedCreatedAt, storedUpdatedAt, "", deletedAt, version}
+}
+
+// userTable returns a User table holding rows, which assigns the stored
+// created_at and updated_at to the rows inserted without them.
+func userTable(rows ...[]driver.Value) *fakedb.Table {
+	return &fakedb.Table{
+		Columns:  []string{"user_id", "username", "email", "created_at", "updated_at", "password_hash", "deleted_at", "version"},
+		Rows:     rows,
+		Defaults: map[string]driver.Value{"created_at": storedCreatedAt, "updated_at": storedUpdatedAt, "version": int64(1)},
+	}
+}
+
+// usersDB returns a handler for a database holding users.
+func usersDB(users *fakedb.Table) *fakedb.Handler {
+	return fakedb.Tables(map[string]*fakedb.Table{"User": users})
+}
+
+func TestUserUpdateLeavesUpdatedAt(t *testing.T) {
+	h := usersDB(userTable(storedUser(7, 3, nil)))
+	db := fakedb.Open(h)
+	defer db.Close()
+
+	loaded := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	u := &User{UserID: 7, Username: "alice", CreatedAt: loaded, UpdatedAt: loaded, Version: 3, _exists: true}
+	if err := u.Update(context.Background(), db); err != nil {
+		t.Fatal(err)
+	}
+
+	stmts := h.Statements()
+	if len(stmts) != 2 {
+		t.Fatalf("ran %d statements, want an update and a read back", len(stmts))
+	}
+	if update := stmts[0].Query; strings.Contains(update, "updated_at") {
+		t.Errorf("update %q sets updated_at", update)
+	}
+	if want := "SELECT updated_at FROM User WHERE user_id = ?"; stmts[1].Query != want {
+		t.Errorf("got read back %q, want %q", stmts[1].Query, want)
+	}
+	if !u.UpdatedAt.Equal(storedUpdatedAt) {
+		t.Errorf("got updated_at %v, want the stored %v", u.UpdatedAt, storedUpdatedAt)
+	}
+	if u.Version != 4 {
+		t.Errorf("got version %d, want 4", u.Version)
+	}
+}
+
+func TestUserUpsert(t *testing.T) {
+	tests := []struct {
+		name string
+		user User
+		// columns are the inserted columns
+		columns []string
+		// update is the ON DUPLICATE KEY UPDATE list
+		update string
+		// stored is the row stored before the upsert, if any
+		stored []driver.Value
+		id     int
+	}{
+		{
+			name:    "generated id",
+			user:    User{Username: "alice", Email: "alice@example.com"},
+			columns: []string{"username", "email", "password_hash", "deleted_at"},
+			update:  "username = VALUES(username), email = VALUES(email), password_hash = VALUES(password_hash), user_id = LAST_INSERT_ID(user_id)",
+			id:      42,
+		},
+		{
+			name:    "set id and updated_at",
+			user:    User{UserID: 7, Username: "alice", Email: "alice@example.com", UpdatedAt: time.Now()},
+			columns: []string{"username", "email", "password_hash", "deleted_at", "updated_at", "user_id"},
+			update:  "username = VALUES(username), email = VALUES(email), password_hash = VALUES(password_hash)",
+			stored:  storedUser(7, 1, nil),
+			id:      7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := userTable()
+			users.AutoIncrement = 42
+			if tt.stored != nil {
+				users.Rows = append(users.Rows, tt.stored)
+			}
+			h := usersDB(users)
+			db := fakedb.Open(h)
+			defer db.Close()
+
+			u := tt.user
+			if err := u.Upsert(context.Background(), db); err != nil {
+				t.Fatal(err)
+			}
+
+			stmts := h.Statements()
+			if len(stmts) != 2 {
+				t.Fatalf("ran %d statements, want an upsert and a read back", len(stmts))
+			}
+			list, rest, _ := strings.Cut(strings.TrimPrefix(stmts[0].Query, "INSERT INTO User ("), ")")
+			if columns := strings.Split(list, ", "); !slices.Equal(columns, tt.columns) {
+				t.Errorf("got columns %v, want %v", columns, tt.columns)
+			}
+			if _, update, _ := strings.Cut(rest, "ON DUPLICATE KEY UPDATE "); update != tt.update {
+				t.Errorf("got update %q, want %q", update, tt.update)
+			}
+			// the defaults are read back from the row the upsert wrote
+			if args := stmts[1].Args; len(args) != 1 || args[0] != int64(tt.id) {
+				t.Errorf("read back with %v, want [%d]", args, tt.id)
+			}
+			if u.UserID != tt.id {
+				t.Errorf("got user_id %d, want %d", u.UserID, tt.id)
+			}
+			if !u.UpdatedAt.Equal(storedUpdatedAt) || !u.CreatedAt.Equal(storedCreatedAt) {
+				t.Errorf("got created_at %v and updated_at %v, want the stored values", u.CreatedAt, u.UpdatedAt)
+			}
+		})
+	}
+}
+
+// insertColumns returns the columns of an INSERT statement and the rest of it.
+func insertColumns(query string) ([]string, string) {
+	_, query, _ = strings.Cut(query, "(")
+	list, rest, _ := strings.Cut(query, ")")
+	return strings.Split(list, ", "), rest
+}
+
+// statementSizes returns the rows of each INSERT statement run through h.
+func statementSizes(h *fakedb.Handler) []int {
+	var sizes []int
+	for _, s := range h.Statements() {
+		if strings.HasPrefix(s.Query, "INSERT") {
+			sizes = append(sizes, strings.Count(s.Query, "(?"))
+		}
+	}
+	return sizes
+}
+
+// setBatchLimits sets the batch limits for the test, restoring the defaults
+// after.
+func setBatchLimits(t *testing.T, placeholders, bytes int) {
+	SetBatchLimits(placeholders, bytes)
+	t.Cleanup(func() { SetBatchLimits(65535, 4<<20) })
+}
+
+func TestUpsertUsers(t *testing.T) {
+	users := userTable(storedUser(7, 1, nil), storedUser(9, 1, nil))
+	h := usersDB(users)
+	db := fakedb.Open(h)
+	defer db.Close()
+
+	// a mixed batch: 7 and 9 are stored and updated, 8 is new and inserted
+	// with a set updated_at, which updates must not overwrite
+	updatedAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	rows := []*User{
+		{UserID: 7, Username: "alice", Email: "alice@example.com"},
+		{UserID: 8, Username: "bob", Email: "bob@example.com", UpdatedAt: updatedAt},
+		{UserID: 9, Username: "carol", Email: "carol@example.com"},
+	}
+	if err := UpsertUsers(context.Background(), db, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	stmts := h.Statements()
+	var upserts []fakedb.Statement
+	for _, s := range stmts {
+		if strings.HasPrefix(s.Query, "INSERT") {
+			upserts = append(upserts, s)
+		}
+	}
+	// the row with updated_at set binds other columns, so goes in its own statement
+	if len(upserts) != 2 {
+		t.Fatalf("ran %d upserts, want 2", len(upserts))
+	}
+	for _, s := range upserts {
+		columns, rest := insertColumns(s.Query)
+		if columns[0] != "user_id" {
+			t.Errorf("upsert %q does not bind user_id", s.Query)
+		}
+		_, update, _ := strings.Cut(rest, "ON DUPLICATE KEY UPDATE ")
+		if strings.Contains(update, "user_id") || strings.Contains(update, "updated_at") {
+			t.Errorf("upsert %q updates user_id or updated_at", s.Query)
+		}
+	}
+	if args := upserts[0].Args; len(args) != 10 || args[0] != int64(7) || args[5] != int64(9) {
+		t.Errorf("got first upsert args %v, want users 7 and 9", args)
+	}
+	for _, u := range rows {
+		if !u._exists {
+			t.Errorf("user %d not marked as existing", u.UserID)
+		}
+		want := storedUpdatedAt
+		if u.UserID == 8 {
+			want = updatedAt
+		}
+		if !u.UpdatedAt.Equal(want) || !u.CreatedAt.Equal(storedCreatedAt) {
+			t.Errorf("user %d: got created_at %v and updated_at %v, want %v and %v", u.UserID, u.CreatedAt, u.UpdatedAt, storedCreatedAt, want)
+		}
+	}
+	if len(users.Rows) != 3 {
+		t.Errorf("got %d stored users, want 3", len(users.Rows))
+	}
+}
+
+func TestUpsertUsersPrimaryKeyUnset(t *testing.T) {
+	h := usersDB(userTable())
+	db := fakedb.Open(h)
+	defer db.Close()
+
+	rows := []*User{
+		{UserID: 7, Username: "alice", Email: "alice@example.com"},
+		{Username: "bob", Email: "bob@example.com"},
+	}
+	if err := UpsertUsers(context.Background(), db, rows); !errors.Is(err, ErrPrimaryKeyUnset) {
+		t.Fatalf("got %v, want %v", err, ErrPrimaryKeyUnset)
+	}
+	if stmts := h.Statements(); len(stmts) != 0 {
+		t.Errorf("ran %v, want no statements", stmts)
+	}
+}
+
+// batchLimits are the arguments of SetBatchLimits.
+type batchLimits struct {
+	placeholders, bytes int
+}
+
+func TestBatchLimits(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		// the limits of the inserts, which bind four columns a row, and of the
+		// upserts, which bind user_id too
+		insert, upsert batchLimits
+		// sizes are the rows of each statement
+		sizes []int
+	}{
+		{
+			name:   "placeholders",
+			names:  []string{"a", "b", "c", "d", "e"},
+			insert: batchLimits{9, 1 << 20},
+			upsert: batchLimits{11, 1 << 20},
+			sizes:  []int{2, 2, 1},
+		},
+		{
+			name:   "bytes",
+			names:  []string{"a", strings.Repeat("b", 150), "c", "d"},
+			insert: batchLimits{65535, 200},
+			upsert: batchLimits{65535, 250},
+			sizes:  []int{1, 1, 2},
+		},
+		{
+			name:   "row over the limits",
+			names:  []string{"a", "b"},
+			insert: batchLimits{2, 1 << 20},
+			upsert: batchLimits{2, 1 << 20},
+			sizes:  []int{1, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newUsers := func(firstID int) []*User {
+				rows := make([]*User, len(tt.names))
+				for i, name := range tt.names {
+					rows[i] = &User{Username: name, Email: name + "@example.com"}
+					if firstID != 0 {
+						rows[i].UserID = firstID + i
+					}
+				}
+				return rows
+			}
+
+			setBatchLimits(t, tt.insert.placeholders, tt.insert.bytes)
+			users := userTable()
+			users.AutoIncrement = 100
+			h := usersDB(users)
+			db := fakedb.Open(h)
+			defer db.Close()
+			inserted := newUsers(0)
+			if err := InsertUsers(context.Background(), db, inserted); err != nil {
+				t.Fatal(err)
+			}
+			if sizes := statementSizes(h); !slices.Equal(sizes, tt.sizes) {
+				t.Errorf("got inserts of %v rows, want %v", sizes, tt.sizes)
+			}
+			// the ids of each statement follow on from its LastInsertId
+			for i, u := range inserted {
+				if u.UserID != 100+i {
+					t.Errorf("user %d got id %d, want %d", i, u.UserID, 100+i)
+				}
+				if !u.CreatedAt.Equal(storedCreatedAt) {
+					t.Errorf("user %d: got created_at %v, want the stored value", i, u.CreatedAt)
+				}
+			}
+
+			setBatchLimits(t, tt.upsert.placeholders, tt.upsert.bytes)
+			h = usersDB(userTable())
+			upsertDB := fakedb.Open(h)
+			defer upsertDB.Close()
+			upserted := newUsers(100)
+			if err := UpsertUsers(context.Background(), upsertDB, upserted); err != nil {
+				t.Fatal(err)
+			}
+			if sizes := statementSizes(h); !slices.Equal(sizes, tt.sizes) {
+				t.Errorf("got upserts of %v rows, want %v", sizes, tt.sizes)
+			}
+			for i, u := range upserted {
+				if u.UserID != 100+i || !u.UpdatedAt.Equal(storedUpdatedAt) {
+					t.Errorf("user %d: got id %d and updated_at %v, want %d and the stored value", i, u.UserID, u.UpdatedAt, 100+i)
+				}
+			}
+		})
+	}
+}
+
+func TestUserUpsertKeepsSoftDelete(t *testing.T) {
+	deletedAt := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	// the conflicting rows are soft deleted
+	h := usersDB(userTable(storedUser(7, 1, deletedAt), storedUser(8, 1, deletedAt)))
+	db := fakedb.Open(h)
+	defer db.Close()
+
+	u := &User{UserID: 7, Username: "alice", Email: "alice@example.com"}
+	if err := u.Upsert(context.Background(), db); err != nil {
+		t.Fatal(err)
+	}
+	rows := []*User{{UserID: 8, Username: "bob", Email: "bob@example.com"}}
+	if err := UpsertUsers(context.Background(), db, rows); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range h.Statements() {
+		if _, update, ok := strings.Cut(s.Query, "ON DUPLICATE KEY UPDATE "); ok && strings.Contains(update, "deleted_at") {
+			t.Errorf("upsert %q updates deleted_at", s.Query)
+		}
+	}
+	for _, u := range append(rows, u) {
+		if !u.Deleted() || !u.DeletedAt.Time.Equal(deletedAt) {
+			t.Errorf("user %d: got deleted %v at %v, want the stored deletion", u.UserID, u.Deleted(), u.DeletedAt)
+		}
+	}
+}
+
+func TestUserDeleteNoRow(t *testing.T) {
+	h := usersDB(userTable())
+	db := fakedb.Open(h)
+	defer db.Close()
+
+	u := &User{UserID: 7, _exists: true}
+	if err := u.Delete(context.Background(), db); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("got %v, want %v", err, sql.ErrNoRows)
+	}
+	if u.Deleted() {
+		t.Error("user marked as deleted")
+	}
+}
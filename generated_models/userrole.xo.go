@@ -4,8 +4,10 @@ package generated_models
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"strings"
 	"time"
 )
@@ -15,6 +17,407 @@ type UserRole struct {
 	UserID     int       `json:"user_id"`     // user_id
 	RoleID     int       `json:"role_id"`     // role_id
 	AssignedAt time.Time `json:"assigned_at"` // assigned_at
+	// xo fields
+	_exists, _deleted bool
+}
+
+// Exists returns true when the [UserRole] exists in the database.
+func (ur *UserRole) Exists() bool {
+	return ur._exists
+}
+
+// Deleted returns true when the [UserRole] has been marked for deletion
+// from the database.
+func (ur *UserRole) Deleted() bool {
+	return ur._deleted
+}
+
+// auditBefore loads the stored [UserRole] as the before image of an audit
+// entry. It returns nil when no audit hook is set or no row is stored.
+func (ur *UserRole) auditBefore(ctx context.Context, db DB) (*UserRole, error) {
+	if auditHook == nil {
+		return nil, nil
+	}
+	const sqlstr = `SELECT ` +
+		`user_id, role_id, assigned_at ` +
+		`FROM UserRole ` +
+		`WHERE user_id = ? AND role_id = ?`
+	logf(sqlstr, ur.UserID, ur.RoleID)
+	before := UserRole{
+		_exists: true,
+	}
+	switch err := db.QueryRowContext(ctx, sqlstr, ur.UserID, ur.RoleID).Scan(&before.UserID, &before.RoleID, &before.AssignedAt); {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, logerror(err)
+	}
+	return &before, nil
+}
+
+// audit passes a mutation of the [UserRole] to the audit hook, with the row
+// before and after it, either of which may be nil.
+func (ur *UserRole) audit(ctx context.Context, db DB, action string, before, after *UserRole) error {
+	if auditHook == nil {
+		return nil
+	}
+	entry := AuditEntry{
+		Table:      "UserRole",
+		Action:     action,
+		PrimaryKey: auditKey(ur.UserID, ur.RoleID),
+	}
+	// keep typed nils out of the interfaces
+	if before != nil {
+		entry.Before = before
+	}
+	if after != nil {
+		entry.After = after
+	}
+	if err := auditHook(ctx, db, entry); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// Insert inserts the [UserRole] to the database.
+func (ur *UserRole) Insert(ctx context.Context, db DB) error {
+	switch {
+	case ur._exists: // already exists
+		return logerror(&ErrInsertFailed{ErrAlreadyExists})
+	case ur._deleted: // deleted
+		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+	}
+	// run the BeforeInsert hook
+	if err := beforeInsert(ctx, db, ur); err != nil {
+		return logerror(&ErrInsertFailed{err})
+	}
+	// insert (manual), leaving
+	// unset columns with a database default to the database
+	columns := []string{"user_id", "role_id"}
+	args := []interface{}{ur.UserID, ur.RoleID}
+	var defaults []string
+	var dest []interface{}
+	if ur.AssignedAt.IsZero() {
+		defaults, dest = append(defaults, "assigned_at"), append(dest, &ur.AssignedAt)
+	} else {
+		columns, args = append(columns, "assigned_at"), append(args, ur.AssignedAt)
+	}
+	sqlstr := `INSERT INTO UserRole (` + strings.Join(columns, ", ") + `) ` +
+		`VALUES (` + bindvars(len(args)) + `)`
+	// run
+	logf(sqlstr, args...)
+	if _, err := db.ExecContext(ctx, sqlstr, args...); err != nil {
+		return logerror(err)
+	}
+	// read back the values the database assigned
+	if len(defaults) != 0 {
+		sqlstr := `SELECT ` + strings.Join(defaults, ", ") + ` ` +
+			`FROM UserRole ` +
+			`WHERE user_id = ? AND role_id = ?`
+		logf(sqlstr, ur.UserID, ur.RoleID)
+		if err := db.QueryRowContext(ctx, sqlstr, ur.UserID, ur.RoleID).Scan(dest...); err != nil {
+			return logerror(err)
+		}
+	}
+	// set exists
+	ur._exists = true
+	// run the AfterInsert hook
+	if err := afterInsert(ctx, db, ur); err != nil {
+		return logerror(&ErrInsertFailed{err})
+	}
+	return ur.audit(ctx, db, "insert", nil, ur)
+}
+
+// Update updates a [UserRole] in the database.
+func (ur *UserRole) Update(ctx context.Context, db DB) error {
+	switch {
+	case !ur._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case ur._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// run the BeforeUpdate hook
+	if err := beforeUpdate(ctx, db, ur); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	before, err := ur.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// update with primary key, leaving unset columns
+	// with a database default unchanged
+	set := []string{}
+	args := []interface{}{}
+	if !ur.AssignedAt.IsZero() {
+		set, args = append(set, "assigned_at = ?"), append(args, ur.AssignedAt)
+	}
+	sqlstr := `UPDATE UserRole SET ` + strings.Join(set, ", ") + ` ` +
+		`WHERE user_id = ? AND role_id = ?`
+	args = append(args, ur.UserID, ur.RoleID)
+	// run
+	if len(set) != 0 {
+		logf(sqlstr, args...)
+		if _, err := db.ExecContext(ctx, sqlstr, args...); err != nil {
+			return logerror(err)
+		}
+	}
+	// run the AfterUpdate hook
+	if err := afterUpdate(ctx, db, ur); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	return ur.audit(ctx, db, "update", before, ur)
+}
+
+// Save saves the [UserRole] to the database.
+func (ur *UserRole) Save(ctx context.Context, db DB) error {
+	if ur.Exists() {
+		return ur.Update(ctx, db)
+	}
+	return ur.Insert(ctx, db)
+}
+
+// Upsert performs an upsert for [UserRole].
+func (ur *UserRole) Upsert(ctx context.Context, db DB) error {
+	switch {
+	case ur._deleted: // deleted
+		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+	}
+	// run the BeforeInsert hook
+	if err := beforeInsert(ctx, db, ur); err != nil {
+		return logerror(&ErrUpsertFailed{err})
+	}
+	before, err := ur.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// upsert, leaving unset columns with a database default to the database
+	// or, on conflict, to their stored values
+	columns := []string{"user_id", "role_id"}
+	args := []interface{}{ur.UserID, ur.RoleID}
+	update := []string{}
+	var defaults []string
+	var dest []interface{}
+	if ur.AssignedAt.IsZero() {
+		defaults, dest = append(defaults, "assigned_at"), append(dest, &ur.AssignedAt)
+	} else {
+		columns, args = append(columns, "assigned_at"), append(args, ur.AssignedAt)
+		update = append(update, "assigned_at = VALUES(assigned_at)")
+	}
+	if len(update) == 0 { // keep the stored row
+		update = append(update, "user_id = user_id")
+	}
+	sqlstr := `INSERT INTO UserRole (` + strings.Join(columns, ", ") + `) ` +
+		`VALUES (` + bindvars(len(args)) + `) ` +
+		`ON DUPLICATE KEY UPDATE ` + strings.Join(update, ", ")
+	// run
+	logf(sqlstr, args...)
+	if _, err := db.ExecContext(ctx, sqlstr, args...); err != nil {
+		return logerror(err)
+	}
+	// read back the values the database assigned or kept
+	if len(defaults) != 0 {
+		sqlstr := `SELECT ` + strings.Join(defaults, ", ") + ` ` +
+			`FROM UserRole ` +
+			`WHERE user_id = ? AND role_id = ?`
+		logf(sqlstr, ur.UserID, ur.RoleID)
+		if err := db.QueryRowContext(ctx, sqlstr, ur.UserID, ur.RoleID).Scan(dest...); err != nil {
+			return logerror(err)
+		}
+	}
+	// set exists
+	ur._exists = true
+	// run the AfterInsert hook
+	if err := afterInsert(ctx, db, ur); err != nil {
+		return logerror(&ErrUpsertFailed{err})
+	}
+	return ur.audit(ctx, db, "upsert", before, ur)
+}
+
+// Delete deletes the [UserRole] from the database.
+func (ur *UserRole) Delete(ctx context.Context, db DB) error {
+	switch {
+	case !ur._exists: // doesn't exist
+		return nil
+	case ur._deleted: // deleted
+		return nil
+	}
+	// run the BeforeDelete hook
+	if err := beforeDelete(ctx, db, ur); err != nil {
+		return logerror(err)
+	}
+	before, err := ur.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// delete with composite primary key
+	const sqlstr = `DELETE FROM UserRole ` +
+		`WHERE user_id = ? AND role_id = ?`
+	// run
+	logf(sqlstr, ur.UserID, ur.RoleID)
+	if _, err := db.ExecContext(ctx, sqlstr, ur.UserID, ur.RoleID); err != nil {
+		return logerror(err)
+	}
+	// set deleted
+	ur._deleted = true
+	// run the AfterDelete hook
+	if err := afterDelete(ctx, db, ur); err != nil {
+		return logerror(err)
+	}
+	return ur.audit(ctx, db, "delete", before, nil)
+}
+
+// UserRoleColumn is a column name of 'UserRole'.
+type UserRoleColumn string
+
+// UserRoleColumn values.
+const (
+	// UserRoleColumnUserID is the 'user_id' column.
+	UserRoleColumnUserID UserRoleColumn = "user_id"
+	// UserRoleColumnRoleID is the 'role_id' column.
+	UserRoleColumnRoleID UserRoleColumn = "role_id"
+	// UserRoleColumnAssignedAt is the 'assigned_at' column.
+	UserRoleColumnAssignedAt UserRoleColumn = "assigned_at"
+)
+
+// Valid returns true when the [UserRoleColumn] is a column of 'UserRole'.
+func (c UserRoleColumn) Valid() bool {
+	switch c {
+	case UserRoleColumnUserID, UserRoleColumnRoleID, UserRoleColumnAssignedAt:
+		return true
+	}
+	return false
+}
+
+// Nullable returns true when the [UserRoleColumn] is a column of
+// 'UserRole' that may hold NULL.
+func (c UserRoleColumn) Nullable() bool {
+	return false
+}
+
+// ParseUserRoleColumn parses a client supplied column name, returning
+// [ErrUnknownColumn] when it is not a column of 'UserRole'.
+func ParseUserRoleColumn(s string) (UserRoleColumn, error) {
+	if c := UserRoleColumn(s); c.Valid() {
+		return c, nil
+	}
+	return "", &ErrUnknownColumn{Table: "UserRole", Column: s}
+}
+
+// ColumnValue returns the value of column for the [UserRole], or nil when
+// column is not a valid [UserRoleColumn].
+func (ur *UserRole) ColumnValue(column UserRoleColumn) interface{} {
+	switch column {
+	case UserRoleColumnUserID:
+		return ur.UserID
+	case UserRoleColumnRoleID:
+		return ur.RoleID
+	case UserRoleColumnAssignedAt:
+		return ur.AssignedAt
+	}
+	return nil
+}
+
+// UpdateColumns updates only the listed columns of the [UserRole] in the
+// database. Unlisted columns keep their stored values, so columns the database
+// maintains itself, such as ON UPDATE CURRENT_TIMESTAMP, are left to it. The
+// row is then reloaded so the [UserRole] reflects the stored values.
+func (ur *UserRole) UpdateColumns(ctx context.Context, db DB, columns ...UserRoleColumn) error {
+	switch {
+	case !ur._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case ur._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// check the listed columns
+	var listed []UserRoleColumn
+	seen := make(map[UserRoleColumn]bool)
+	for _, c := range columns {
+		switch {
+		case !c.Valid():
+			return logerror(&ErrUpdateFailed{&ErrUnknownColumn{Table: "UserRole", Column: string(c)}})
+		case c == UserRoleColumnUserID || c == UserRoleColumnRoleID:
+			return logerror(&ErrUpdateFailed{ErrPrimaryKeyColumn})
+		case seen[c]:
+			continue
+		}
+		seen[c] = true
+		listed = append(listed, c)
+	}
+	if len(listed) == 0 {
+		return nil
+	}
+	// run the BeforeUpdate hook
+	if err := beforeUpdate(ctx, db, ur); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	// build the set list from the values the hook left
+	var set []string
+	var args []interface{}
+	for _, c := range listed {
+		set = append(set, string(c)+" = ?")
+		args = append(args, ur.ColumnValue(c))
+	}
+	before, err := ur.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+	// update with primary key
+	sqlstr := `UPDATE UserRole SET ` + strings.Join(set, ", ") + ` ` +
+		`WHERE user_id = ? AND role_id = ?`
+	args = append(args, ur.UserID, ur.RoleID)
+	// run
+	logf(sqlstr, args...)
+	if _, err := db.ExecContext(ctx, sqlstr, args...); err != nil {
+		return logerror(err)
+	}
+	// reload
+	const selstr = `SELECT ` +
+		`user_id, role_id, assigned_at ` +
+		`FROM UserRole ` +
+		`WHERE user_id = ? AND role_id = ?`
+	logf(selstr, ur.UserID, ur.RoleID)
+	if err := db.QueryRowContext(ctx, selstr, ur.UserID, ur.RoleID).Scan(&ur.UserID, &ur.RoleID, &ur.AssignedAt); err != nil {
+		return logerror(err)
+	}
+	// run the AfterUpdate hook
+	if err := afterUpdate(ctx, db, ur); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	return ur.audit(ctx, db, "update", before, ur)
+}
+
+// decodeUserRoleColumnValue decodes a JSON encoded value of column.
+func decodeUserRoleColumnValue(column UserRoleColumn, buf []byte) (interface{}, error) {
+	switch column {
+	case UserRoleColumnUserID:
+		var v int
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case UserRoleColumnRoleID:
+		var v int
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	case UserRoleColumnAssignedAt:
+		var v time.Time
+		err := json.Unmarshal(buf, &v)
+		return v, err
+	}
+	return nil, &ErrUnknownColumn{Table: "UserRole", Column: string(column)}
+}
+
+// UserRoleFilters holds a typed predicate builder for each column of 'UserRole'.
+type UserRoleFilters struct {
+	UserID     ColumnFilter[UserRole, int]
+	RoleID     ColumnFilter[UserRole, int]
+	AssignedAt ColumnFilter[UserRole, time.Time]
+}
+
+// UserRoleFilter builds predicates over 'UserRole' for
+// [UserRoleKeysetPage], [UserRoleCount] and [UserRoleDeleteWhere].
+var UserRoleFilter = UserRoleFilters{
+	UserID:     ColumnFilter[UserRole, int]{column: "user_id"},
+	RoleID:     ColumnFilter[UserRole, int]{column: "role_id"},
+	AssignedAt: ColumnFilter[UserRole, time.Time]{column: "assigned_at"},
 }
 
 // UserRoleKeysetPage retrieves a page of [UserRole] records using keyset pagination with dynamic filtering.
@@ -25,64 +428,47 @@ type UserRole struct {
 // If `order` is `ASC`, it retrieves records where the value of `column` is greater than `key`.
 // If `order` is `DESC`, it retrieves records where the value of `column` is less than `key`.
 //
-// Filters are dynamically provided via a `filters` map, where keys are column names and values are either single values or slices for `IN` clauses.
-func UserRoleKeysetPage(ctx context.Context, db DB, column string, key interface{}, limit int, order string, filters map[string]interface{}) ([]*UserRole, *UserRole, error) {
+// Rows are further restricted by `where`, built from [UserRoleFilter]; the zero
+// [Predicate] applies no filter.
+//
+// `column` must be a valid [UserRoleColumn], otherwise [ErrUnknownColumn] is
+// returned before any SQL is built. Nullable columns return [ErrNullableColumn].
+func UserRoleKeysetPage(ctx context.Context, db DB, column UserRoleColumn, key interface{}, limit int, order string, where Predicate[UserRole]) ([]*UserRole, *UserRole, error) {
 	if order != "ASC" && order != "DESC" {
 		return nil, nil, fmt.Errorf("invalid order: %s", order)
 	}
 
+	// Only known columns may be interpolated into the query
+	if !column.Valid() {
+		return nil, nil, logerror(&ErrUnknownColumn{Table: "UserRole", Column: string(column)})
+	}
+	if column.Nullable() {
+		return nil, nil, logerror(ErrNullableColumn)
+	}
+
 	// Start building the query
 	query := fmt.Sprintf(
-		`SELECT * FROM UserRole 
-         WHERE %s %s ?`,
+		`SELECT user_id, role_id, assigned_at `+
+			`FROM UserRole WHERE %s %s ?`,
 		column, condition(order),
 	)
 
 	// Arguments for the query
 	args := []interface{}{key}
 
-	// Dynamically add filters from the `filters` map to the query
-	for field, value := range filters {
-		switch v := value.(type) {
-		case []int:
-			if len(v) > 0 {
-				placeholders := make([]string, len(v))
-				for i := range v {
-					placeholders[i] = "?"
-					args = append(args, v[i])
-				}
-				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
-			}
-		case []string:
-			if len(v) > 0 {
-				placeholders := make([]string, len(v))
-				for i := range v {
-					placeholders[i] = "?"
-					args = append(args, v[i])
-				}
-				query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
-			}
-		default:
-			// Handle NULL and NOT NULL checks
-			if value == nil {
-				query += fmt.Sprintf(" AND %s IS NULL", field)
-			} else if value == "NOT NULL" {
-				query += fmt.Sprintf(" AND %s IS NOT NULL", field)
-			} else {
-				query += fmt.Sprintf(" AND %s = ?", field)
-				args = append(args, value)
-			}
-		}
+	// Add the filter predicate
+	if !where.IsZero() {
+		cond, condArgs := where.SQL()
+		query += " AND (" + cond + ")"
+		args = append(args, condArgs...)
 	}
 
 	// Finalize the query with the order and limit
 	query += fmt.Sprintf(" ORDER BY %s %s LIMIT ?", column, order)
 	args = append(args, limit)
 
-	// Log the final query for debugging purposes
-	log.Printf("Executing query: %s with args: %v", query, args)
-
 	// Execute the query
+	logf(query, args...)
 	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, nil, logerror(err)
@@ -93,7 +479,9 @@ func UserRoleKeysetPage(ctx context.Context, db DB, column string, key interface
 	var lastItem *UserRole // Variable to store the last item
 
 	for rows.Next() {
-		ur := UserRole{}
+		ur := UserRole{
+			_exists: true,
+		}
 		if err := rows.Scan(
 			&ur.UserID, &ur.RoleID, &ur.AssignedAt,
 		); err != nil {
@@ -115,6 +503,414 @@ func UserRoleKeysetPage(ctx context.Context, db DB, column string, key interface
 	return results, lastItem, nil
 }
 
+// UserRolePage is a page of [UserRole] records returned by [UserRoleCursorPage].
+type UserRolePage struct {
+	Items []*UserRole
+	// NextCursor retrieves the following page. It is empty on the last page.
+	NextCursor string
+	// PrevCursor retrieves the preceding page. It is empty on the first page.
+	PrevCursor string
+}
+
+// userRoleKeysetColumns returns column followed by the primary key columns
+// of 'UserRole' that break ties between equal values of column.
+func userRoleKeysetColumns(column UserRoleColumn) []UserRoleColumn {
+	columns := []UserRoleColumn{column}
+	if column != UserRoleColumnUserID {
+		columns = append(columns, UserRoleColumnUserID)
+	}
+	if column != UserRoleColumnRoleID {
+		columns = append(columns, UserRoleColumnRoleID)
+	}
+	return columns
+}
+
+// UserRoleCursorPage retrieves a page of [UserRole] records ordered by
+// (`column`, primary key) in `order` (`ASC` or `DESC`), so that rows sharing a
+// value of `column` are neither skipped nor repeated between pages.
+//
+// An empty `cursor` retrieves the first page. Passing the returned NextCursor or
+// PrevCursor, together with the same `column` and `order`, retrieves the
+// following or preceding page. Cursors are opaque and return [ErrInvalidCursor]
+// when they are malformed or were issued for a different column or order.
+//
+// `column` must not be nullable, otherwise [ErrNullableColumn] is returned.
+// Rows are further restricted by `where`, built from [UserRoleFilter].
+func UserRoleCursorPage(ctx context.Context, db DB, column UserRoleColumn, order, cursor string, limit int, where Predicate[UserRole]) (*UserRolePage, error) {
+	switch {
+	case order != "ASC" && order != "DESC":
+		return nil, fmt.Errorf("invalid order: %s", order)
+	case limit <= 0:
+		return nil, fmt.Errorf("invalid limit: %d", limit)
+	case !column.Valid():
+		return nil, logerror(&ErrUnknownColumn{Table: "UserRole", Column: string(column)})
+	case column.Nullable():
+		return nil, logerror(ErrNullableColumn)
+	}
+	columns := userRoleKeysetColumns(column)
+	// decode the boundary row
+	var backward bool
+	var conds []string
+	var args []interface{}
+	if cursor != "" {
+		c, err := decodeCursor(cursor, string(column), order)
+		if err != nil || len(c.Values) != len(columns) {
+			return nil, logerror(ErrInvalidCursor)
+		}
+		for i, col := range columns {
+			v, err := decodeUserRoleColumnValue(col, c.Values[i])
+			if err != nil {
+				return nil, logerror(ErrInvalidCursor)
+			}
+			args = append(args, v)
+		}
+		backward = c.Backward
+	}
+	// paging backward walks the index in reverse
+	dir := order
+	if backward {
+		dir = reverse(order)
+	}
+	if cursor != "" {
+		conds = append(conds, keysetCondition(columns, dir))
+	}
+	if !where.IsZero() {
+		cond, condArgs := where.SQL()
+		conds = append(conds, "("+cond+")")
+		args = append(args, condArgs...)
+	}
+	// query
+	sqlstr := `SELECT ` +
+		`user_id, role_id, assigned_at ` +
+		`FROM UserRole`
+	if len(conds) != 0 {
+		sqlstr += ` WHERE ` + strings.Join(conds, " AND ")
+	}
+	sqlstr += ` ORDER BY ` + keysetOrderBy(columns, dir) + ` LIMIT ?`
+	// fetch one extra row to learn whether another page follows
+	args = append(args, limit+1)
+	// run
+	logf(sqlstr, args...)
+	rows, err := db.QueryContext(ctx, sqlstr, args...)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*UserRole
+	for rows.Next() {
+		ur := UserRole{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan(&ur.UserID, &ur.RoleID, &ur.AssignedAt); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &ur)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	more := len(res) > limit
+	if more {
+		res = res[:limit]
+	}
+	if backward {
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+	page := &UserRolePage{Items: res}
+	if len(res) == 0 {
+		return page, nil
+	}
+	// a backward page always has rows after it, and a forward page from a
+	// cursor always has rows before it
+	hasNext, hasPrev := more, cursor != ""
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+	if hasNext {
+		if page.NextCursor, err = userRoleCursor(res[len(res)-1], column, order, false); err != nil {
+			return nil, logerror(err)
+		}
+	}
+	if hasPrev {
+		if page.PrevCursor, err = userRoleCursor(res[0], column, order, true); err != nil {
+			return nil, logerror(err)
+		}
+	}
+	return page, nil
+}
+
+// userRoleCursor encodes the position of ur as a cursor for [UserRoleCursorPage].
+func userRoleCursor(ur *UserRole, column UserRoleColumn, order string, backward bool) (string, error) {
+	var values []interface{}
+	for _, col := range userRoleKeysetColumns(column) {
+		values = append(values, ur.ColumnValue(col))
+	}
+	return encodeCursor(string(column), order, backward, values)
+}
+
+// UserRoleCount returns the number of [UserRole] records matching `where`.
+func UserRoleCount(ctx context.Context, db DB, where Predicate[UserRole]) (int64, error) {
+	cond, args := where.SQL()
+	sqlstr := `SELECT COUNT(*) FROM UserRole WHERE ` + cond
+	// run
+	logf(sqlstr, args...)
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlstr, args...).Scan(&count); err != nil {
+		return 0, logerror(err)
+	}
+	return count, nil
+}
+
+// UserRoleDeleteWhere deletes the [UserRole] records matching `where`,
+// returning the number of records deleted. The zero [Predicate] is rejected with
+// [ErrEmptyPredicate] rather than deleting every record.
+func UserRoleDeleteWhere(ctx context.Context, db DB, where Predicate[UserRole]) (int64, error) {
+	if where.IsZero() {
+		return 0, logerror(ErrEmptyPredicate)
+	}
+	cond, args := where.SQL()
+	// with an audit hook or delete hooks the matching rows are locked and
+	// deleted one by one, so that each deletion is audited and hooked
+	if auditHook != nil || hasDeleteHooks(&UserRole{}) {
+		sqlstr := `SELECT ` +
+			`user_id, role_id, assigned_at ` +
+			`FROM UserRole WHERE (` + cond + `) FOR UPDATE`
+		logf(sqlstr, args...)
+		rows, err := db.QueryContext(ctx, sqlstr, args...)
+		if err != nil {
+			return 0, logerror(err)
+		}
+		var matched []*UserRole
+		for rows.Next() {
+			ur := UserRole{
+				_exists: true,
+			}
+			if err := rows.Scan(&ur.UserID, &ur.RoleID, &ur.AssignedAt); err != nil {
+				rows.Close()
+				return 0, logerror(err)
+			}
+			matched = append(matched, &ur)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return 0, logerror(err)
+		}
+		for _, ur := range matched {
+			if err := ur.Delete(ctx, db); err != nil {
+				return 0, err
+			}
+		}
+		return int64(len(matched)), nil
+	}
+	sqlstr := `DELETE FROM UserRole WHERE ` + cond
+	// run
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
+		return 0, logerror(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, logerror(err)
+	}
+	return n, nil
+}
+
+// batchColumns returns the columns and values of the [UserRole] in a
+// multi-row insert or upsert. As with [UserRole.Insert], unset columns with a
+// database default are left out.
+func (ur *UserRole) batchColumns(upsert bool) ([]string, []interface{}) {
+	columns := []string{"user_id", "role_id"}
+	args := []interface{}{ur.UserID, ur.RoleID}
+	if !ur.AssignedAt.IsZero() {
+		columns, args = append(columns, "assigned_at"), append(args, ur.AssignedAt)
+	}
+	return columns, args
+}
+
+// readBackUserRoles reads the values of the columns with a database default
+// back into rows, after a multi-row insert or upsert left some to the database.
+func readBackUserRoles(ctx context.Context, db DB, rows []*UserRole) error {
+	type key struct {
+		UserID int
+		RoleID int
+	}
+	byKey := make(map[key]*UserRole, len(rows))
+	var args []interface{}
+	for _, ur := range rows {
+		byKey[key{ur.UserID, ur.RoleID}] = ur
+		args = append(args, ur.UserID, ur.RoleID)
+	}
+	sqlstr := `SELECT ` +
+		`user_id, role_id, assigned_at ` +
+		`FROM UserRole ` +
+		`WHERE (user_id, role_id) IN (` + batchValues(2, len(rows)) + `)`
+	// run
+	logf(sqlstr, args...)
+	res, err := db.QueryContext(ctx, sqlstr, args...)
+	if err != nil {
+		return logerror(err)
+	}
+	defer res.Close()
+	for res.Next() {
+		var stored UserRole
+		if err := res.Scan(&stored.UserID, &stored.RoleID, &stored.AssignedAt); err != nil {
+			return logerror(err)
+		}
+		if ur := byKey[key{stored.UserID, stored.RoleID}]; ur != nil {
+			ur.AssignedAt = stored.AssignedAt
+		}
+	}
+	if err := res.Err(); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+// InsertUserRoles inserts the [UserRole] rows to the database with multi-row
+// statements, split under the limits of [SetBatchLimits]. As with
+// [UserRole.Insert], unset columns with a database default are read back, and the
+// hooks and the audit hook run for every row.
+func InsertUserRoles(ctx context.Context, db DB, rows []*UserRole) error {
+	for _, ur := range rows {
+		switch {
+		case ur._exists: // already exists
+			return logerror(&ErrInsertFailed{ErrAlreadyExists})
+		case ur._deleted: // deleted
+			return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+		}
+		// run the BeforeInsert hook
+		if err := beforeInsert(ctx, db, ur); err != nil {
+			return logerror(&ErrInsertFailed{err})
+		}
+	}
+	for _, b := range batches(rows, func(ur *UserRole) ([]string, []interface{}) {
+		return ur.batchColumns(false)
+	}) {
+		// insert
+		sqlstr := `INSERT INTO UserRole (` + strings.Join(b.columns, ", ") + `) ` +
+			`VALUES ` + batchValues(len(b.columns), len(b.rows))
+		// run
+		logf(sqlstr, b.args...)
+		if _, err := db.ExecContext(ctx, sqlstr, b.args...); err != nil {
+			return logerror(err)
+		}
+		// read back the values the database assigned
+		if len(b.columns) < 3 {
+			if err := readBackUserRoles(ctx, db, b.rows); err != nil {
+				return err
+			}
+		}
+	}
+	for _, ur := range rows {
+		// set exists
+		ur._exists = true
+		// run the AfterInsert hook
+		if err := afterInsert(ctx, db, ur); err != nil {
+			return logerror(&ErrInsertFailed{err})
+		}
+		if err := ur.audit(ctx, db, "insert", nil, ur); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpsertUserRoles performs an upsert of the [UserRole] rows with multi-row
+// statements, split under the limits of [SetBatchLimits]. As with
+// [UserRole.Upsert], unset columns with a database default are left to the
+// database or, on conflict, keep their stored values, and are read back. With
+// an audit hook set, the stored rows are loaded one by one for their audit
+// entries.
+func UpsertUserRoles(ctx context.Context, db DB, rows []*UserRole) error {
+	befores := make([]*UserRole, len(rows))
+	for i, ur := range rows {
+		switch {
+		case ur._deleted: // deleted
+			return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+		}
+		// run the BeforeInsert hook
+		if err := beforeInsert(ctx, db, ur); err != nil {
+			return logerror(&ErrUpsertFailed{err})
+		}
+		before, err := ur.auditBefore(ctx, db)
+		if err != nil {
+			return err
+		}
+		befores[i] = before
+	}
+	for _, b := range batches(rows, func(ur *UserRole) ([]string, []interface{}) {
+		return ur.batchColumns(true)
+	}) {
+		// upsert, updating the columns other than the primary key on conflict
+		var update []string
+		for _, c := range b.columns {
+			switch c {
+			case "user_id", "role_id":
+			default:
+				update = append(update, c+" = VALUES("+c+")")
+			}
+		}
+		if len(update) == 0 { // keep the stored rows
+			update = append(update, "user_id = user_id")
+		}
+		sqlstr := `INSERT INTO UserRole (` + strings.Join(b.columns, ", ") + `) ` +
+			`VALUES ` + batchValues(len(b.columns), len(b.rows)) + ` ` +
+			`ON DUPLICATE KEY UPDATE ` + strings.Join(update, ", ")
+		// run
+		logf(sqlstr, b.args...)
+		if _, err := db.ExecContext(ctx, sqlstr, b.args...); err != nil {
+			return logerror(err)
+		}
+		// read back the values the database assigned or kept
+		if len(b.columns) < 3 {
+			if err := readBackUserRoles(ctx, db, b.rows); err != nil {
+				return err
+			}
+		}
+	}
+	for i, ur := range rows {
+		// set exists
+		ur._exists = true
+		// run the AfterInsert hook
+		if err := afterInsert(ctx, db, ur); err != nil {
+			return logerror(&ErrUpsertFailed{err})
+		}
+		if err := ur.audit(ctx, db, "upsert", befores[i], ur); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteUserRoles deletes the [UserRole] records with the primary keys of
+// rows, returning the number of records deleted. The rows are split into
+// statements under the limits of [SetBatchLimits], each deleting as
+// [UserRoleDeleteWhere] does.
+func DeleteUserRoles(ctx context.Context, db DB, rows []*UserRole) (int64, error) {
+	var n int64
+	for _, chunk := range chunks(rows, batchPlaceholders/2) {
+		var args []interface{}
+		for _, ur := range chunk {
+			args = append(args, ur.UserID, ur.RoleID)
+		}
+		where := Predicate[UserRole]{
+			sql:  `(user_id, role_id) IN (` + batchValues(2, len(chunk)) + `)`,
+			args: args,
+		}
+		deleted, err := UserRoleDeleteWhere(ctx, db, where)
+		n += deleted
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
 // UserRoleByRoleID retrieves a row from 'UserRole' as a [UserRole].
 //
 // Generated from index 'role_id'.
@@ -134,7 +930,9 @@ func UserRoleByRoleID(ctx context.Context, db DB, roleID int) ([]*UserRole, erro
 	// process
 	var res []*UserRole
 	for rows.Next() {
-		ur := UserRole{}
+		ur := UserRole{
+			_exists: true,
+		}
 		// scan
 		if err := rows.Scan(&ur.UserID, &ur.RoleID, &ur.AssignedAt); err != nil {
 			return nil, logerror(err)
@@ -147,6 +945,26 @@ func UserRoleByRoleID(ctx context.Context, db DB, roleID int) ([]*UserRole, erro
 	return res, nil
 }
 
+// UserRoleByUserIDRoleID retrieves a row from 'UserRole' as a [UserRole].
+//
+// Generated from index 'user_id'.
+func UserRoleByUserIDRoleID(ctx context.Context, db DB, userID, roleID int) (*UserRole, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`user_id, role_id, assigned_at ` +
+		`FROM UserRole ` +
+		`WHERE user_id = ? AND role_id = ?`
+	// run
+	logf(sqlstr, userID, roleID)
+	ur := UserRole{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, userID, roleID).Scan(&ur.UserID, &ur.RoleID, &ur.AssignedAt); err != nil {
+		return nil, logerror(err)
+	}
+	return &ur, nil
+}
+
 // UserRoleByUserID retrieves a row from 'UserRole' as a [UserRole].
 //
 // Generated from index 'user_id'.
@@ -166,7 +984,9 @@ func UserRoleByUserID(ctx context.Context, db DB, userID int) ([]*UserRole, erro
 	// process
 	var res []*UserRole
 	for rows.Next() {
-		ur := UserRole{}
+		ur := UserRole{
+			_exists: true,
+		}
 		// scan
 		if err := rows.Scan(&ur.UserID, &ur.RoleID, &ur.AssignedAt); err != nil {
 			return nil, logerror(err)
@@ -4,12 +4,17 @@ go 1.23.0
 
 require (
 	github.com/go-sql-driver/mysql v1.8.1
+	github.com/golang-jwt/jwt/v5 v5.2.1
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.24.0
 	github.com/imran31415/proto-db-translator v1.0.5
 	github.com/imran31415/protobuf-db v0.0.0-20241203231650-004f712e564c
 	github.com/kenshaw/inflector v0.3.0
 	github.com/kenshaw/snaker v0.4.2
 	github.com/xo/xo v1.0.2
+	golang.org/x/crypto v0.30.0
 	golang.org/x/tools v0.21.1-0.20240508182429-e35e4ccd0d2d
+	google.golang.org/genproto/googleapis/api v0.0.0-20241118233622-e639e219e697
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20241206012308-a4fef0638583
 	google.golang.org/grpc v1.68.1
 	google.golang.org/protobuf v1.35.2
 	mvdan.cc/gofumpt v0.7.0
@@ -31,6 +36,5 @@ require (
 	golang.org/x/net v0.32.0 // indirect
 	golang.org/x/sys v0.28.0 // indirect
 	golang.org/x/text v0.21.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20241206012308-a4fef0638583 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
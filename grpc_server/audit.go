@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/imran31415/example-project-proto-db/auth"
+	"github.com/imran31415/example-project-proto-db/generated_models"
+	db_annotations "github.com/imran31415/protobuf-db/db-annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// unauditedTables are left out of the audit trail. Refresh tokens are session
+// state churned by every login and refresh rather than changes to accounts.
+var unauditedTables = map[string]bool{
+	"RefreshToken": true,
+}
+
+// auditScope is the request on whose behalf mutations are made, recorded with
+// them in the audit trail.
+type auditScope struct {
+	method string
+	actor  *caller // nil for unauthenticated requests
+}
+
+type auditScopeKey struct{}
+
+// unaryAuditInterceptor stores the audit scope of a request in its context for
+// recordAudit. It runs after the authorization interceptor, which identifies
+// the caller.
+func unaryAuditInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	scope := auditScope{method: info.FullMethod}
+	if c, ok := callerFromContext(ctx); ok {
+		scope.actor = c
+	}
+	return handler(context.WithValue(ctx, auditScopeKey{}, scope), req)
+}
+
+// recordAudit is the generated_models audit hook. It inserts an AuditEvent
+// for the entry using the same db as the mutation, so a handler running in
+// WithTx commits the event with the change or neither.
+func recordAudit(ctx context.Context, db generated_models.DB, entry generated_models.AuditEntry) error {
+	if unauditedTables[entry.Table] {
+		return nil
+	}
+	event := &generated_models.AuditEvent{
+		Entity:   entry.Table,
+		EntityPk: entry.PrimaryKey,
+		Action:   entry.Action,
+	}
+	if scope, ok := ctx.Value(auditScopeKey{}).(auditScope); ok {
+		event.Method = scope.method
+		if scope.actor != nil {
+			event.ActorUserID = sql.NullInt64{Int64: int64(scope.actor.UserID), Valid: true}
+		}
+	}
+	var err error
+	if event.BeforeJSON, err = auditJSON(entry.Table, entry.Before); err != nil {
+		return err
+	}
+	if event.AfterJSON, err = auditJSON(entry.Table, entry.After); err != nil {
+		return err
+	}
+	if err := event.Insert(ctx, db); err != nil {
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+	return nil
+}
+
+// auditJSON encodes a generated_models row of table for an AuditEvent,
+// leaving out the columns of sensitive fields. A nil row is NULL.
+func auditJSON(table string, row interface{}) (sql.NullString, error) {
+	if row == nil {
+		return sql.NullString{}, nil
+	}
+	buf, err := json.Marshal(row)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to encode %s for audit: %w", table, err)
+	}
+	var columns map[string]json.RawMessage
+	if err := json.Unmarshal(buf, &columns); err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to encode %s for audit: %w", table, err)
+	}
+	if md := auth.File_proto_auth_proto.Messages().ByName(protoreflect.Name(table)); md != nil {
+		fields := md.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			if isSensitive(fields.Get(i)) {
+				column, _ := proto.GetExtension(fields.Get(i).Options(), db_annotations.E_DbColumn).(string)
+				delete(columns, column)
+			}
+		}
+	}
+	if buf, err = json.Marshal(columns); err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to encode %s for audit: %w", table, err)
+	}
+	return sql.NullString{String: string(buf), Valid: true}, nil
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/imran31415/example-project-proto-db/auth"
+	"github.com/imran31415/example-project-proto-db/generated_models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// caller is the authenticated identity behind a request.
+type caller struct {
+	UserID int32
+	Roles  []string
+}
+
+type callerKey struct{}
+
+// callerFromContext returns the caller the authorization interceptor stored in
+// ctx, if the request carried an access token.
+func callerFromContext(ctx context.Context) (*caller, bool) {
+	c, ok := ctx.Value(callerKey{}).(*caller)
+	return c, ok
+}
+
+// authServiceMethods maps the full method names of the AuthService RPCs, as
+// interceptors see them, to their descriptors.
+var authServiceMethods = func() map[string]protoreflect.MethodDescriptor {
+	methods := make(map[string]protoreflect.MethodDescriptor)
+	sd := auth.File_proto_auth_proto.Services().ByName("AuthService")
+	for i := 0; i < sd.Methods().Len(); i++ {
+		md := sd.Methods().Get(i)
+		methods["/"+string(sd.FullName())+"/"+string(md.Name())] = md
+	}
+	return methods
+}()
+
+// authzPolicies maps the full method names of the AuthService RPCs to their
+// authz options. RPCs without the option have no entry.
+var authzPolicies = func() map[string]*auth.AuthzPolicy {
+	policies := make(map[string]*auth.AuthzPolicy)
+	for name, md := range authServiceMethods {
+		if proto.HasExtension(md.Options(), auth.E_Authz) {
+			policies[name] = proto.GetExtension(md.Options(), auth.E_Authz).(*auth.AuthzPolicy)
+		}
+	}
+	return policies
+}()
+
+// unaryAuthzInterceptor enforces the authz policy of AuthService RPCs before
+// their handlers run.
+func (s *Server) unaryAuthzInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	msg, _ := req.(proto.Message)
+	ctx, err := s.authorize(ctx, info.FullMethod, msg)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// streamAuthzInterceptor enforces the authz policy of streaming AuthService
+// RPCs. There is no single request to read a self_field from, so only roles
+// are checked.
+func (s *Server) streamAuthzInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.authorize(ss.Context(), info.FullMethod, nil)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authzStream{ServerStream: ss, ctx: ctx})
+}
+
+// authzStream carries the caller in the context of a stream.
+type authzStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authzStream) Context() context.Context {
+	return s.ctx
+}
+
+// authorize checks the caller of method against its policy and returns ctx
+// with the caller attached. Methods of other services, such as health and
+// reflection, are not checked.
+func (s *Server) authorize(ctx context.Context, method string, req proto.Message) (context.Context, error) {
+	if !strings.HasPrefix(method, "/"+auth.AuthService_ServiceDesc.ServiceName+"/") {
+		return ctx, nil
+	}
+	policy, ok := authzPolicies[method]
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "%s has no authorization policy", method)
+	}
+
+	// public RPCs such as Login must work with a stale token in the metadata
+	token, err := bearerToken(ctx)
+	switch {
+	case policy.GetPublic() && (err != nil || token == ""):
+		return ctx, nil
+	case err != nil:
+		return nil, err
+	case token == "":
+		return nil, status.Error(codes.Unauthenticated, "missing access token")
+	}
+	claims, err := s.Tokens.parseAccessToken(token)
+	switch {
+	case policy.GetPublic() && err != nil:
+		return ctx, nil
+	case err != nil:
+		return nil, errInvalidToken
+	}
+
+	// the user and roles are read from the database rather than the token, so
+	// deleting the user or revoking a role takes effect before it expires.
+	// Public RPCs go on unauthenticated when the lookup fails.
+	_, err = generated_models.UserByUserID(ctx, s.Db, int(claims.UserID))
+	switch {
+	case policy.GetPublic() && err != nil:
+		return ctx, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, errInvalidToken
+	case err != nil:
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	roles, err := userRoleNames(ctx, s.Db, int(claims.UserID))
+	switch {
+	case policy.GetPublic() && err != nil:
+		return ctx, nil
+	case err != nil:
+		return nil, err
+	}
+	c := &caller{UserID: claims.UserID, Roles: roles}
+	ctx = context.WithValue(ctx, callerKey{}, c)
+
+	switch {
+	case policy.GetPublic(), len(policy.GetRoles()) == 0 && policy.GetSelfField() == "":
+		return ctx, nil
+	case slices.ContainsFunc(policy.GetRoles(), func(role string) bool { return slices.Contains(roles, role) }):
+		return ctx, nil
+	case policy.GetSelfField() != "" && req != nil && selfUserID(req, policy.GetSelfField()) == c.UserID:
+		return ctx, nil
+	}
+	return nil, status.Errorf(codes.PermissionDenied, "permission denied for %s", method)
+}
+
+// bearerToken returns the access token from the authorization metadata, or ""
+// when there is none.
+func bearerToken(ctx context.Context) (string, error) {
+	values := metadata.ValueFromIncomingContext(ctx, "authorization")
+	if len(values) == 0 {
+		return "", nil
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", status.Error(codes.Unauthenticated, "authorization must be a bearer token")
+	}
+	return strings.TrimSpace(token), nil
+}
+
+// selfUserID reads the int32 field at the dotted path from req, returning 0
+// when the path does not name one.
+func selfUserID(req proto.Message, path string) int32 {
+	m := req.ProtoReflect()
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return 0
+		}
+		if i == len(names)-1 {
+			if fd.Kind() != protoreflect.Int32Kind || fd.Cardinality() == protoreflect.Repeated {
+				return 0
+			}
+			return int32(m.Get(fd).Int())
+		}
+		if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+			return 0
+		}
+		m = m.Get(fd).Message()
+	}
+	return 0
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imran31415/example-project-proto-db/auth"
+	"github.com/imran31415/example-project-proto-db/config"
+	"github.com/imran31415/example-project-proto-db/internal/fakedb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// brokenID is a user whose lookup fails in authzDB.
+const brokenID = deletedID + 1
+
+// authzDB answers with the test database, failing the lookups of brokenID.
+func authzDB() *fakedb.Handler {
+	h, _ := testDB()
+	query := h.Query
+	h.Query = func(q string, args []driver.Value) (fakedb.Rows, error) {
+		if strings.Contains(q, "FROM User ") && len(args) != 0 && args[0] == int64(brokenID) {
+			return fakedb.Rows{}, errors.New("connection refused")
+		}
+		return query(q, args)
+	}
+	return h
+}
+
+func TestAuthorize(t *testing.T) {
+	tokens, err := newTokenIssuer(config.AuthConfig{JWTSecret: "secret", AccessTokenTTL: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Server{Db: fakedb.Open(authzDB()), Tokens: tokens}
+	bearer := func(userID int32) string {
+		token, _, err := tokens.issueAccessToken(userID, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return "Bearer " + token
+	}
+
+	tests := []struct {
+		name          string
+		method        string
+		authorization string
+		req           proto.Message
+		code          codes.Code
+		// caller is the expected caller, 0 for none
+		caller int32
+		roles  []string
+	}{
+		{"public without a token", auth.AuthService_Login_FullMethodName, "", nil, codes.OK, 0, nil},
+		{"public with a malformed header", auth.AuthService_Login_FullMethodName, "Basic abc", nil, codes.OK, 0, nil},
+		{"public with an invalid token", auth.AuthService_Login_FullMethodName, "Bearer abc", nil, codes.OK, 0, nil},
+		{"public with a deleted user's token", auth.AuthService_Login_FullMethodName, bearer(deletedID), nil, codes.OK, 0, nil},
+		{"public with a failed user lookup", auth.AuthService_CreateUser_FullMethodName, bearer(brokenID), nil, codes.OK, 0, nil},
+		{"public with a valid token", auth.AuthService_Login_FullMethodName, bearer(adminID), nil, codes.OK, adminID, []string{"admin"}},
+		{"other services", "/grpc.health.v1.Health/Check", "", nil, codes.OK, 0, nil},
+		{"authenticated without a token", auth.AuthService_GetRoleById_FullMethodName, "", nil, codes.Unauthenticated, 0, nil},
+		{"authenticated with a malformed header", auth.AuthService_GetRoleById_FullMethodName, "Basic abc", nil, codes.Unauthenticated, 0, nil},
+		{"authenticated with an invalid token", auth.AuthService_GetRoleById_FullMethodName, "Bearer abc", nil, codes.Unauthenticated, 0, nil},
+		{"authenticated with a deleted user's token", auth.AuthService_GetRoleById_FullMethodName, bearer(deletedID), nil, codes.Unauthenticated, 0, nil},
+		{"authenticated with a failed user lookup", auth.AuthService_GetRoleById_FullMethodName, bearer(brokenID), nil, codes.Unknown, 0, nil},
+		{"authenticated", auth.AuthService_GetRoleById_FullMethodName, bearer(plainID), nil, codes.OK, plainID, []string{}},
+		{"role", auth.AuthService_ListAuditEvents_FullMethodName, bearer(adminID), &auth.ListAuditEventsRequest{}, codes.OK, adminID, []string{"admin"}},
+		{"missing role", auth.AuthService_ListAuditEvents_FullMethodName, bearer(plainID), &auth.ListAuditEventsRequest{}, codes.PermissionDenied, 0, nil},
+		{"role or self as the role", auth.AuthService_GetUserById_FullMethodName, bearer(adminID), &auth.GetUserRequest{UserId: plainID}, codes.OK, adminID, []string{"admin"}},
+		{"role or self as self", auth.AuthService_GetUserById_FullMethodName, bearer(plainID), &auth.GetUserRequest{UserId: plainID}, codes.OK, plainID, []string{}},
+		{"role or self as another user", auth.AuthService_GetUserById_FullMethodName, bearer(plainID), &auth.GetUserRequest{UserId: adminID}, codes.PermissionDenied, 0, nil},
+		{"role or self listing another user's roles", auth.AuthService_ListUserRoles_FullMethodName, bearer(plainID), &auth.ListUserRolesRequest{UserId: adminID}, codes.PermissionDenied, 0, nil},
+		{"self only as self", auth.AuthService_ChangePassword_FullMethodName, bearer(plainID), &auth.ChangePasswordRequest{UserId: plainID}, codes.OK, plainID, []string{}},
+		{"self only as another user", auth.AuthService_ChangePassword_FullMethodName, bearer(plainID), &auth.ChangePasswordRequest{UserId: adminID}, codes.PermissionDenied, 0, nil},
+		{"self only as an admin", auth.AuthService_ChangePassword_FullMethodName, bearer(adminID), &auth.ChangePasswordRequest{UserId: plainID}, codes.PermissionDenied, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.authorization != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.authorization))
+			}
+			ctx, err := s.authorize(ctx, tt.method, tt.req)
+			if code := status.Code(toStatus(err)); code != tt.code {
+				t.Fatalf("got %v (%v), want %v", code, err, tt.code)
+			}
+			if err != nil {
+				return
+			}
+			c, ok := callerFromContext(ctx)
+			switch {
+			case tt.caller == 0 && ok:
+				t.Errorf("got caller %+v, want none", c)
+			case tt.caller != 0 && !ok:
+				t.Errorf("got no caller, want %d", tt.caller)
+			case ok && (c.UserID != tt.caller || !slices.Equal(c.Roles, tt.roles)):
+				t.Errorf("got caller %+v, want %d with roles %v", c, tt.caller, tt.roles)
+			}
+		})
+	}
+}
+
+// TestAuthzPolicies checks that every AuthService RPC declares a policy.
+func TestAuthzPolicies(t *testing.T) {
+	for name := range authServiceMethods {
+		if _, ok := authzPolicies[name]; !ok {
+			t.Errorf("%s has no authz policy", name)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"database/sql/driver"
+	"time"
+
+	"github.com/imran31415/example-project-proto-db/internal/fakedb"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Users of the test database: an admin, a user without roles and a soft
+// deleted user.
+const (
+	adminID int32 = iota + 1
+	plainID
+	deletedID
+)
+
+// testPassword is the password of the users of the test database.
+const testPassword = "correct horse"
+
+var testPasswordHash, _ = bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+
+// testDB returns a handler for the test database and its tables, which hold
+// the test users at version 1, an admin role assigned to the admin, and no
+// refresh tokens.
+func testDB() (*fakedb.Handler, map[string]*fakedb.Table) {
+	now := time.Now()
+	user := func(id int32, name string, deletedAt driver.Value) []driver.Value {
+		return []driver.Value{int64(id), name, name + "@example.com", now, now, string(testPasswordHash), deletedAt, int64(1)}
+	}
+	tables := map[string]*fakedb.Table{
+		"User": {
+			Columns:  []string{"user_id", "username", "email", "created_at", "updated_at", "password_hash", "deleted_at", "version"},
+			Rows:     [][]driver.Value{user(adminID, "admin", nil), user(plainID, "plain", nil), user(deletedID, "deleted", now)},
+			Defaults: map[string]driver.Value{"created_at": now, "updated_at": now, "version": int64(1)},
+		},
+		"Role": {
+			Columns:  []string{"role_id", "role_name", "created_at", "updated_at", "deleted_at", "version"},
+			Rows:     [][]driver.Value{{int64(1), "admin", now, now, nil, int64(1)}},
+			Defaults: map[string]driver.Value{"created_at": now, "updated_at": now, "version": int64(1)},
+		},
+		"UserRole": {
+			Columns:  []string{"user_id", "role_id", "assigned_at"},
+			Rows:     [][]driver.Value{{int64(adminID), int64(1), now}},
+			Key:      2,
+			Defaults: map[string]driver.Value{"assigned_at": now},
+		},
+		"RefreshToken": {
+			Columns:  []string{"refresh_token_id", "user_id", "token_hash", "expires_at", "created_at", "revoked_at"},
+			Defaults: map[string]driver.Value{"created_at": now},
+		},
+	}
+	return fakedb.Tables(tables), tables
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"log"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/imran31415/example-project-proto-db/generated_models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MySQL server error numbers that map to a specific gRPC code.
+const (
+	mysqlErrTooManyConnections      = 1040
+	mysqlErrBadNull                 = 1048
+	mysqlErrDupEntry                = 1062
+	mysqlErrLockWaitTimeout         = 1205
+	mysqlErrLockDeadlock            = 1213
+	mysqlErrNoReferencedRow         = 1216
+	mysqlErrRowIsReferenced         = 1217
+	mysqlErrTruncatedWrongValue     = 1366
+	mysqlErrDataTooLong             = 1406
+	mysqlErrRowIsReferenced2        = 1451
+	mysqlErrNoReferencedRow2        = 1452
+	mysqlErrDupEntryWithKeyName     = 1586
+	mysqlErrCheckConstraintViolated = 3819
+)
+
+// unaryErrorInterceptor converts handler errors into gRPC status errors so
+// every RPC reports a canonical code instead of codes.Unknown.
+func unaryErrorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return resp, nil
+}
+
+// toStatus translates errors from generated_models, database/sql and the MySQL
+// driver into a status error. Errors that already carry a status are returned
+// unchanged. Internal and unknown errors are logged and reported without their
+// message, which may hold driver text such as table names and SQL.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if st, ok := status.FromError(err); ok {
+		return st.Err()
+	}
+	code := errorCode(err)
+	if code == codes.Internal || code == codes.Unknown {
+		log.Printf("Internal error: %v", err)
+		return status.Error(code, "internal error")
+	}
+	return status.Error(code, err.Error())
+}
+
+// errorCode picks the gRPC code that best describes err.
+func errorCode(err error) codes.Code {
+	var mysqlErr *mysql.MySQLError
+	var columnErr *generated_models.ErrUnknownColumn
+	switch {
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, sql.ErrNoRows):
+		return codes.NotFound
+	case errors.Is(err, generated_models.ErrAlreadyExists):
+		return codes.AlreadyExists
+	case errors.Is(err, generated_models.ErrDoesNotExist):
+		return codes.NotFound
+	case errors.Is(err, generated_models.ErrMarkedForDeletion):
+		return codes.FailedPrecondition
+	case errors.Is(err, generated_models.ErrStaleVersion):
+		return codes.Aborted
+	case errors.As(err, &columnErr),
+		errors.Is(err, generated_models.ErrEmptyPredicate),
+		errors.Is(err, generated_models.ErrInvalidCursor),
+		errors.Is(err, generated_models.ErrNullableColumn),
+		errors.Is(err, generated_models.ErrPrimaryKeyColumn),
+		errors.Is(err, generated_models.ErrPrimaryKeyUnset),
+		errors.Is(err, generated_models.ErrVersionColumn):
+		return codes.InvalidArgument
+	case errors.As(err, &mysqlErr):
+		return mysqlErrorCode(mysqlErr)
+	case errors.Is(err, driver.ErrBadConn),
+		errors.Is(err, mysql.ErrInvalidConn),
+		errors.Is(err, sql.ErrConnDone):
+		return codes.Unavailable
+	}
+	return codes.Unknown
+}
+
+// mysqlErrorCode maps a MySQL server error to a gRPC code.
+func mysqlErrorCode(err *mysql.MySQLError) codes.Code {
+	switch err.Number {
+	case mysqlErrDupEntry, mysqlErrDupEntryWithKeyName:
+		return codes.AlreadyExists
+	case mysqlErrNoReferencedRow, mysqlErrRowIsReferenced,
+		mysqlErrRowIsReferenced2, mysqlErrNoReferencedRow2,
+		mysqlErrCheckConstraintViolated:
+		return codes.FailedPrecondition
+	case mysqlErrBadNull, mysqlErrTruncatedWrongValue, mysqlErrDataTooLong:
+		return codes.InvalidArgument
+	case mysqlErrLockWaitTimeout, mysqlErrLockDeadlock:
+		return codes.Aborted
+	case mysqlErrTooManyConnections:
+		return codes.Unavailable
+	}
+	return codes.Internal
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/imran31415/example-project-proto-db/generated_models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorCodeInvalidArgument(t *testing.T) {
+	for _, err := range []error{
+		&generated_models.ErrUnknownColumn{Table: "User", Column: "nope"},
+		generated_models.ErrEmptyPredicate,
+		generated_models.ErrInvalidCursor,
+		generated_models.ErrNullableColumn,
+		&generated_models.ErrUpdateFailed{Err: generated_models.ErrPrimaryKeyColumn},
+		&generated_models.ErrUpdateFailed{Err: generated_models.ErrVersionColumn},
+		&generated_models.ErrUpsertFailed{Err: generated_models.ErrPrimaryKeyUnset},
+	} {
+		wrapped := fmt.Errorf("failed to list users: %w", err)
+		if got := errorCode(wrapped); got != codes.InvalidArgument {
+			t.Errorf("errorCode(%v) = %v, want %v", wrapped, got, codes.InvalidArgument)
+		}
+	}
+}
+
+func TestToStatusMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+		msg  string
+	}{
+		{
+			"unknown",
+			fmt.Errorf("failed to find user: %w", errors.New("Table 'auth.User' doesn't exist")),
+			codes.Unknown, "internal error",
+		},
+		{
+			"internal",
+			&mysql.MySQLError{Number: 1054, Message: "Unknown column 'x' in 'field list'"},
+			codes.Internal, "internal error",
+		},
+		{
+			"client facing",
+			fmt.Errorf("failed to create user: %w", generated_models.ErrAlreadyExists),
+			codes.AlreadyExists, "failed to create user: already exists",
+		},
+		{
+			"status",
+			status.Error(codes.Internal, "token signing failed"),
+			codes.Internal, "token signing failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(toStatus(tt.err))
+			if st.Code() != tt.code || st.Message() != tt.msg {
+				t.Errorf("got %v %q, want %v %q", st.Code(), st.Message(), tt.code, tt.msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// parseETag parses the etag of an update request, the decimal row version the
+// client last read. ok is false when the request carries no etag, in which
+// case the update applies to whatever version it loads.
+func parseETag(etag string) (version int, ok bool, err error) {
+	if etag == "" {
+		return 0, false, nil
+	}
+	version, err = strconv.Atoi(etag)
+	if err != nil || version < 0 {
+		return 0, false, status.Errorf(codes.InvalidArgument, "invalid etag %q", etag)
+	}
+	return version, true, nil
+}
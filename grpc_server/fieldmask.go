@@ -0,0 +1,115 @@
+package main
+
+import (
+	"github.com/imran31415/example-project-proto-db/auth"
+	db_annotations "github.com/imran31415/protobuf-db/db-annotations"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// maskColumns returns the database columns named by the paths of mask, read
+// from the db_column annotations of msg. Paths must name top level annotated
+// fields that are not primary keys, sensitive, output only, soft delete
+// timestamps, row versions, or maintained by the database on update. An empty
+// mask selects every such field that is populated in msg.
+func maskColumns(msg proto.Message, mask *fieldmaskpb.FieldMask) ([]string, error) {
+	m := msg.ProtoReflect()
+	fields := m.Descriptor().Fields()
+	if len(mask.GetPaths()) == 0 {
+		var columns []string
+		for i := 0; i < fields.Len(); i++ {
+			if !m.Has(fields.Get(i)) {
+				continue
+			}
+			if column, ok := updatableColumn(fields.Get(i)); ok {
+				columns = append(columns, column)
+			}
+		}
+		return columns, nil
+	}
+	columns := make([]string, 0, len(mask.GetPaths()))
+	for _, path := range mask.GetPaths() {
+		fd := fields.ByName(protoreflect.Name(path))
+		if fd == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid update_mask: unknown field %q", path)
+		}
+		column, ok := updatableColumn(fd)
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid update_mask: field %q cannot be updated", path)
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// updatableColumn returns the db_column of fd and whether an update may set it.
+func updatableColumn(fd protoreflect.FieldDescriptor) (string, bool) {
+	opts := fd.Options()
+	column, _ := proto.GetExtension(opts, db_annotations.E_DbColumn).(string)
+	if column == "" {
+		return "", false
+	}
+	if pk, _ := proto.GetExtension(opts, db_annotations.E_DbPrimaryKey).(bool); pk || isSensitive(fd) {
+		return "", false
+	}
+	if rules, _ := proto.GetExtension(opts, auth.E_Rules).(*auth.FieldRules); rules.GetOutputOnly() {
+		return "", false
+	}
+	if softDelete, _ := proto.GetExtension(opts, auth.E_SoftDelete).(bool); softDelete {
+		return "", false
+	}
+	if rowVersion, _ := proto.GetExtension(opts, auth.E_RowVersion).(bool); rowVersion {
+		return "", false
+	}
+	if action, _ := proto.GetExtension(opts, db_annotations.E_DbUpdateAction).(db_annotations.DbUpdateAction); action != db_annotations.DbUpdateAction_DB_UPDATE_ACTION_UNSPECIFIED {
+		return "", false
+	}
+	return column, true
+}
+
+// isSensitive reports whether fd has the sensitive option.
+func isSensitive(fd protoreflect.FieldDescriptor) bool {
+	sensitive, _ := proto.GetExtension(fd.Options(), auth.E_Sensitive).(bool)
+	return sensitive
+}
+
+// sortableColumn reports whether column is the db_column of a field of msg
+// that a List RPC may order by. Page tokens carry the sort value, so secrets
+// cannot be sort keys, soft delete timestamps and row versions stay internal,
+// and nullable columns are left out as NULL cannot bound a page.
+func sortableColumn(msg proto.Message, column string) bool {
+	fields := msg.ProtoReflect().Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		opts := fd.Options()
+		if name, _ := proto.GetExtension(opts, db_annotations.E_DbColumn).(string); name != column {
+			continue
+		}
+		if isSensitive(fd) || isNullable(fd) {
+			return false
+		}
+		softDelete, _ := proto.GetExtension(opts, auth.E_SoftDelete).(bool)
+		rowVersion, _ := proto.GetExtension(opts, auth.E_RowVersion).(bool)
+		return !softDelete && !rowVersion
+	}
+	return false
+}
+
+// isNullable reports whether the column of fd may hold NULL, that is fd is
+// neither a primary key nor constrained NOT NULL.
+func isNullable(fd protoreflect.FieldDescriptor) bool {
+	opts := fd.Options()
+	if pk, _ := proto.GetExtension(opts, db_annotations.E_DbPrimaryKey).(bool); pk {
+		return false
+	}
+	constraints, _ := proto.GetExtension(opts, db_annotations.E_DbConstraints).([]db_annotations.DbConstraint)
+	for _, c := range constraints {
+		if c == db_annotations.DbConstraint_DB_CONSTRAINT_NOT_NULL || c == db_annotations.DbConstraint_DB_CONSTRAINT_PRIMARY_KEY {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/imran31415/example-project-proto-db/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestMaskColumns(t *testing.T) {
+	user := &auth.User{UserId: 1, Username: "alice", CreatedAt: timestamppb.Now(), Version: 2}
+	tests := []struct {
+		name  string
+		msg   proto.Message
+		paths []string
+		want  []string
+		code  codes.Code
+	}{
+		{"fields", user, []string{"username", "email"}, []string{"username", "email"}, codes.OK},
+		{"empty mask selects populated fields", user, nil, []string{"username"}, codes.OK},
+		{"role fields", &auth.Role{}, []string{"role_name"}, []string{"role_name"}, codes.OK},
+		{"unknown field", user, []string{"nickname"}, nil, codes.InvalidArgument},
+		{"primary key", user, []string{"user_id"}, nil, codes.InvalidArgument},
+		{"sensitive", user, []string{"password_hash"}, nil, codes.InvalidArgument},
+		{"output only", user, []string{"created_at"}, nil, codes.InvalidArgument},
+		{"updated by the database", user, []string{"updated_at"}, nil, codes.InvalidArgument},
+		{"soft delete", user, []string{"deleted_at"}, nil, codes.InvalidArgument},
+		{"row version", user, []string{"version"}, nil, codes.InvalidArgument},
+		{"output only among fields", &auth.Role{}, []string{"role_name", "created_at"}, nil, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mask *fieldmaskpb.FieldMask
+			if tt.paths != nil {
+				mask = &fieldmaskpb.FieldMask{Paths: tt.paths}
+			}
+			got, err := maskColumns(tt.msg, mask)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("got %v (%v), want %v", code, err, tt.code)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got columns %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/imran31415/example-project-proto-db/auth"
+	"github.com/imran31415/example-project-proto-db/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Gateway serves the AuthService over REST/JSON on cfg.HTTPPort, translating
+// requests with the google.api.http annotations in proto/auth.proto and
+// proxying them to the gRPC server at cfg.GrpcGatewayURL. It also serves the
+// gRPC health status at /healthz.
+//
+// With TLS enabled the gateway dials over TLS, unless GrpcGatewayURL points at
+// the InsecureGRPCPort listener. See gatewayCredentials.
+type Gateway struct {
+	server *http.Server
+	conn   *grpc.ClientConn
+	addr   net.Addr
+	errc   chan error
+}
+
+// NewGateway prepares the gateway. Connections to the gRPC server are made
+// lazily, so it may be created before the gRPC server is started.
+func NewGateway(cfg config.ServerConfig) (*Gateway, error) {
+	creds, err := gatewayCredentials(cfg)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.NewClient(cfg.GrpcGatewayURL, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial gRPC server at %s: %w", cfg.GrpcGatewayURL, err)
+	}
+	mux := runtime.NewServeMux(runtime.WithHealthzEndpoint(grpc_health_v1.NewHealthClient(conn)))
+	if err := auth.RegisterAuthServiceHandler(context.Background(), mux, conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to register gateway handlers: %w", err)
+	}
+	return &Gateway{
+		server: &http.Server{Addr: listenAddr(cfg.HTTPPort), Handler: mux},
+		conn:   conn,
+		errc:   make(chan error, 1),
+	}, nil
+}
+
+// Start listens on the HTTP port and serves in the background. Errors from
+// serving are reported on Err.
+func (g *Gateway) Start() error {
+	lis, err := net.Listen("tcp", g.server.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", g.server.Addr, err)
+	}
+	g.addr = lis.Addr()
+	log.Printf("Starting REST gateway on %s...", g.addr)
+	go func() {
+		if err := g.server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			g.errc <- fmt.Errorf("failed to serve REST gateway on %s: %w", g.addr, err)
+		}
+	}()
+	return nil
+}
+
+// Addr returns the address being served. It is only valid after Start.
+func (g *Gateway) Addr() net.Addr {
+	return g.addr
+}
+
+// Err reports errors that stop the gateway from serving.
+func (g *Gateway) Err() <-chan error {
+	return g.errc
+}
+
+// Stop waits for in-flight requests to finish, or for ctx to be done, then
+// closes the connection to the gRPC server.
+func (g *Gateway) Stop(ctx context.Context) error {
+	err := g.server.Shutdown(ctx)
+	if cerr := g.conn.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
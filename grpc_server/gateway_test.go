@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/imran31415/example-project-proto-db/config"
+)
+
+// login posts a login through the gateway at addr and returns the status.
+func login(t *testing.T, addr string) int {
+	t.Helper()
+	resp, err := http.Post("http://"+addr+"/v1/login", "application/json",
+		strings.NewReader(`{"username": "alice", "password": "secret"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestGatewayCredentials(t *testing.T) {
+	pki := newTestPKI(t)
+	tlsConfig := config.ServerConfig{
+		GRPCPort:         "127.0.0.1:0",
+		InsecureGRPCPort: "127.0.0.1:0",
+		TLSCertPath:      pki.server.certPath,
+		TLSKeyPath:       pki.server.keyPath,
+	}
+	mtlsConfig := tlsConfig
+	mtlsConfig.InsecureGRPCPort = ""
+	mtlsConfig.TLSCaCertPath = pki.ca.certPath
+
+	tests := []struct {
+		name string
+		cfg  config.ServerConfig
+		// gateway sets the gateway's configuration given the gRPC addresses
+		gateway func(cfg *config.ServerConfig, addrs []string)
+	}{
+		{"plaintext", config.ServerConfig{GRPCPort: "127.0.0.1:0"}, func(cfg *config.ServerConfig, addrs []string) {
+			cfg.GrpcGatewayURL = addrs[0]
+		}},
+		{"TLS", tlsConfig, func(cfg *config.ServerConfig, addrs []string) {
+			cfg.GrpcGatewayURL = addrs[0]
+		}},
+		{"TLS via the insecure port", tlsConfig, func(cfg *config.ServerConfig, addrs []string) {
+			cfg.InsecureGRPCPort = addrs[1]
+			cfg.GrpcGatewayURL = addrs[1]
+		}},
+		{"mTLS", mtlsConfig, func(cfg *config.ServerConfig, addrs []string) {
+			cfg.GrpcGatewayURL = addrs[0]
+			cfg.GatewayTLSCertPath = pki.client.certPath
+			cfg.GatewayTLSKeyPath = pki.client.keyPath
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var addrs []string
+			for _, addr := range startTestServer(t, tt.cfg).Addrs() {
+				addrs = append(addrs, addr.String())
+			}
+			cfg := tt.cfg
+			cfg.HTTPPort = "127.0.0.1:0"
+			tt.gateway(&cfg, addrs)
+
+			gw, err := NewGateway(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := gw.Start(); err != nil {
+				t.Fatal(err)
+			}
+			defer gw.Stop(context.Background())
+
+			// the unknown user fails the login itself rather than the proxying
+			if code := login(t, gw.Addr().String()); code != http.StatusUnauthorized {
+				t.Errorf("login through the gateway got status %d, want %d", code, http.StatusUnauthorized)
+			}
+		})
+	}
+}
+
+func TestGatewayRequiresClientCertificate(t *testing.T) {
+	pki := newTestPKI(t)
+	cfg := config.ServerConfig{
+		GRPCPort:       "127.0.0.1:50051",
+		TLSCertPath:    pki.server.certPath,
+		TLSKeyPath:     pki.server.keyPath,
+		TLSCaCertPath:  pki.ca.certPath,
+		HTTPPort:       "127.0.0.1:0",
+		GrpcGatewayURL: "127.0.0.1:50051",
+	}
+	if _, err := NewGateway(cfg); err == nil {
+		t.Error("NewGateway under mutual TLS without a client certificate succeeded")
+	}
+}
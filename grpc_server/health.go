@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/imran31415/example-project-proto-db/auth"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// schemaTables are the tables generated from proto/auth.proto that must exist
+// before the server reports itself ready.
+var schemaTables = []string{"User", "Role", "UserRole", "RefreshToken", "Permission", "RolePermission", "AuditEvent"}
+
+// healthChecker pings the database on an interval and reports the result as
+// the overall and AuthService health status. Until the schema has been found
+// the server is not ready and reports NOT_SERVING even when the database is up.
+type healthChecker struct {
+	db       *sql.DB
+	health   *health.Server
+	interval time.Duration
+	// ready is set once the schema has been verified
+	ready   bool
+	status  grpc_health_v1.HealthCheckResponse_ServingStatus
+	lastErr string
+}
+
+// defaultHealthCheckInterval is used when no interval is configured.
+const defaultHealthCheckInterval = 10 * time.Second
+
+// newHealthChecker creates a checker reporting NOT_SERVING until its first
+// successful check.
+func newHealthChecker(db *sql.DB, healthServer *health.Server, interval time.Duration) *healthChecker {
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+	c := &healthChecker{
+		db:       db,
+		health:   healthServer,
+		interval: interval,
+	}
+	c.set(grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+// run checks immediately and then on every interval until ctx is done.
+func (c *healthChecker) run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		c.check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check pings the database, verifies the schema if it has not been yet, and
+// updates the health status.
+func (c *healthChecker) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, c.interval)
+	defer cancel()
+
+	status, err := grpc_health_v1.HealthCheckResponse_NOT_SERVING, c.db.PingContext(ctx)
+	switch {
+	case err != nil:
+		err = fmt.Errorf("database unreachable: %w", err)
+	case !c.ready:
+		if err = verifySchema(ctx, c.db); err != nil {
+			break
+		}
+		c.ready = true
+		status = grpc_health_v1.HealthCheckResponse_SERVING
+	default:
+		status = grpc_health_v1.HealthCheckResponse_SERVING
+	}
+
+	// log failures once rather than on every interval
+	var msg string
+	if err != nil {
+		msg = err.Error()
+	}
+	if msg != "" && msg != c.lastErr {
+		log.Printf("Health check failed: %s", msg)
+	}
+	c.lastErr = msg
+	if status != c.status {
+		log.Printf("Health status changed to %s", status)
+	}
+	c.set(status)
+}
+
+// set reports status overall and for the AuthService.
+func (c *healthChecker) set(status grpc_health_v1.HealthCheckResponse_ServingStatus) {
+	c.status = status
+	c.health.SetServingStatus("", status)
+	c.health.SetServingStatus(auth.AuthService_ServiceDesc.ServiceName, status)
+}
+
+// verifySchema returns an error unless every table in schemaTables exists in
+// the connected database.
+func verifySchema(ctx context.Context, db *sql.DB) error {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(schemaTables)), ", ")
+	args := make([]interface{}, len(schemaTables))
+	for i, table := range schemaTables {
+		args[i] = table
+	}
+	query := `SELECT COUNT(*) FROM information_schema.tables ` +
+		`WHERE table_schema = DATABASE() AND table_name IN (` + placeholders + `)`
+
+	var n int
+	if err := db.QueryRowContext(ctx, query, args...).Scan(&n); err != nil {
+		return fmt.Errorf("failed to verify schema: %w", err)
+	}
+	if n != len(schemaTables) {
+		return fmt.Errorf("schema incomplete: found %d of tables %s", n, strings.Join(schemaTables, ", "))
+	}
+	return nil
+}
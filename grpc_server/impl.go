@@ -2,93 +2,327 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
-	"time"
 
 	"github.com/imran31415/example-project-proto-db/auth"
 	"github.com/imran31415/example-project-proto-db/generated_models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Example gRPC Methods Implementation
 func (s *Server) CreateRole(ctx context.Context, req *auth.Role) (*auth.Role, error) {
-	role := &generated_models.Role{
-		RoleName:  req.GetRoleName(),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	var role *generated_models.Role
+	err := s.WithTx(ctx, sql.LevelDefault, func(tx generated_models.DB) error {
+		role = generated_models.RoleFromProto(req)
+		if err := role.Insert(ctx, tx); err != nil {
+			return fmt.Errorf("failed to create role: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	err := role.Insert(ctx, s.Db)
+	return role.ToProto(), nil
+}
+
+// CreateUser creates a user, storing the password as a bcrypt hash.
+func (s *Server) CreateUser(ctx context.Context, req *auth.CreateUserRequest) (*auth.User, error) {
+	if req.GetUser() == nil {
+		return nil, status.Error(codes.InvalidArgument, "user is required")
+	}
+	hash, err := hashPassword(req.GetPassword())
 	if err != nil {
-		return nil, fmt.Errorf("failed to create role: %v", err)
+		return nil, err
 	}
 
-	return &auth.Role{
-		RoleId:   int32(role.RoleID),
-		RoleName: role.RoleName,
-	}, nil
+	var user *generated_models.User
+	err = s.WithTx(ctx, sql.LevelDefault, func(tx generated_models.DB) error {
+		user = generated_models.UserFromProto(req.GetUser())
+		user.PasswordHash = hash
+
+		if err := user.Insert(ctx, tx); err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user.ToProto(), nil
 }
 
-// Example gRPC Methods Implementation
-func (s *Server) CreateUser(ctx context.Context, req *auth.User) (*auth.User, error) {
+func (s *Server) GetUserById(ctx context.Context, req *auth.GetUserRequest) (*auth.User, error) {
+	user, err := generated_models.UserByUserID(ctx, s.Db, int(req.GetUserId()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	return user.ToProto(), nil
+}
 
-	user := &generated_models.User{
-		UserID:    int(req.GetUserId()),
-		Username:  req.Username,
-		Email:     req.GetEmail(),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+// DeleteUser soft deletes a user. It can be restored with UndeleteUser.
+func (s *Server) DeleteUser(ctx context.Context, req *auth.User) (*auth.User, error) {
+	var user *generated_models.User
+	err := s.WithTx(ctx, sql.LevelDefault, func(tx generated_models.DB) error {
+		var err error
+		user, err = generated_models.UserByUserID(ctx, tx, int(req.GetUserId()))
+		if err != nil {
+			return fmt.Errorf("failed to find user: %w", err)
+		}
+
+		if err := user.Delete(ctx, tx); err != nil {
+			return fmt.Errorf("failed to delete user: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	err := user.Insert(ctx, s.Db)
+	return user.ToProto(), nil
+}
+
+// UndeleteUser restores a soft deleted user.
+func (s *Server) UndeleteUser(ctx context.Context, req *auth.UndeleteUserRequest) (*auth.User, error) {
+	var user *generated_models.User
+	err := s.WithTx(ctx, sql.LevelDefault, func(tx generated_models.DB) error {
+		var err error
+		user, err = generated_models.UserByUserIDWithDeleted(ctx, tx, int(req.GetUserId()))
+		if err != nil {
+			return fmt.Errorf("failed to find user: %w", err)
+		}
+		if !user.Deleted() {
+			return status.Errorf(codes.FailedPrecondition, "user %d is not deleted", req.GetUserId())
+		}
+
+		if err := user.Restore(ctx, tx); err != nil {
+			return fmt.Errorf("failed to restore user: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create role: %v", err)
+		return nil, err
 	}
 
-	return req, nil
+	return user.ToProto(), nil
 }
 
 func (s *Server) GetRoleById(ctx context.Context, req *auth.GetRoleRequest) (*auth.Role, error) {
 	role, err := generated_models.RoleByRoleID(ctx, s.Db, int(req.GetRoleId()))
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve role: %v", err)
+		return nil, fmt.Errorf("failed to retrieve role: %w", err)
 	}
 
-	return &auth.Role{
-		RoleId:   int32(role.RoleID),
-		RoleName: role.RoleName,
-	}, nil
+	return role.ToProto(), nil
 }
 
-func (s *Server) UpdateRole(ctx context.Context, req *auth.Role) (*auth.Role, error) {
-	role, err := generated_models.RoleByRoleID(ctx, s.Db, int(req.GetRoleId()))
+// UpdateUser updates the columns of a user named by the update mask,
+// which cannot clear a required field.
+// updated_at is left to the database's ON UPDATE CURRENT_TIMESTAMP. With an
+// etag the update only applies to that version of the user and fails with
+// Aborted when the user has changed since.
+func (s *Server) UpdateUser(ctx context.Context, req *auth.UpdateUserRequest) (*auth.User, error) {
+	in := req.GetUser()
+	columns, err := maskColumns(in, req.GetUpdateMask())
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMask(req, "user", req.GetUpdateMask()); err != nil {
+		return nil, err
+	}
+	version, hasETag, err := parseETag(req.GetEtag())
+	if err != nil {
+		return nil, err
+	}
+
+	var user *generated_models.User
+	err = s.WithTx(ctx, sql.LevelDefault, func(tx generated_models.DB) error {
+		var err error
+		user, err = generated_models.UserByUserID(ctx, tx, int(in.GetUserId()))
+		if err != nil {
+			return fmt.Errorf("failed to find user: %w", err)
+		}
+		// UpdateColumns then guards against changes after the load
+		if hasETag && user.Version != version {
+			return fmt.Errorf("failed to update user: %w", generated_models.ErrStaleVersion)
+		}
+
+		update := make([]generated_models.UserColumn, 0, len(columns))
+		for _, name := range columns {
+			column, err := generated_models.ParseUserColumn(name)
+			if err != nil {
+				return fmt.Errorf("invalid update_mask: %w", err)
+			}
+			switch column {
+			case generated_models.UserColumnUsername:
+				user.Username = in.GetUsername()
+			case generated_models.UserColumnEmail:
+				user.Email = in.GetEmail()
+			}
+			update = append(update, column)
+		}
+
+		err = user.UpdateColumns(ctx, tx, update...)
+		if err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to find role: %v", err)
+		return nil, err
 	}
 
-	role.RoleName = req.GetRoleName()
-	role.UpdatedAt = time.Now()
+	return user.ToProto(), nil
+}
 
-	err = role.Update(ctx, s.Db)
+// UpdateRole updates the columns of a role named by the update mask,
+// which cannot clear a required field.
+// updated_at is left to the database's ON UPDATE CURRENT_TIMESTAMP. With an
+// etag the update only applies to that version of the role and fails with
+// Aborted when the role has changed since.
+func (s *Server) UpdateRole(ctx context.Context, req *auth.UpdateRoleRequest) (*auth.Role, error) {
+	in := req.GetRole()
+	columns, err := maskColumns(in, req.GetUpdateMask())
 	if err != nil {
-		return nil, fmt.Errorf("failed to update role: %v", err)
+		return nil, err
 	}
+	if err := validateMask(req, "role", req.GetUpdateMask()); err != nil {
+		return nil, err
+	}
+	version, hasETag, err := parseETag(req.GetEtag())
+	if err != nil {
+		return nil, err
+	}
+
+	var role *generated_models.Role
+	err = s.WithTx(ctx, sql.LevelDefault, func(tx generated_models.DB) error {
+		var err error
+		role, err = generated_models.RoleByRoleID(ctx, tx, int(in.GetRoleId()))
+		if err != nil {
+			return fmt.Errorf("failed to find role: %w", err)
+		}
+		// UpdateColumns then guards against changes after the load
+		if hasETag && role.Version != version {
+			return fmt.Errorf("failed to update role: %w", generated_models.ErrStaleVersion)
+		}
+
+		update := make([]generated_models.RoleColumn, 0, len(columns))
+		for _, name := range columns {
+			column, err := generated_models.ParseRoleColumn(name)
+			if err != nil {
+				return fmt.Errorf("invalid update_mask: %w", err)
+			}
+			switch column {
+			case generated_models.RoleColumnRoleName:
+				role.RoleName = in.GetRoleName()
+			}
+			update = append(update, column)
+		}
 
-	return &auth.Role{
-		RoleId:   int32(role.RoleID),
-		RoleName: role.RoleName,
-	}, nil
+		err = role.UpdateColumns(ctx, tx, update...)
+		if err != nil {
+			return fmt.Errorf("failed to update role: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return role.ToProto(), nil
 }
 
+// DeleteRole soft deletes a role. It can be restored with UndeleteRole.
 func (s *Server) DeleteRole(ctx context.Context, req *auth.Role) (*auth.Role, error) {
-	role, err := generated_models.RoleByRoleID(ctx, s.Db, int(req.GetRoleId()))
+	var role *generated_models.Role
+	err := s.WithTx(ctx, sql.LevelDefault, func(tx generated_models.DB) error {
+		var err error
+		role, err = generated_models.RoleByRoleID(ctx, tx, int(req.GetRoleId()))
+		if err != nil {
+			return fmt.Errorf("failed to find role: %w", err)
+		}
+
+		if err := role.Delete(ctx, tx); err != nil {
+			return fmt.Errorf("failed to delete role: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return role.ToProto(), nil
+}
+
+// UndeleteRole restores a soft deleted role.
+func (s *Server) UndeleteRole(ctx context.Context, req *auth.UndeleteRoleRequest) (*auth.Role, error) {
+	var role *generated_models.Role
+	err := s.WithTx(ctx, sql.LevelDefault, func(tx generated_models.DB) error {
+		var err error
+		role, err = generated_models.RoleByRoleIDWithDeleted(ctx, tx, int(req.GetRoleId()))
+		if err != nil {
+			return fmt.Errorf("failed to find role: %w", err)
+		}
+		if !role.Deleted() {
+			return status.Errorf(codes.FailedPrecondition, "role %d is not deleted", req.GetRoleId())
+		}
+
+		if err := role.Restore(ctx, tx); err != nil {
+			return fmt.Errorf("failed to restore role: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to find role: %v", err)
+		return nil, err
 	}
 
-	err = role.Delete(ctx, s.Db)
+	return role.ToProto(), nil
+}
+
+// AssignRoleToUser links an existing user to an existing role. The foreign keys
+// on UserRole reject unknown user or role IDs.
+func (s *Server) AssignRoleToUser(ctx context.Context, req *auth.UserRole) (*auth.UserRole, error) {
+	var userRole *generated_models.UserRole
+	err := s.WithTx(ctx, sql.LevelDefault, func(tx generated_models.DB) error {
+		userRole = generated_models.UserRoleFromProto(req)
+		if err := userRole.Insert(ctx, tx); err != nil {
+			return fmt.Errorf("failed to assign role: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to delete role: %v", err)
+		return nil, err
 	}
 
-	return &auth.Role{}, nil
+	return userRole.ToProto(), nil
+}
+
+// BatchAssignRoles links users to roles in bulk with multi-row statements,
+// rather than a round trip per assignment. Assignments that already exist
+// keep their assigned_at.
+func (s *Server) BatchAssignRoles(ctx context.Context, req *auth.BatchAssignRolesRequest) (*auth.BatchAssignRolesResponse, error) {
+	var userRoles []*generated_models.UserRole
+	err := s.WithTx(ctx, sql.LevelDefault, func(tx generated_models.DB) error {
+		userRoles = make([]*generated_models.UserRole, len(req.GetAssignments()))
+		for i, assignment := range req.GetAssignments() {
+			userRoles[i] = generated_models.UserRoleFromProto(assignment)
+		}
+		if err := generated_models.UpsertUserRoles(ctx, tx, userRoles); err != nil {
+			return fmt.Errorf("failed to assign roles: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &auth.BatchAssignRolesResponse{Assignments: make([]*auth.UserRole, len(userRoles))}
+	for i, userRole := range userRoles {
+		res.Assignments[i] = userRole.ToProto()
+	}
+	return res, nil
 }
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/imran31415/example-project-proto-db/auth"
+	"github.com/imran31415/example-project-proto-db/internal/fakedb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// changedDB answers with the test database. When changed is set, another
+// update bumps the version of every loaded user and role after the load.
+func changedDB(changed bool) *fakedb.Handler {
+	h, tables := testDB()
+	query := h.Query
+	h.Query = func(q string, args []driver.Value) (fakedb.Rows, error) {
+		res, err := query(q, args)
+		if changed && strings.HasPrefix(q, "SELECT ") {
+			for _, t := range []*fakedb.Table{tables["User"], tables["Role"]} {
+				for _, row := range t.Rows {
+					row[len(row)-1] = row[len(row)-1].(int64) + 1
+				}
+			}
+		}
+		return res, err
+	}
+	return h
+}
+
+func TestUpdateETag(t *testing.T) {
+	mask := &fieldmaskpb.FieldMask{Paths: []string{"username"}}
+	tests := []struct {
+		name    string
+		etag    string
+		changed bool
+		code    codes.Code
+		// updates is the number of UPDATE statements run
+		updates int
+	}{
+		{"no etag", "", false, codes.OK, 1},
+		{"current etag", "1", false, codes.OK, 1},
+		{"stale etag", "0", false, codes.Aborted, 0},
+		{"changed after the load", "1", true, codes.Aborted, 1},
+		{"changed after the load without an etag", "", true, codes.Aborted, 1},
+		{"malformed etag", "v1", false, codes.InvalidArgument, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := changedDB(tt.changed)
+			s := &Server{Db: fakedb.Open(h)}
+
+			user, err := s.UpdateUser(context.Background(), &auth.UpdateUserRequest{
+				User:       &auth.User{UserId: plainID, Username: "bob"},
+				UpdateMask: mask,
+				Etag:       tt.etag,
+			})
+			if code := status.Code(toStatus(err)); code != tt.code {
+				t.Fatalf("got %v (%v), want %v", code, err, tt.code)
+			}
+			if err == nil && user.GetVersion() != 2 {
+				t.Errorf("got version %d, want 2", user.GetVersion())
+			}
+			var updates int
+			for _, stmt := range h.Statements() {
+				if strings.HasPrefix(stmt.Query, "UPDATE ") {
+					updates++
+				}
+			}
+			if updates != tt.updates {
+				t.Errorf("ran %d updates, want %d", updates, tt.updates)
+			}
+		})
+	}
+}
+
+func TestUpdateRoleStaleETag(t *testing.T) {
+	h, _ := testDB()
+	s := &Server{Db: fakedb.Open(h)}
+
+	_, err := s.UpdateRole(context.Background(), &auth.UpdateRoleRequest{
+		Role:       &auth.Role{RoleId: 1, RoleName: "owner"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"role_name"}},
+		Etag:       "0",
+	})
+	if code := status.Code(toStatus(err)); code != codes.Aborted {
+		t.Fatalf("got %v (%v), want %v", code, err, codes.Aborted)
+	}
+}
+
+func TestUpdateClearsRequiredField(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(s *Server) error
+	}{
+		{"username", func(s *Server) error {
+			_, err := s.UpdateUser(context.Background(), &auth.UpdateUserRequest{
+				User:       &auth.User{UserId: 1},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"username"}},
+			})
+			return err
+		}},
+		{"email", func(s *Server) error {
+			_, err := s.UpdateUser(context.Background(), &auth.UpdateUserRequest{
+				User:       &auth.User{UserId: 1, Username: "bob"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"username", "email"}},
+			})
+			return err
+		}},
+		{"role name", func(s *Server) error {
+			_, err := s.UpdateRole(context.Background(), &auth.UpdateRoleRequest{
+				Role:       &auth.Role{RoleId: 1},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"role_name"}},
+			})
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := testDB()
+			err := tt.update(&Server{Db: fakedb.Open(h)})
+			if code := status.Code(toStatus(err)); code != codes.InvalidArgument {
+				t.Fatalf("got %v (%v), want %v", code, err, codes.InvalidArgument)
+			}
+			if stmts := h.Statements(); len(stmts) != 0 {
+				t.Errorf("ran %d statements, want none", len(stmts))
+			}
+		})
+	}
+}
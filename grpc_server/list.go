@@ -0,0 +1,364 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/imran31415/example-project-proto-db/auth"
+	"github.com/imran31415/example-project-proto-db/generated_models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Page size bounds for the List RPCs.
+const (
+	defaultPageSize = 50
+	maxPageSize     = 1000
+)
+
+func (s *Server) ListUsers(ctx context.Context, req *auth.ListUsersRequest) (*auth.ListUsersResponse, error) {
+	size, err := pageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+	name, order, err := parseOrderBy(req.GetOrderBy())
+	if err != nil {
+		return nil, err
+	}
+	column := generated_models.UserColumnUserID
+	if name != "" {
+		if column, err = generated_models.ParseUserColumn(name); err != nil {
+			return nil, fmt.Errorf("invalid order_by: %w", err)
+		}
+		if !sortableColumn(&auth.User{}, name) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid order_by: cannot order by %s", name)
+		}
+	}
+	where, err := filterPredicate(req.GetFilter(), userPredicate)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := generated_models.UserCursorPage(ctx, s.Db, column, order, req.GetPageToken(), size, where)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	resp := &auth.ListUsersResponse{NextPageToken: page.NextCursor}
+	for _, user := range page.Items {
+		resp.Users = append(resp.Users, user.ToProto())
+	}
+	return resp, nil
+}
+
+func (s *Server) ListRoles(ctx context.Context, req *auth.ListRolesRequest) (*auth.ListRolesResponse, error) {
+	size, err := pageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+	name, order, err := parseOrderBy(req.GetOrderBy())
+	if err != nil {
+		return nil, err
+	}
+	column := generated_models.RoleColumnRoleID
+	if name != "" {
+		if column, err = generated_models.ParseRoleColumn(name); err != nil {
+			return nil, fmt.Errorf("invalid order_by: %w", err)
+		}
+		if !sortableColumn(&auth.Role{}, name) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid order_by: cannot order by %s", name)
+		}
+	}
+	where, err := filterPredicate(req.GetFilter(), rolePredicate)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := generated_models.RoleCursorPage(ctx, s.Db, column, order, req.GetPageToken(), size, where)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+
+	resp := &auth.ListRolesResponse{NextPageToken: page.NextCursor}
+	for _, role := range page.Items {
+		resp.Roles = append(resp.Roles, role.ToProto())
+	}
+	return resp, nil
+}
+
+// ListUserRoles lists role assignments, restricted to a single user when
+// user_id is set.
+func (s *Server) ListUserRoles(ctx context.Context, req *auth.ListUserRolesRequest) (*auth.ListUserRolesResponse, error) {
+	size, err := pageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+	name, order, err := parseOrderBy(req.GetOrderBy())
+	if err != nil {
+		return nil, err
+	}
+	column := generated_models.UserRoleColumnUserID
+	if name != "" {
+		if column, err = generated_models.ParseUserRoleColumn(name); err != nil {
+			return nil, fmt.Errorf("invalid order_by: %w", err)
+		}
+		if !sortableColumn(&auth.UserRole{}, name) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid order_by: cannot order by %s", name)
+		}
+	}
+	where, err := filterPredicate(req.GetFilter(), userRolePredicate)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetUserId() != 0 {
+		where = generated_models.And(generated_models.UserRoleFilter.UserID.Eq(int(req.GetUserId())), where)
+	}
+
+	page, err := generated_models.UserRoleCursorPage(ctx, s.Db, column, order, req.GetPageToken(), size, where)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user roles: %w", err)
+	}
+
+	resp := &auth.ListUserRolesResponse{NextPageToken: page.NextCursor}
+	for _, userRole := range page.Items {
+		resp.UserRoles = append(resp.UserRoles, userRole.ToProto())
+	}
+	return resp, nil
+}
+
+// ListAuditEvents lists the audit trail recorded by recordAudit.
+func (s *Server) ListAuditEvents(ctx context.Context, req *auth.ListAuditEventsRequest) (*auth.ListAuditEventsResponse, error) {
+	size, err := pageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+	name, order, err := parseOrderBy(req.GetOrderBy())
+	if err != nil {
+		return nil, err
+	}
+	column := generated_models.AuditEventColumnAuditEventID
+	if name != "" {
+		if column, err = generated_models.ParseAuditEventColumn(name); err != nil {
+			return nil, fmt.Errorf("invalid order_by: %w", err)
+		}
+		if !sortableColumn(&auth.AuditEvent{}, name) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid order_by: cannot order by %s", name)
+		}
+	}
+	where, err := filterPredicate(req.GetFilter(), auditEventPredicate)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := generated_models.AuditEventCursorPage(ctx, s.Db, column, order, req.GetPageToken(), size, where)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	resp := &auth.ListAuditEventsResponse{NextPageToken: page.NextCursor}
+	for _, event := range page.Items {
+		resp.AuditEvents = append(resp.AuditEvents, event.ToProto())
+	}
+	return resp, nil
+}
+
+// pageSize applies the default and the cap to a requested page size.
+func pageSize(n int32) (int, error) {
+	switch {
+	case n < 0:
+		return 0, status.Errorf(codes.InvalidArgument, "invalid page_size: %d", n)
+	case n == 0:
+		return defaultPageSize, nil
+	case n > maxPageSize:
+		return maxPageSize, nil
+	}
+	return int(n), nil
+}
+
+// parseOrderBy parses an order_by of the form "<column> [asc|desc]". An empty
+// column means the caller's default.
+func parseOrderBy(s string) (string, string, error) {
+	fields := strings.Fields(s)
+	switch len(fields) {
+	case 0:
+		return "", "ASC", nil
+	case 1:
+		return fields[0], "ASC", nil
+	case 2:
+		if order := strings.ToUpper(fields[1]); order == "ASC" || order == "DESC" {
+			return fields[0], order, nil
+		}
+	}
+	return "", "", status.Errorf(codes.InvalidArgument, "invalid order_by: %q", s)
+}
+
+// filterTerm is a single "<column> <op> <value>" comparison of a filter.
+type filterTerm struct {
+	column string
+	op     string
+	value  string
+}
+
+// filterTermRE matches a comparison at the start of a filter. Values may be
+// bare words or single or double quoted, with the quote escaped by doubling it.
+var filterTermRE = regexp.MustCompile(`^\s*(\w+)\s*(<=|>=|!=|=|<|>)\s*('(?:[^']|'')*'|"(?:[^"]|"")*"|[^\s'"]+)\s*`)
+
+// filterAndRE matches the AND joining two comparisons.
+var filterAndRE = regexp.MustCompile(`^(?i:AND)\s`)
+
+// parseFilter splits a filter into its comparisons.
+func parseFilter(s string) ([]filterTerm, error) {
+	var terms []filterTerm
+	rest := strings.TrimSpace(s)
+	for rest != "" {
+		m := filterTermRE.FindStringSubmatch(rest)
+		if m == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %q", s)
+		}
+		terms = append(terms, filterTerm{column: m[1], op: m[2], value: unquote(m[3])})
+		rest = rest[len(m[0]):]
+		if rest == "" {
+			break
+		}
+		loc := filterAndRE.FindStringIndex(rest)
+		if loc == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %q", s)
+		}
+		rest = rest[loc[1]:]
+		if strings.TrimSpace(rest) == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %q", s)
+		}
+	}
+	return terms, nil
+}
+
+// unquote strips the quotes from a quoted filter value.
+func unquote(v string) string {
+	if len(v) < 2 || (v[0] != '\'' && v[0] != '"') {
+		return v
+	}
+	q := v[:1]
+	return strings.ReplaceAll(v[1:len(v)-1], q+q, q)
+}
+
+// filterPredicate parses a filter into the conjunction of its comparisons,
+// using predicate to build each one.
+func filterPredicate[R any](s string, predicate func(filterTerm) (generated_models.Predicate[R], error)) (generated_models.Predicate[R], error) {
+	terms, err := parseFilter(s)
+	if err != nil {
+		return generated_models.Predicate[R]{}, err
+	}
+	preds := make([]generated_models.Predicate[R], 0, len(terms))
+	for _, term := range terms {
+		p, err := predicate(term)
+		if err != nil {
+			return generated_models.Predicate[R]{}, fmt.Errorf("invalid filter: %w", err)
+		}
+		preds = append(preds, p)
+	}
+	return generated_models.And(preds...), nil
+}
+
+// comparePredicate builds the comparison of term against column c, parsing
+// the value with parse.
+func comparePredicate[R, T any](c generated_models.ColumnFilter[R, T], term filterTerm, parse func(string) (T, error)) (generated_models.Predicate[R], error) {
+	v, err := parse(term.value)
+	if err != nil {
+		return generated_models.Predicate[R]{}, status.Errorf(codes.InvalidArgument, "invalid filter value for %s: %q", term.column, term.value)
+	}
+	switch term.op {
+	case "=":
+		return c.Eq(v), nil
+	case "!=":
+		return c.NotEq(v), nil
+	case "<":
+		return c.Lt(v), nil
+	case "<=":
+		return c.Lte(v), nil
+	case ">":
+		return c.Gt(v), nil
+	}
+	return c.Gte(v), nil
+}
+
+func parseString(s string) (string, error) {
+	return s, nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	return time.Parse(time.RFC3339, s)
+}
+
+func parseInt64(s string) (int64, error) {
+	return strconv.ParseInt(s, 10, 64)
+}
+
+func userPredicate(term filterTerm) (generated_models.Predicate[generated_models.User], error) {
+	f := generated_models.UserFilter
+	switch generated_models.UserColumn(term.column) {
+	case generated_models.UserColumnUserID:
+		return comparePredicate(f.UserID, term, strconv.Atoi)
+	case generated_models.UserColumnUsername:
+		return comparePredicate(f.Username.ColumnFilter, term, parseString)
+	case generated_models.UserColumnEmail:
+		return comparePredicate(f.Email.ColumnFilter, term, parseString)
+	case generated_models.UserColumnCreatedAt:
+		return comparePredicate(f.CreatedAt, term, parseTime)
+	case generated_models.UserColumnUpdatedAt:
+		return comparePredicate(f.UpdatedAt, term, parseTime)
+	}
+	return generated_models.Predicate[generated_models.User]{}, &generated_models.ErrUnknownColumn{Table: "User", Column: term.column}
+}
+
+func rolePredicate(term filterTerm) (generated_models.Predicate[generated_models.Role], error) {
+	f := generated_models.RoleFilter
+	switch generated_models.RoleColumn(term.column) {
+	case generated_models.RoleColumnRoleID:
+		return comparePredicate(f.RoleID, term, strconv.Atoi)
+	case generated_models.RoleColumnRoleName:
+		return comparePredicate(f.RoleName.ColumnFilter, term, parseString)
+	case generated_models.RoleColumnCreatedAt:
+		return comparePredicate(f.CreatedAt, term, parseTime)
+	case generated_models.RoleColumnUpdatedAt:
+		return comparePredicate(f.UpdatedAt, term, parseTime)
+	}
+	return generated_models.Predicate[generated_models.Role]{}, &generated_models.ErrUnknownColumn{Table: "Role", Column: term.column}
+}
+
+func userRolePredicate(term filterTerm) (generated_models.Predicate[generated_models.UserRole], error) {
+	f := generated_models.UserRoleFilter
+	switch generated_models.UserRoleColumn(term.column) {
+	case generated_models.UserRoleColumnUserID:
+		return comparePredicate(f.UserID, term, strconv.Atoi)
+	case generated_models.UserRoleColumnRoleID:
+		return comparePredicate(f.RoleID, term, strconv.Atoi)
+	case generated_models.UserRoleColumnAssignedAt:
+		return comparePredicate(f.AssignedAt, term, parseTime)
+	}
+	return generated_models.Predicate[generated_models.UserRole]{}, &generated_models.ErrUnknownColumn{Table: "UserRole", Column: term.column}
+}
+
+func auditEventPredicate(term filterTerm) (generated_models.Predicate[generated_models.AuditEvent], error) {
+	f := generated_models.AuditEventFilter
+	switch generated_models.AuditEventColumn(term.column) {
+	case generated_models.AuditEventColumnAuditEventID:
+		return comparePredicate(f.AuditEventID, term, strconv.Atoi)
+	case generated_models.AuditEventColumnActorUserID:
+		return comparePredicate(f.ActorUserID, term, parseInt64)
+	case generated_models.AuditEventColumnMethod:
+		return comparePredicate(f.Method.ColumnFilter, term, parseString)
+	case generated_models.AuditEventColumnEntity:
+		return comparePredicate(f.Entity.ColumnFilter, term, parseString)
+	case generated_models.AuditEventColumnEntityPk:
+		return comparePredicate(f.EntityPk.ColumnFilter, term, parseString)
+	case generated_models.AuditEventColumnAction:
+		return comparePredicate(f.Action.ColumnFilter, term, parseString)
+	case generated_models.AuditEventColumnCreatedAt:
+		return comparePredicate(f.CreatedAt, term, parseTime)
+	}
+	return generated_models.Predicate[generated_models.AuditEvent]{}, &generated_models.ErrUnknownColumn{Table: "AuditEvent", Column: term.column}
+}
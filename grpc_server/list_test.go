@@ -0,0 +1,130 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/imran31415/example-project-proto-db/auth"
+	"github.com/imran31415/example-project-proto-db/generated_models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSortableColumn(t *testing.T) {
+	tests := []struct {
+		msg    proto.Message
+		column string
+		want   bool
+	}{
+		{&auth.User{}, "user_id", true},
+		{&auth.User{}, "username", true},
+		{&auth.User{}, "created_at", true},
+		{&auth.User{}, "password_hash", false},
+		{&auth.User{}, "deleted_at", false},
+		{&auth.User{}, "version", false},
+		{&auth.User{}, "unknown", false},
+		{&auth.Role{}, "role_name", true},
+		{&auth.Role{}, "deleted_at", false},
+		{&auth.Role{}, "version", false},
+		{&auth.UserRole{}, "role_id", true},
+		{&auth.AuditEvent{}, "audit_event_id", true},
+		{&auth.AuditEvent{}, "actor_user_id", false},
+	}
+	for _, tt := range tests {
+		if got := sortableColumn(tt.msg, tt.column); got != tt.want {
+			t.Errorf("sortableColumn(%T, %q) = %v, want %v", tt.msg, tt.column, got, tt.want)
+		}
+	}
+}
+
+// TestSortableColumnNotNullable checks sortableColumn against the nullability
+// of the generated columns, which keyset pagination rejects.
+func TestSortableColumnNotNullable(t *testing.T) {
+	for _, column := range []generated_models.UserColumn{
+		generated_models.UserColumnUserID,
+		generated_models.UserColumnUsername,
+		generated_models.UserColumnEmail,
+		generated_models.UserColumnCreatedAt,
+		generated_models.UserColumnUpdatedAt,
+		generated_models.UserColumnDeletedAt,
+	} {
+		if sortableColumn(&auth.User{}, string(column)) && column.Nullable() {
+			t.Errorf("User column %s is sortable but nullable", column)
+		}
+	}
+	for _, column := range []generated_models.AuditEventColumn{
+		generated_models.AuditEventColumnAuditEventID,
+		generated_models.AuditEventColumnActorUserID,
+		generated_models.AuditEventColumnMethod,
+		generated_models.AuditEventColumnEntity,
+		generated_models.AuditEventColumnCreatedAt,
+	} {
+		if sortableColumn(&auth.AuditEvent{}, string(column)) && column.Nullable() {
+			t.Errorf("AuditEvent column %s is sortable but nullable", column)
+		}
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   []filterTerm
+		code   codes.Code
+	}{
+		{"", nil, codes.OK},
+		{"   ", nil, codes.OK},
+		{"username = alice", []filterTerm{{"username", "=", "alice"}}, codes.OK},
+		{"user_id>=3", []filterTerm{{"user_id", ">=", "3"}}, codes.OK},
+		{`username = 'o''brien'`, []filterTerm{{"username", "=", "o'brien"}}, codes.OK},
+		{`username = "say ""hi"""`, []filterTerm{{"username", "=", `say "hi"`}}, codes.OK},
+		{`username = 'it"s'`, []filterTerm{{"username", "=", `it"s`}}, codes.OK},
+		{"username = ''", []filterTerm{{"username", "=", ""}}, codes.OK},
+		{
+			"user_id > 1 AND email != 'a b' and created_at <= 2024-01-01T00:00:00Z",
+			[]filterTerm{{"user_id", ">", "1"}, {"email", "!=", "a b"}, {"created_at", "<=", "2024-01-01T00:00:00Z"}},
+			codes.OK,
+		},
+		{"username = alice extra", nil, codes.InvalidArgument},
+		{"username = 'alice'bob", nil, codes.InvalidArgument},
+		{"username = alice AND", nil, codes.InvalidArgument},
+		{"username = alice OR email = a", nil, codes.InvalidArgument},
+		{"username = 'unterminated", nil, codes.InvalidArgument},
+		{"username alice", nil, codes.InvalidArgument},
+		{"username ~ alice", nil, codes.InvalidArgument},
+		{"= alice", nil, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		got, err := parseFilter(tt.filter)
+		if code := status.Code(err); code != tt.code {
+			t.Errorf("parseFilter(%q): got %v (%v), want %v", tt.filter, code, err, tt.code)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("parseFilter(%q) = %+v, want %+v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestUserFilterColumns(t *testing.T) {
+	tests := []struct {
+		filter string
+		code   codes.Code
+	}{
+		{"username = 'o''brien' AND user_id > 1", codes.OK},
+		{"created_at >= 2024-01-01T00:00:00Z", codes.OK},
+		{"nickname = alice", codes.InvalidArgument},
+		{"password_hash = x", codes.InvalidArgument},
+		{"deleted_at > 2024-01-01T00:00:00Z", codes.InvalidArgument},
+		{"version = 1", codes.InvalidArgument},
+		{"username = alice AND version = 1", codes.InvalidArgument},
+		{"user_id = abc", codes.InvalidArgument},
+		{"created_at > yesterday", codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		_, err := filterPredicate(tt.filter, userPredicate)
+		if code := status.Code(toStatus(err)); code != tt.code {
+			t.Errorf("filter %q: got %v (%v), want %v", tt.filter, code, err, tt.code)
+		}
+	}
+}
@@ -1,9 +1,13 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os/signal"
+	"syscall"
 
 	"github.com/imran31415/example-project-proto-db/config"
+	"github.com/imran31415/example-project-proto-db/generated_models"
 
 	_ "github.com/go-sql-driver/mysql"
 )
@@ -17,11 +21,74 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
-	defer db.Close()
+
+	// Load the token signing keys
+	tokens, err := newTokenIssuer(cfg.Auth)
+	if err != nil {
+		log.Fatalf("Failed to load token configuration: %v", err)
+	}
 
 	// Initialize the gRPC server
-	server := &Server{Db: db}
+	server := &Server{Db: db, Tokens: tokens}
+
+	// Record mutations made through the generated models
+	generated_models.SetAuditHook(recordAudit)
 
 	// Start gRPC servers
-	startGRPCServers(server)
+	grpcServer, err := NewGRPCServer(server, cfg.Server)
+	if err != nil {
+		log.Fatalf("Failed to create gRPC server: %v", err)
+	}
+	if err := grpcServer.Start(); err != nil {
+		log.Fatalf("Failed to start gRPC server: %v", err)
+	}
+
+	// Start the REST gateway alongside it
+	var gateway *Gateway
+	var gatewayErr <-chan error
+	if cfg.Server.HTTPPort != "" {
+		if gateway, err = NewGateway(cfg.Server); err != nil {
+			log.Fatalf("Failed to create REST gateway: %v", err)
+		}
+		if err := gateway.Start(); err != nil {
+			log.Fatalf("Failed to start REST gateway: %v", err)
+		}
+		gatewayErr = gateway.Err()
+	}
+
+	// Run until interrupted or a listener fails
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	select {
+	case <-ctx.Done():
+		log.Println("Shutting down gRPC server...")
+	case err := <-grpcServer.Err():
+		log.Printf("gRPC server failed, shutting down: %v", err)
+	case err := <-gatewayErr:
+		log.Printf("REST gateway failed, shutting down: %v", err)
+	}
+
+	// Drain in-flight requests, then release the database pool
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	shutdown(shutdownCtx, grpcServer, gateway)
+	if err := db.Close(); err != nil {
+		log.Printf("Failed to close database: %v", err)
+	}
+	log.Println("Shutdown complete.")
+}
+
+// shutdown drains the servers within ctx. Health goes NOT_SERVING first so
+// load balancers stop routing to either server, then the gateway finishes its
+// requests, which still need the gRPC server, and then the gRPC server drains.
+func shutdown(ctx context.Context, grpcServer *GRPCServer, gateway *Gateway) {
+	grpcServer.SetNotServing()
+	if gateway != nil {
+		if err := gateway.Stop(ctx); err != nil {
+			log.Printf("Failed to drain REST gateway: %v", err)
+		}
+	}
+	if err := grpcServer.Stop(ctx); err != nil {
+		log.Printf("Timed out draining gRPC server: %v", err)
+	}
 }
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/imran31415/example-project-proto-db/auth"
+	"github.com/imran31415/example-project-proto-db/generated_models"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Password length bounds. bcrypt only uses the first 72 bytes.
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 72
+)
+
+// errInvalidCredentials is returned for both unknown users and wrong
+// passwords, so callers cannot tell which usernames exist.
+var errInvalidCredentials = status.Error(codes.Unauthenticated, "invalid credentials")
+
+// dummyHash is compared against when a user does not exist, so that failed
+// logins take the same time whether or not the username is known.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
+// Login verifies a username and password and starts a session, returning
+// an access token and a refresh token.
+func (s *Server) Login(ctx context.Context, req *auth.LoginRequest) (*auth.LoginResponse, error) {
+	user, err := generated_models.UserByUsername(ctx, s.Db, req.GetUsername())
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(req.GetPassword()))
+		return nil, errInvalidCredentials
+	case err != nil:
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if !checkPassword(user.PasswordHash, req.GetPassword()) {
+		return nil, errInvalidCredentials
+	}
+
+	return s.newSession(ctx, s.Db, user)
+}
+
+// ChangePassword replaces a user's password after verifying the current one,
+// and revokes the user's refresh tokens so that no session outlives the old
+// password for longer than an access token.
+func (s *Server) ChangePassword(ctx context.Context, req *auth.ChangePasswordRequest) (*auth.User, error) {
+	hash, err := hashPassword(req.GetNewPassword())
+	if err != nil {
+		return nil, err
+	}
+
+	var user *generated_models.User
+	err = s.WithTx(ctx, sql.LevelDefault, func(tx generated_models.DB) error {
+		var err error
+		user, err = generated_models.UserByUserID(ctx, tx, int(req.GetUserId()))
+		if err != nil {
+			return fmt.Errorf("failed to find user: %w", err)
+		}
+
+		if !checkPassword(user.PasswordHash, req.GetCurrentPassword()) {
+			return errInvalidCredentials
+		}
+
+		user.PasswordHash = hash
+		err = user.UpdateColumns(ctx, tx, generated_models.UserColumnPasswordHash)
+		if err != nil {
+			return fmt.Errorf("failed to change password: %w", err)
+		}
+		return revokeUserRefreshTokens(ctx, tx, user.UserID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user.ToProto(), nil
+}
+
+// hashPassword checks the length of password and returns its bcrypt hash.
+func hashPassword(password string) (string, error) {
+	switch {
+	case len(password) < minPasswordLength:
+		return "", status.Errorf(codes.InvalidArgument, "password must be at least %d characters", minPasswordLength)
+	case len(password) > maxPasswordLength:
+		return "", status.Errorf(codes.InvalidArgument, "password must be at most %d bytes", maxPasswordLength)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// checkPassword reports whether password matches the bcrypt hash.
+func checkPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
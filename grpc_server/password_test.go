@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/imran31415/example-project-proto-db/auth"
+	"github.com/imran31415/example-project-proto-db/internal/fakedb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestChangePassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  string
+		code     codes.Code
+		password string
+		// revoked reports whether the user's refresh tokens are revoked
+		revoked bool
+	}{
+		{"current password", testPassword, codes.OK, "new password", true},
+		{"wrong password", "wrong", codes.Unauthenticated, testPassword, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, tables := testDB()
+			revokedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			expiresAt := time.Now().Add(time.Hour)
+			tokens := tables["RefreshToken"]
+			tokens.Rows = [][]driver.Value{
+				{int64(1), int64(plainID), "a", expiresAt, expiresAt, nil},
+				{int64(2), int64(plainID), "b", expiresAt, expiresAt, revokedAt},
+				{int64(3), int64(adminID), "c", expiresAt, expiresAt, nil},
+			}
+			s := &Server{Db: fakedb.Open(h)}
+
+			_, err := s.ChangePassword(context.Background(), &auth.ChangePasswordRequest{
+				UserId:          plainID,
+				CurrentPassword: tt.current,
+				NewPassword:     "new password",
+			})
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("got %v (%v), want %v", code, err, tt.code)
+			}
+
+			hash := tables["User"].Rows[plainID-1][5].(string)
+			if !checkPassword(hash, tt.password) {
+				t.Errorf("the stored password is not %q", tt.password)
+			}
+			if revoked := tokens.Rows[0][5] != nil; revoked != tt.revoked {
+				t.Errorf("got the active token revoked %v, want %v", revoked, tt.revoked)
+			}
+			// already revoked tokens and the tokens of other users are left alone
+			if at, ok := tokens.Rows[1][5].(time.Time); !ok || !at.Equal(revokedAt) {
+				t.Errorf("got the revoked token revoked at %v, want %v", tokens.Rows[1][5], revokedAt)
+			}
+			if tokens.Rows[2][5] != nil {
+				t.Error("another user's token was revoked")
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/imran31415/example-project-proto-db/auth"
+	"github.com/imran31415/example-project-proto-db/generated_models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkPermissionSQL reports whether any role of a user grants the named
+// permission, ignoring soft deleted users and roles. The unique indexes on
+// Permission.permission_name and RolePermission (role_id, permission_id) keep
+// it to index lookups.
+const checkPermissionSQL = `SELECT EXISTS (` +
+	`SELECT 1 FROM UserRole ur ` +
+	`JOIN User u ON u.user_id = ur.user_id AND u.deleted_at IS NULL ` +
+	`JOIN Role r ON r.role_id = ur.role_id AND r.deleted_at IS NULL ` +
+	`JOIN RolePermission rp ON rp.role_id = ur.role_id ` +
+	`JOIN Permission p ON p.permission_id = rp.permission_id ` +
+	`WHERE ur.user_id = ? AND p.permission_name = ?)`
+
+func (s *Server) CreatePermission(ctx context.Context, req *auth.Permission) (*auth.Permission, error) {
+	var permission *generated_models.Permission
+	err := s.WithTx(ctx, sql.LevelDefault, func(tx generated_models.DB) error {
+		permission = generated_models.PermissionFromProto(req)
+		if err := permission.Insert(ctx, tx); err != nil {
+			return fmt.Errorf("failed to create permission: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return permission.ToProto(), nil
+}
+
+// DeletePermission deletes a permission. Its grants are removed by the
+// cascading foreign key on RolePermission.
+func (s *Server) DeletePermission(ctx context.Context, req *auth.Permission) (*auth.Permission, error) {
+	var permission *generated_models.Permission
+	err := s.WithTx(ctx, sql.LevelDefault, func(tx generated_models.DB) error {
+		var err error
+		permission, err = generated_models.PermissionByPermissionID(ctx, tx, int(req.GetPermissionId()))
+		if err != nil {
+			return fmt.Errorf("failed to find permission: %w", err)
+		}
+
+		if err := permission.Delete(ctx, tx); err != nil {
+			return fmt.Errorf("failed to delete permission: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return permission.ToProto(), nil
+}
+
+// GrantPermission grants an existing permission to an existing role. The
+// foreign keys on RolePermission reject unknown role or permission IDs.
+func (s *Server) GrantPermission(ctx context.Context, req *auth.RolePermission) (*auth.RolePermission, error) {
+	var rolePermission *generated_models.RolePermission
+	err := s.WithTx(ctx, sql.LevelDefault, func(tx generated_models.DB) error {
+		rolePermission = generated_models.RolePermissionFromProto(req)
+		if err := rolePermission.Insert(ctx, tx); err != nil {
+			return fmt.Errorf("failed to grant permission: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rolePermission.ToProto(), nil
+}
+
+// RevokePermission removes a permission from a role.
+func (s *Server) RevokePermission(ctx context.Context, req *auth.RolePermission) (*auth.RolePermission, error) {
+	var rolePermission *generated_models.RolePermission
+	err := s.WithTx(ctx, sql.LevelDefault, func(tx generated_models.DB) error {
+		var err error
+		rolePermission, err = generated_models.RolePermissionByRoleIDPermissionID(ctx, tx, int(req.GetRoleId()), int(req.GetPermissionId()))
+		if err != nil {
+			return fmt.Errorf("failed to find role permission: %w", err)
+		}
+
+		if err := rolePermission.Delete(ctx, tx); err != nil {
+			return fmt.Errorf("failed to revoke permission: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rolePermission.ToProto(), nil
+}
+
+// CheckPermission reports whether any of a user's roles grants a permission.
+// Unknown users and permissions are simply not allowed.
+func (s *Server) CheckPermission(ctx context.Context, req *auth.CheckPermissionRequest) (*auth.CheckPermissionResponse, error) {
+	if req.GetPermission() == "" {
+		return nil, status.Error(codes.InvalidArgument, "permission is required")
+	}
+
+	var allowed bool
+	err := s.Db.QueryRowContext(ctx, checkPermissionSQL, req.GetUserId(), req.GetPermission()).Scan(&allowed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+
+	return &auth.CheckPermissionResponse{Allowed: allowed}, nil
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/imran31415/example-project-proto-db/auth"
+	"github.com/imran31415/example-project-proto-db/generated_models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// errInvalidToken is returned for any token that is malformed, unknown,
+// expired or revoked, without saying which.
+var errInvalidToken = status.Error(codes.Unauthenticated, "invalid or expired token")
+
+// RefreshAccessToken exchanges a refresh token for a new access token and
+// refresh token. The presented refresh token is revoked, so each can only be
+// used once.
+func (s *Server) RefreshAccessToken(ctx context.Context, req *auth.RefreshAccessTokenRequest) (*auth.LoginResponse, error) {
+	var resp *auth.LoginResponse
+	err := s.WithTx(ctx, sql.LevelDefault, func(tx generated_models.DB) error {
+		token, err := activeRefreshToken(ctx, tx, req.GetRefreshToken())
+		if err != nil {
+			return err
+		}
+		revoked, err := revokeRefreshToken(ctx, tx, token)
+		if err != nil {
+			return err
+		}
+		if !revoked {
+			// lost a race with another refresh or revoke of the same token
+			return errInvalidToken
+		}
+
+		user, err := generated_models.UserByUserID(ctx, tx, token.UserID)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			// the user was deleted since the token was issued
+			return errInvalidToken
+		case err != nil:
+			return fmt.Errorf("failed to find user: %w", err)
+		}
+		resp, err = s.newSession(ctx, tx, user)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RevokeRefreshToken revokes a refresh token. Revoking an unknown or already
+// revoked token succeeds.
+func (s *Server) RevokeRefreshToken(ctx context.Context, req *auth.RevokeRefreshTokenRequest) (*auth.RevokeRefreshTokenResponse, error) {
+	token, err := generated_models.RefreshTokenByTokenHash(ctx, s.Db, hashRefreshToken(req.GetRefreshToken()))
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return &auth.RevokeRefreshTokenResponse{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to find refresh token: %w", err)
+	}
+	if _, err := revokeRefreshToken(ctx, s.Db, token); err != nil {
+		return nil, err
+	}
+	return &auth.RevokeRefreshTokenResponse{}, nil
+}
+
+// ValidateToken verifies an access token and returns the identity it carries.
+// As in authorize, the user and roles are read from the database rather than
+// the token, so the tokens of deleted users are invalid and revoked roles are
+// left out before the tokens expire.
+func (s *Server) ValidateToken(ctx context.Context, req *auth.ValidateTokenRequest) (*auth.ValidateTokenResponse, error) {
+	claims, err := s.Tokens.parseAccessToken(req.GetAccessToken())
+	if err != nil {
+		return nil, errInvalidToken
+	}
+	_, err = generated_models.UserByUserID(ctx, s.Db, int(claims.UserID))
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, errInvalidToken
+	case err != nil:
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	roles, err := userRoleNames(ctx, s.Db, int(claims.UserID))
+	if err != nil {
+		return nil, err
+	}
+	return &auth.ValidateTokenResponse{
+		UserId:    claims.UserID,
+		Roles:     roles,
+		ExpiresAt: timestamppb.New(claims.ExpiresAt.Time),
+	}, nil
+}
+
+// newSession issues an access token and a persisted refresh token for user.
+func (s *Server) newSession(ctx context.Context, db generated_models.DB, user *generated_models.User) (*auth.LoginResponse, error) {
+	roles, err := userRoleNames(ctx, db, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, expiresAt, err := s.Tokens.issueAccessToken(int32(user.UserID), roles)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, hash, refreshExpiresAt, err := s.Tokens.newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	token := &generated_models.RefreshToken{
+		UserID:    user.UserID,
+		TokenHash: hash,
+		ExpiresAt: refreshExpiresAt,
+	}
+	if err := token.Insert(ctx, db); err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return &auth.LoginResponse{
+		User:                 user.ToProto(),
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: timestamppb.New(expiresAt),
+		RefreshToken:         refreshToken,
+	}, nil
+}
+
+// activeRefreshToken looks up a refresh token that is neither revoked nor
+// expired.
+func activeRefreshToken(ctx context.Context, db generated_models.DB, refreshToken string) (*generated_models.RefreshToken, error) {
+	token, err := generated_models.RefreshTokenByTokenHash(ctx, db, hashRefreshToken(refreshToken))
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, errInvalidToken
+	case err != nil:
+		return nil, fmt.Errorf("failed to find refresh token: %w", err)
+	}
+	if token.RevokedAt.Valid || time.Now().After(token.ExpiresAt) {
+		return nil, errInvalidToken
+	}
+	return token, nil
+}
+
+// revokeRefreshToken marks token revoked unless it already is, and reports
+// whether this call revoked it.
+func revokeRefreshToken(ctx context.Context, db generated_models.DB, token *generated_models.RefreshToken) (bool, error) {
+	const sqlstr = `UPDATE RefreshToken SET revoked_at = ? WHERE refresh_token_id = ? AND revoked_at IS NULL`
+	res, err := db.ExecContext(ctx, sqlstr, time.Now(), token.RefreshTokenID)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	return n == 1, nil
+}
+
+// revokeUserRefreshTokens revokes the refresh tokens of a user that are not
+// revoked yet.
+func revokeUserRefreshTokens(ctx context.Context, db generated_models.DB, userID int) error {
+	const sqlstr = `UPDATE RefreshToken SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL`
+	if _, err := db.ExecContext(ctx, sqlstr, time.Now(), userID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
+
+// userRoleNames returns the names of the roles assigned to a user, leaving out
+// soft deleted roles.
+func userRoleNames(ctx context.Context, db generated_models.DB, userID int) ([]string, error) {
+	userRoles, err := generated_models.UserRoleByUserID(ctx, db, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user roles: %w", err)
+	}
+	names := make([]string, 0, len(userRoles))
+	for _, userRole := range userRoles {
+		role, err := generated_models.RoleByRoleID(ctx, db, userRole.RoleID)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			// soft deleted roles grant nothing
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("failed to find role: %w", err)
+		}
+		names = append(names, role.RoleName)
+	}
+	return names, nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/imran31415/example-project-proto-db/auth"
+	"github.com/imran31415/example-project-proto-db/config"
+	"github.com/imran31415/example-project-proto-db/internal/fakedb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateToken(t *testing.T) {
+	tokens, err := newTokenIssuer(config.AuthConfig{JWTSecret: "secret", AccessTokenTTL: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, _ := testDB()
+	s := &Server{Db: fakedb.Open(h), Tokens: tokens}
+	token := func(userID int32, roles ...string) string {
+		token, _, err := tokens.issueAccessToken(userID, roles)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		code  codes.Code
+		roles []string
+	}{
+		{"invalid token", "abc", codes.Unauthenticated, nil},
+		{"deleted user", token(deletedID), codes.Unauthenticated, nil},
+		{"unknown user", token(deletedID + 1), codes.Unauthenticated, nil},
+		{"roles from the database", token(adminID), codes.OK, []string{"admin"}},
+		// the roles of the token are not trusted after they are revoked
+		{"revoked role", token(plainID, "admin"), codes.OK, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.ValidateToken(context.Background(), &auth.ValidateTokenRequest{AccessToken: tt.token})
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("got %v (%v), want %v", code, err, tt.code)
+			}
+			if err == nil && !slices.Equal(res.GetRoles(), tt.roles) {
+				t.Errorf("got roles %v, want %v", res.GetRoles(), tt.roles)
+			}
+		})
+	}
+}
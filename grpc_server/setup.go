@@ -1,23 +1,28 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"net"
+	"strings"
+	"sync"
 
 	"github.com/imran31415/example-project-proto-db/auth"
 	"github.com/imran31415/example-project-proto-db/config"
 
 	_ "github.com/go-sql-driver/mysql"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
 type Server struct {
-	Db *sql.DB
+	Db     *sql.DB
+	Tokens *tokenIssuer
 	auth.UnimplementedAuthServiceServer
 }
 
@@ -38,28 +43,177 @@ func connectToDB(cfg config.DatabaseConfig) (*sql.DB, error) {
 	return db, nil
 }
 
-func startGRPCServers(server *Server) {
-	go func() {
-		log.Println("Starting insecure gRPC server on port 50052...")
-		insecureServer := grpc.NewServer()
-		auth.RegisterAuthServiceServer(insecureServer, server)
-		grpc_health_v1.RegisterHealthServer(insecureServer, health.NewServer())
-		reflection.Register(insecureServer)
+// listener is a gRPC server and the address it serves on.
+type listener struct {
+	name   string
+	addr   string
+	server *grpc.Server
+}
+
+// GRPCServer serves the API on the listeners described by a
+// config.ServerConfig. It is started with Start and drained with Stop.
+type GRPCServer struct {
+	health    *health.Server
+	checker   *healthChecker
+	listeners []listener
+	addrs     []net.Addr
+	errc      chan error
+	// stopChecker stops the health checker and waits for it to return
+	stopChecker func()
+	notServing  sync.Once
+}
+
+// NewGRPCServer prepares the listeners for server: cfg.GRPCPort, using TLS
+// when a certificate is configured, and cfg.InsecureGRPCPort in plaintext
+// alongside the TLS listener when that is set. Health is reported from
+// database checks every cfg.HealthCheckInterval.
+func NewGRPCServer(server *Server, cfg config.ServerConfig) (*GRPCServer, error) {
+	tlsConfig, err := serverTLSConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure TLS: %w", err)
+	}
+	g := &GRPCServer{
+		health: health.NewServer(),
+		errc:   make(chan error, 2),
+	}
+	g.checker = newHealthChecker(server.Db, g.health, cfg.HealthCheckInterval)
+
+	if tlsConfig != nil {
+		name := "secure gRPC server (TLS)"
+		if tlsConfig.ClientCAs != nil {
+			name = "secure gRPC server (mTLS)"
+		}
+		g.listeners = append(g.listeners, listener{
+			name:   name,
+			addr:   listenAddr(cfg.GRPCPort),
+			server: g.newServer(server, grpc.Creds(credentials.NewTLS(tlsConfig))),
+		})
+	}
+
+	insecurePort := cfg.GRPCPort
+	if tlsConfig != nil {
+		insecurePort = cfg.InsecureGRPCPort
+	}
+	if insecurePort != "" {
+		g.listeners = append(g.listeners, listener{
+			name:   "insecure gRPC server",
+			addr:   listenAddr(insecurePort),
+			server: g.newServer(server),
+		})
+	}
+	return g, nil
+}
+
+// newServer creates a gRPC server exposing the AuthService, health and
+// reflection services, with the AuthService authz policies and validation
+// rules enforced and mutations audited.
+func (g *GRPCServer) newServer(server *Server, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(unaryErrorInterceptor, server.unaryAuthzInterceptor, unaryAuditInterceptor, unaryValidationInterceptor),
+		grpc.ChainStreamInterceptor(server.streamAuthzInterceptor),
+	)
+	grpcServer := grpc.NewServer(opts...)
+	auth.RegisterAuthServiceServer(grpcServer, server)
+	grpc_health_v1.RegisterHealthServer(grpcServer, g.health)
+	reflection.Register(grpcServer)
+	return grpcServer
+}
 
-		listenAndServe(insecureServer, ":50052")
+// Start listens on every address, serves in the background and starts
+// checking health. Errors from serving are reported on Err.
+func (g *GRPCServer) Start() error {
+	var listeners []net.Listener
+	for _, l := range g.listeners {
+		lis, err := net.Listen("tcp", l.addr)
+		if err != nil {
+			for _, lis := range listeners {
+				lis.Close()
+			}
+			return fmt.Errorf("failed to listen on %s: %w", l.addr, err)
+		}
+		listeners = append(listeners, lis)
+		g.addrs = append(g.addrs, lis.Addr())
+	}
+	for i, l := range g.listeners {
+		log.Printf("Starting %s on %s...", l.name, listeners[i].Addr())
+		go func(l listener, lis net.Listener) {
+			if err := l.server.Serve(lis); err != nil {
+				g.errc <- fmt.Errorf("failed to serve %s on %s: %w", l.name, lis.Addr(), err)
+			}
+		}(l, listeners[i])
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		g.checker.run(ctx)
 	}()
+	g.stopChecker = func() {
+		cancel()
+		<-done
+	}
+	return nil
+}
 
-	// Block the main goroutine to prevent the program from exiting
-	select {}
+// Addrs returns the addresses being served, in the order of the listeners.
+// It is only valid after Start, and resolves port 0 to the assigned port.
+func (g *GRPCServer) Addrs() []net.Addr {
+	return g.addrs
 }
 
-func listenAndServe(server *grpc.Server, port string) {
-	listener, err := net.Listen("tcp", port)
-	if err != nil {
-		log.Fatalf("Failed to listen on port %s: %v", port, err)
+// Err reports errors that stop a listener from serving.
+func (g *GRPCServer) Err() <-chan error {
+	return g.errc
+}
+
+// SetNotServing stops the health checks and reports NOT_SERVING, so load
+// balancers stop routing new RPCs. RPCs are still served until Stop.
+func (g *GRPCServer) SetNotServing() {
+	g.notServing.Do(func() {
+		if g.stopChecker != nil {
+			g.stopChecker()
+		}
+		g.health.Shutdown()
+	})
+}
+
+// Stop reports NOT_SERVING if SetNotServing has not, then waits for in-flight
+// RPCs to finish. When ctx is done first the remaining RPCs are cancelled and
+// ctx's error is returned.
+func (g *GRPCServer) Stop(ctx context.Context) error {
+	g.SetNotServing()
+
+	done := make(chan struct{})
+	go func() {
+		var wg sync.WaitGroup
+		for _, l := range g.listeners {
+			wg.Add(1)
+			go func(server *grpc.Server) {
+				defer wg.Done()
+				server.GracefulStop()
+			}(l.server)
+		}
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		for _, l := range g.listeners {
+			l.server.Stop()
+		}
+		<-done
+		return ctx.Err()
 	}
+}
 
-	if err := server.Serve(listener); err != nil {
-		log.Fatalf("Failed to serve gRPC server on port %s: %v", port, err)
+// listenAddr turns a bare port into a listen address on all interfaces.
+func listenAddr(port string) string {
+	if strings.Contains(port, ":") {
+		return port
 	}
+	return ":" + port
 }
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imran31415/example-project-proto-db/auth"
+	"github.com/imran31415/example-project-proto-db/config"
+	"github.com/imran31415/example-project-proto-db/internal/fakedb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// blockingLogins returns a handler for the test database whose user lookups
+// by username signal started and then wait for release.
+func blockingLogins(started chan<- struct{}, release <-chan struct{}) *fakedb.Handler {
+	h, _ := testDB()
+	query := h.Query
+	h.Query = func(q string, args []driver.Value) (fakedb.Rows, error) {
+		if strings.Contains(q, "username = ?") {
+			started <- struct{}{}
+			<-release
+		}
+		return query(q, args)
+	}
+	return h
+}
+
+// startServers starts a plaintext gRPC server for db and a gateway in front
+// of it.
+func startServers(t *testing.T, h *fakedb.Handler) (*GRPCServer, *Gateway) {
+	t.Helper()
+	g, err := NewGRPCServer(&Server{Db: fakedb.Open(h)}, config.ServerConfig{GRPCPort: "127.0.0.1:0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	gw, err := NewGateway(config.ServerConfig{HTTPPort: "127.0.0.1:0", GrpcGatewayURL: g.Addrs()[0].String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Start(); err != nil {
+		t.Fatal(err)
+	}
+	return g, gw
+}
+
+func dial(t *testing.T, addr string) *grpc.ClientConn {
+	t.Helper()
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestShutdownOrder(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	g, gw := startServers(t, blockingLogins(started, release))
+	health := grpc_health_v1.NewHealthClient(dial(t, g.Addrs()[0].String()))
+
+	// a REST request in flight through the gateway when shutdown begins
+	resp := make(chan *http.Response, 1)
+	go func() {
+		r, err := http.Post("http://"+gw.Addr().String()+"/v1/login", "application/json",
+			strings.NewReader(`{"username": "alice", "password": "secret"}`))
+		if err != nil {
+			t.Error(err)
+		}
+		resp <- r
+	}()
+	<-started
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		shutdown(ctx, g, gw)
+	}()
+
+	// health goes NOT_SERVING while the gRPC server still serves the gateway
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		res, err := health.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		cancel()
+		if err != nil {
+			t.Fatalf("health check during gateway drain: %v", err)
+		}
+		if res.GetStatus() == grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("health status %s, want NOT_SERVING", res.GetStatus())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case <-done:
+		t.Fatal("shutdown returned with a gateway request in flight")
+	default:
+	}
+
+	close(release)
+	if r := <-resp; r != nil {
+		r.Body.Close()
+		// the unknown user fails the login itself rather than the proxying
+		if r.StatusCode != http.StatusUnauthorized {
+			t.Errorf("in-flight gateway request got status %d, want %d", r.StatusCode, http.StatusUnauthorized)
+		}
+	}
+	<-done
+}
+
+func TestStopTimeout(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	g, gw := startServers(t, blockingLogins(started, release))
+	defer gw.Stop(context.Background())
+	client := auth.NewAuthServiceClient(dial(t, g.Addrs()[0].String()))
+
+	go client.Login(context.Background(), &auth.LoginRequest{Username: "alice", Password: "secret"})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	// the query returns once cancelled, as a real driver's would
+	go func() {
+		<-ctx.Done()
+		close(release)
+	}()
+	if err := g.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Stop with an RPC in flight returned %v, want %v", err, context.DeadlineExceeded)
+	}
+}
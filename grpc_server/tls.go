@@ -0,0 +1,109 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/imran31415/example-project-proto-db/config"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// serverTLSConfig builds the TLS configuration of the secure listener, or
+// returns nil when no certificate is configured. Setting TLSCaCertPath turns on
+// mutual TLS: clients must present a certificate signed by that CA.
+func serverTLSConfig(cfg config.ServerConfig) (*tls.Config, error) {
+	switch {
+	case cfg.TLSCertPath == "" && cfg.TLSKeyPath == "":
+		if cfg.TLSCaCertPath != "" {
+			return nil, errors.New("TLS CA certificate set without a server certificate and key")
+		}
+		return nil, nil
+	case cfg.TLSCertPath == "" || cfg.TLSKeyPath == "":
+		return nil, errors.New("TLS certificate and key must be set together")
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.TLSCertPath, cfg.TLSKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if cfg.TLSCaCertPath == "" {
+		return tlsConfig, nil
+	}
+
+	pem, err := os.ReadFile(cfg.TLSCaCertPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TLS CA certificate: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", cfg.TLSCaCertPath)
+	}
+	tlsConfig.ClientCAs = pool
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	return tlsConfig, nil
+}
+
+// gatewayCredentials returns the credentials the gateway dials the gRPC server
+// at cfg.GrpcGatewayURL with. Without TLS, or when GrpcGatewayURL is the
+// InsecureGRPCPort, that is plaintext. Otherwise the gateway verifies the
+// server's own certificate and, under mutual TLS, presents GatewayTLSCertPath
+// and GatewayTLSKeyPath, refusing to start when they are not set.
+func gatewayCredentials(cfg config.ServerConfig) (credentials.TransportCredentials, error) {
+	serverConfig, err := serverTLSConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure TLS: %w", err)
+	}
+	if serverConfig == nil || dialsInsecurePort(cfg) {
+		return insecure.NewCredentials(), nil
+	}
+
+	leaf, err := x509.ParseCertificate(serverConfig.Certificates[0].Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse TLS certificate: %w", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+	tlsConfig := &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+	if serverConfig.ClientCAs == nil {
+		return credentials.NewTLS(tlsConfig), nil
+	}
+
+	if cfg.GatewayTLSCertPath == "" || cfg.GatewayTLSKeyPath == "" {
+		return nil, errors.New("mutual TLS requires a gateway client certificate and key, or GrpcGatewayURL on the insecure gRPC port")
+	}
+	cert, err := tls.LoadX509KeyPair(cfg.GatewayTLSCertPath, cfg.GatewayTLSKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gateway TLS certificate: %w", err)
+	}
+	tlsConfig.Certificates = []tls.Certificate{cert}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// dialsInsecurePort reports whether cfg.GrpcGatewayURL is on the plaintext
+// cfg.InsecureGRPCPort.
+func dialsInsecurePort(cfg config.ServerConfig) bool {
+	if cfg.InsecureGRPCPort == "" {
+		return false
+	}
+	_, port, err := net.SplitHostPort(cfg.GrpcGatewayURL)
+	if err != nil {
+		return false
+	}
+	insecurePort := cfg.InsecureGRPCPort
+	if _, p, err := net.SplitHostPort(insecurePort); err == nil {
+		insecurePort = p
+	}
+	return port == insecurePort
+}
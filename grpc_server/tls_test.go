@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/imran31415/example-project-proto-db/config"
+	"github.com/imran31415/example-project-proto-db/internal/fakedb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// testCert is a certificate and key, in memory and written to PEM files.
+type testCert struct {
+	cert     *x509.Certificate
+	key      *ecdsa.PrivateKey
+	certPath string
+	keyPath  string
+}
+
+// tlsCert returns c as a certificate to present in a handshake.
+func (c *testCert) tlsCert() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.cert.Raw}, PrivateKey: c.key, Leaf: c.cert}
+}
+
+// pool returns a pool trusting c.
+func (c *testCert) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(c.cert)
+	return pool
+}
+
+// newTestCert creates a certificate named name, signed by parent or self
+// signed as a CA when parent is nil, and writes it to dir.
+func newTestCert(t *testing.T, dir, name string, parent *testCert, usage x509.ExtKeyUsage) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{usage}
+		tmpl.DNSNames = []string{"localhost"}
+		tmpl.IPAddresses = []net.IP{net.IPv4(127, 0, 0, 1)}
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &testCert{
+		cert:     cert,
+		key:      key,
+		certPath: filepath.Join(dir, name+".crt"),
+		keyPath:  filepath.Join(dir, name+".key"),
+	}
+	writePEM(t, c.certPath, "CERTIFICATE", der)
+	writePEM(t, c.keyPath, "EC PRIVATE KEY", keyDER)
+	return c
+}
+
+func writePEM(t *testing.T, path, typ string, der []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testPKI is a CA with server and client certificates it signed, and a client
+// certificate signed by another CA.
+type testPKI struct {
+	ca, server, client, otherClient *testCert
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	dir := t.TempDir()
+	ca := newTestCert(t, dir, "ca", nil, 0)
+	other := newTestCert(t, dir, "other-ca", nil, 0)
+	return &testPKI{
+		ca:          ca,
+		server:      newTestCert(t, dir, "server", ca, x509.ExtKeyUsageServerAuth),
+		client:      newTestCert(t, dir, "client", ca, x509.ExtKeyUsageClientAuth),
+		otherClient: newTestCert(t, dir, "other-client", other, x509.ExtKeyUsageClientAuth),
+	}
+}
+
+// startTestServer starts a GRPCServer for cfg on loopback ports, backed by a
+// the test database, and stops it when the test ends.
+func startTestServer(t *testing.T, cfg config.ServerConfig) *GRPCServer {
+	t.Helper()
+	h, _ := testDB()
+	g, err := NewGRPCServer(&Server{Db: fakedb.Open(h)}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		g.Stop(ctx)
+	})
+	return g
+}
+
+// checkHealth makes a health check RPC to addr with creds.
+func checkHealth(addr string, creds credentials.TransportCredentials) error {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	return err
+}
+
+func TestListenerCredentials(t *testing.T) {
+	pki := newTestPKI(t)
+	tlsCreds := func(certs ...tls.Certificate) credentials.TransportCredentials {
+		return credentials.NewTLS(&tls.Config{RootCAs: pki.ca.pool(), Certificates: certs})
+	}
+	untrusted := credentials.NewTLS(&tls.Config{RootCAs: x509.NewCertPool()})
+	plaintext := insecure.NewCredentials()
+
+	type attempt struct {
+		name     string
+		listener int
+		creds    credentials.TransportCredentials
+		ok       bool
+	}
+	tests := []struct {
+		name     string
+		cfg      config.ServerConfig
+		attempts []attempt
+	}{
+		{
+			name: "plaintext",
+			cfg:  config.ServerConfig{GRPCPort: "127.0.0.1:0"},
+			attempts: []attempt{
+				{"plaintext client", 0, plaintext, true},
+				{"TLS client", 0, tlsCreds(), false},
+			},
+		},
+		{
+			name: "TLS",
+			cfg: config.ServerConfig{
+				GRPCPort:         "127.0.0.1:0",
+				InsecureGRPCPort: "127.0.0.1:0",
+				TLSCertPath:      pki.server.certPath,
+				TLSKeyPath:       pki.server.keyPath,
+			},
+			attempts: []attempt{
+				{"TLS client", 0, tlsCreds(), true},
+				{"plaintext client", 0, plaintext, false},
+				{"client not trusting the CA", 0, untrusted, false},
+				{"plaintext client on the insecure port", 1, plaintext, true},
+			},
+		},
+		{
+			name: "mTLS",
+			cfg: config.ServerConfig{
+				GRPCPort:      "127.0.0.1:0",
+				TLSCertPath:   pki.server.certPath,
+				TLSKeyPath:    pki.server.keyPath,
+				TLSCaCertPath: pki.ca.certPath,
+			},
+			attempts: []attempt{
+				{"client certificate", 0, tlsCreds(pki.client.tlsCert()), true},
+				{"no client certificate", 0, tlsCreds(), false},
+				{"client certificate from another CA", 0, tlsCreds(pki.otherClient.tlsCert()), false},
+				{"plaintext client", 0, plaintext, false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addrs := startTestServer(t, tt.cfg).Addrs()
+			for _, a := range tt.attempts {
+				err := checkHealth(addrs[a.listener].String(), a.creds)
+				switch {
+				case a.ok && err != nil:
+					t.Errorf("%s: rejected: %v", a.name, err)
+				case !a.ok && err == nil:
+					t.Errorf("%s: accepted", a.name)
+				}
+			}
+		})
+	}
+}
+
+func TestServerTLSConfigErrors(t *testing.T) {
+	pki := newTestPKI(t)
+	for _, cfg := range []config.ServerConfig{
+		{TLSCaCertPath: pki.ca.certPath},
+		{TLSCertPath: pki.server.certPath},
+		{TLSKeyPath: pki.server.keyPath},
+		{TLSCertPath: pki.server.certPath, TLSKeyPath: pki.client.keyPath},
+		{TLSCertPath: pki.server.certPath, TLSKeyPath: pki.server.keyPath, TLSCaCertPath: pki.server.keyPath},
+	} {
+		if _, err := serverTLSConfig(cfg); err == nil {
+			t.Errorf("serverTLSConfig(%+v) succeeded", cfg)
+		}
+	}
+}
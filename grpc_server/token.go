@@ -0,0 +1,176 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/imran31415/example-project-proto-db/config"
+)
+
+// tokenIssuer signs and verifies access tokens and mints refresh tokens.
+type tokenIssuer struct {
+	method     jwt.SigningMethod
+	signKey    interface{}
+	verifyKey  interface{}
+	issuer     string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+// accessClaims are the claims of an access token.
+type accessClaims struct {
+	UserID int32    `json:"user_id"`
+	Roles  []string `json:"roles"`
+	jwt.RegisteredClaims
+}
+
+// newTokenIssuer loads the signing key described by cfg. Without a configured
+// key a random HS256 secret is generated, so tokens do not survive restarts.
+func newTokenIssuer(cfg config.AuthConfig) (*tokenIssuer, error) {
+	t := &tokenIssuer{
+		issuer:     cfg.JWTIssuer,
+		accessTTL:  cfg.AccessTokenTTL,
+		refreshTTL: cfg.RefreshTokenTTL,
+	}
+	switch {
+	case cfg.JWTPrivateKeyPath != "":
+		if cfg.JWTSecret != "" {
+			return nil, errors.New("JWT secret and private key are mutually exclusive")
+		}
+		if err := t.loadPrivateKey(cfg.JWTPrivateKeyPath); err != nil {
+			return nil, err
+		}
+	case cfg.JWTSecret != "":
+		t.method, t.signKey, t.verifyKey = jwt.SigningMethodHS256, []byte(cfg.JWTSecret), []byte(cfg.JWTSecret)
+	default:
+		log.Println("No JWT key configured. Signing tokens with a random secret that is lost on restart.")
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate JWT secret: %w", err)
+		}
+		t.method, t.signKey, t.verifyKey = jwt.SigningMethodHS256, secret, secret
+	}
+	return t, nil
+}
+
+// loadPrivateKey loads a PEM encoded RSA key for RS256 or P-256 EC key for
+// ES256.
+func (t *tokenIssuer) loadPrivateKey(path string) error {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read JWT private key: %w", err)
+	}
+	block, _ := pem.Decode(buf)
+	if block == nil {
+		return fmt.Errorf("no PEM data found in %s", path)
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse JWT private key: %w", err)
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		t.method, t.signKey, t.verifyKey = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return fmt.Errorf("unsupported EC curve %s for ES256", k.Curve.Params().Name)
+		}
+		t.method, t.signKey, t.verifyKey = jwt.SigningMethodES256, k, &k.PublicKey
+	default:
+		return fmt.Errorf("unsupported JWT private key type %T", key)
+	}
+	return nil
+}
+
+// issueAccessToken signs an access token for the user and its role names.
+func (t *tokenIssuer) issueAccessToken(userID int32, roles []string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(t.accessTTL)
+	id, err := randomToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	claims := accessClaims{
+		UserID: userID,
+		Roles:  roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        id,
+			Issuer:    t.issuer,
+			Subject:   strconv.Itoa(int(userID)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	token, err := jwt.NewWithClaims(t.method, claims).SignedString(t.signKey)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign access token: %w", err)
+	}
+	return token, expiresAt, nil
+}
+
+// parseAccessToken verifies the signature, issuer and expiry of token and
+// returns its claims.
+func (t *tokenIssuer) parseAccessToken(token string) (*accessClaims, error) {
+	var claims accessClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return t.verifyKey, nil
+	},
+		jwt.WithValidMethods([]string{t.method.Alg()}),
+		jwt.WithIssuer(t.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
+
+// newRefreshToken returns a random refresh token, its hash for storage and
+// its expiry.
+func (t *tokenIssuer) newRefreshToken() (string, string, time.Time, error) {
+	token, err := randomToken()
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	return token, hashRefreshToken(token), time.Now().Add(t.refreshTTL), nil
+}
+
+// hashRefreshToken returns the hex encoded SHA-256 of token, which is what the
+// RefreshToken table stores.
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomToken returns 32 random bytes encoded for use in URLs.
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/imran31415/example-project-proto-db/generated_models"
+)
+
+// Retry bounds for transactions aborted by a deadlock or lock wait timeout.
+const (
+	maxTxAttempts  = 3
+	txRetryBackoff = 50 * time.Millisecond
+)
+
+// WithTx runs fn in a transaction at the given isolation level, committing
+// when fn returns nil and rolling back otherwise. sql.LevelDefault uses the
+// server's isolation level.
+//
+// Transactions that fail with a MySQL deadlock or lock wait timeout are rolled
+// back and run again from the start, so fn must not have effects outside tx.
+func (s *Server) WithTx(ctx context.Context, isolation sql.IsolationLevel, fn func(tx generated_models.DB) error) error {
+	for attempt := 1; ; attempt++ {
+		err := s.runTx(ctx, isolation, fn)
+		if err == nil || attempt == maxTxAttempts || !retryableTxError(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt) * txRetryBackoff):
+		}
+	}
+}
+
+// runTx runs fn in a single transaction.
+func (s *Server) runTx(ctx context.Context, isolation sql.IsolationLevel, fn func(tx generated_models.DB) error) error {
+	tx, err := s.Db.BeginTx(ctx, &sql.TxOptions{Isolation: isolation})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// retryableTxError reports whether err aborted a transaction that may succeed
+// if run again.
+func retryableTxError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+	return mysqlErr.Number == mysqlErrLockDeadlock || mysqlErr.Number == mysqlErrLockWaitTimeout
+}
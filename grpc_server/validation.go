@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/imran31415/example-project-proto-db/auth"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// validatable is implemented by the messages of proto/auth.proto through the
+// code generated by generate/validators.go.
+type validatable interface {
+	Validate() error
+	ValidatePartial() error
+	ValidateMask(paths ...string) error
+}
+
+// partialValidation holds the full method names of the AuthService RPCs with
+// the partial_validation option.
+var partialValidation = func() map[string]bool {
+	partial := make(map[string]bool)
+	for name, md := range authServiceMethods {
+		partial[name], _ = proto.GetExtension(md.Options(), auth.E_PartialValidation).(bool)
+	}
+	return partial
+}()
+
+// unaryValidationInterceptor checks requests against the rules declared in
+// proto/auth.proto before their handlers run, returning InvalidArgument with a
+// google.rpc.BadRequest listing the violations.
+func unaryValidationInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := validateRequest(info.FullMethod, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// validateRequest validates req, partially if method has the
+// partial_validation option.
+func validateRequest(method string, req interface{}) error {
+	msg, ok := req.(validatable)
+	if !ok {
+		return nil
+	}
+	if partialValidation[method] {
+		return validationStatus(msg.ValidatePartial())
+	}
+	return validationStatus(msg.Validate())
+}
+
+// validateMask validates req partially, but with the required rules of the
+// fields of its resource field named by mask, so that an update cannot clear
+// them. An empty mask names no fields.
+func validateMask(req validatable, resource string, mask *fieldmaskpb.FieldMask) error {
+	paths := make([]string, len(mask.GetPaths()))
+	for i, path := range mask.GetPaths() {
+		paths[i] = resource + "." + path
+	}
+	return validationStatus(req.ValidateMask(paths...))
+}
+
+// validationStatus converts an *auth.ValidationError into an InvalidArgument
+// status with a google.rpc.BadRequest listing the violations. Other errors
+// are returned as is.
+func validationStatus(err error) error {
+	var validationErr *auth.ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+	badRequest := &errdetails.BadRequest{}
+	for _, v := range validationErr.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+	st, err := status.New(codes.InvalidArgument, validationErr.Error()).WithDetails(badRequest)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, validationErr.Error())
+	}
+	return st.Err()
+}
@@ -0,0 +1,186 @@
+// Package fakedb is a database/sql driver that answers statements with a
+// handler instead of a server, for testing code that runs SQL without a
+// MySQL instance.
+package fakedb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+)
+
+// Statement is a statement run through the driver and its arguments.
+type Statement struct {
+	Query string
+	Args  []driver.Value
+}
+
+// Result is the outcome of an exec.
+type Result struct {
+	LastInsertID int64
+	RowsAffected int64
+}
+
+// Rows is the outcome of a query.
+type Rows struct {
+	Columns []string
+	Values  [][]driver.Value
+}
+
+// Handler answers the statements run against a DB. A nil func answers with
+// an empty result.
+type Handler struct {
+	Exec  func(query string, args []driver.Value) (Result, error)
+	Query func(query string, args []driver.Value) (Rows, error)
+	// Ping is returned by pings, which succeed when it is nil.
+	Ping error
+
+	mu  sync.Mutex
+	log []Statement
+}
+
+// Statements returns the statements run so far, in order.
+func (h *Handler) Statements() []Statement {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]Statement(nil), h.log...)
+}
+
+func (h *Handler) record(query string, args []driver.NamedValue) []driver.Value {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	h.mu.Lock()
+	h.log = append(h.log, Statement{Query: query, Args: values})
+	h.mu.Unlock()
+	return values
+}
+
+// Open returns a DB answering statements with h.
+func Open(h *Handler) *sql.DB {
+	return sql.OpenDB(connector{h})
+}
+
+type connector struct {
+	h *Handler
+}
+
+func (c connector) Connect(context.Context) (driver.Conn, error) {
+	return &conn{h: c.h}, nil
+}
+
+func (c connector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("fakedb: open with fakedb.Open")
+}
+
+type conn struct {
+	h *Handler
+}
+
+func (c *conn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("fakedb: prepared statements are not supported")
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return tx{}, nil
+}
+
+func (c *conn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return tx{}, nil
+}
+
+func (c *conn) Ping(context.Context) error {
+	return c.h.Ping
+}
+
+// CheckNamedValue accepts the arguments as converted by database/sql.
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	v, err := driver.DefaultParameterConverter.ConvertValue(nv.Value)
+	if err != nil {
+		return err
+	}
+	nv.Value = v
+	return nil
+}
+
+func (c *conn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	values := c.h.record(query, args)
+	if c.h.Exec == nil {
+		return result{}, nil
+	}
+	res, err := c.h.Exec(query, values)
+	if err != nil {
+		return nil, err
+	}
+	return result{res}, nil
+}
+
+func (c *conn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	values := c.h.record(query, args)
+	if c.h.Query == nil {
+		return &rows{}, nil
+	}
+	res, err := c.h.Query(query, values)
+	if err != nil {
+		return nil, err
+	}
+	return &rows{Rows: res}, nil
+}
+
+type result struct {
+	res Result
+}
+
+func (r result) LastInsertId() (int64, error) {
+	return r.res.LastInsertID, nil
+}
+
+func (r result) RowsAffected() (int64, error) {
+	return r.res.RowsAffected, nil
+}
+
+type tx struct{}
+
+func (tx) Commit() error {
+	return nil
+}
+
+func (tx) Rollback() error {
+	return nil
+}
+
+type rows struct {
+	Rows
+	next int
+}
+
+func (r *rows) Columns() []string {
+	return r.Rows.Columns
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.next == len(r.Values) {
+		return io.EOF
+	}
+	copy(dest, r.Values[r.next])
+	r.next++
+	return nil
+}
@@ -0,0 +1,398 @@
+package fakedb
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Table is a table held in memory by a handler from Tables.
+type Table struct {
+	Columns []string
+	Rows    [][]driver.Value
+	// Key is the number of leading columns forming the primary key, 1 when
+	// zero.
+	Key int
+	// Defaults are the values of the columns an insert leaves out, NULL for
+	// the others.
+	Defaults map[string]driver.Value
+	// AutoIncrement is the next key generated for rows inserted without
+	// their single column key, one past the largest stored key when zero.
+	AutoIncrement int64
+}
+
+// Statement patterns of the generated models.
+var (
+	selectRE = regexp.MustCompile(`^SELECT (.+?) FROM (\w+)(?: WHERE (.+?))?(?: FOR UPDATE)?$`)
+	insertRE = regexp.MustCompile(`^INSERT INTO (\w+) \(([^)]*)\) VALUES (.+?)(?: ON DUPLICATE KEY UPDATE (.+))?$`)
+	updateRE = regexp.MustCompile(`^UPDATE (\w+) SET (.+?) WHERE (.+)$`)
+	deleteRE = regexp.MustCompile(`^DELETE FROM (\w+) WHERE (.+)$`)
+	inRE     = regexp.MustCompile(`^\(([\w, ]+)\) IN \(.*\)$`)
+)
+
+// Tables returns a handler running the statements of the generated models
+// against tables, keyed by name. It understands single table SELECTs,
+// INSERTs with or without ON DUPLICATE KEY UPDATE, UPDATEs and DELETEs whose
+// WHERE clauses are conjunctions of "c = ?", "c IS NULL" and "(c, ...) IN
+// (...)". Other statements fail.
+func Tables(tables map[string]*Table) *Handler {
+	db := &tablesDB{tables: tables}
+	return &Handler{Exec: db.exec, Query: db.query}
+}
+
+type tablesDB struct {
+	mu     sync.Mutex
+	tables map[string]*Table
+}
+
+func (db *tablesDB) table(name string) (*Table, error) {
+	t, ok := db.tables[name]
+	if !ok {
+		return nil, fmt.Errorf("fakedb: unknown table %s", name)
+	}
+	return t, nil
+}
+
+func (db *tablesDB) query(query string, args []driver.Value) (Rows, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	m := selectRE.FindStringSubmatch(strings.TrimSpace(query))
+	if m == nil {
+		return Rows{}, fmt.Errorf("fakedb: unsupported query %q", query)
+	}
+	t, err := db.table(m[2])
+	if err != nil {
+		return Rows{}, err
+	}
+	columns := splitList(m[1])
+	indexes := make([]int, len(columns))
+	for i, c := range columns {
+		if indexes[i] = slices.Index(t.Columns, c); indexes[i] < 0 {
+			return Rows{}, fmt.Errorf("fakedb: unknown column %s.%s", m[2], c)
+		}
+	}
+	matched, err := t.where(m[3], args)
+	if err != nil {
+		return Rows{}, err
+	}
+	res := Rows{Columns: columns}
+	for _, row := range matched {
+		values := make([]driver.Value, len(indexes))
+		for i, j := range indexes {
+			values[i] = t.Rows[row][j]
+		}
+		res.Values = append(res.Values, values)
+	}
+	return res, nil
+}
+
+func (db *tablesDB) exec(query string, args []driver.Value) (Result, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	query = strings.TrimSpace(query)
+	if m := insertRE.FindStringSubmatch(query); m != nil {
+		t, err := db.table(m[1])
+		if err != nil {
+			return Result{}, err
+		}
+		return t.insert(splitList(m[2]), m[4], args)
+	}
+	if m := updateRE.FindStringSubmatch(query); m != nil {
+		t, err := db.table(m[1])
+		if err != nil {
+			return Result{}, err
+		}
+		return t.update(splitList(m[2]), m[3], args)
+	}
+	if m := deleteRE.FindStringSubmatch(query); m != nil {
+		t, err := db.table(m[1])
+		if err != nil {
+			return Result{}, err
+		}
+		matched, err := t.where(m[2], args)
+		if err != nil {
+			return Result{}, err
+		}
+		for i := len(matched) - 1; i >= 0; i-- {
+			t.Rows = slices.Delete(t.Rows, matched[i], matched[i]+1)
+		}
+		return Result{RowsAffected: int64(len(matched))}, nil
+	}
+	return Result{}, fmt.Errorf("fakedb: unsupported statement %q", query)
+}
+
+func (t *Table) keyColumns() int {
+	if t.Key == 0 {
+		return 1
+	}
+	return t.Key
+}
+
+// insert inserts the rows of args, updating the rows with the same key as
+// update says when it is set.
+func (t *Table) insert(columns []string, update string, args []driver.Value) (Result, error) {
+	if len(columns) == 0 || len(args)%len(columns) != 0 {
+		return Result{}, fmt.Errorf("fakedb: %d arguments for %d columns", len(args), len(columns))
+	}
+	indexes := make([]int, len(columns))
+	for i, c := range columns {
+		if indexes[i] = slices.Index(t.Columns, c); indexes[i] < 0 {
+			return Result{}, fmt.Errorf("fakedb: unknown column %s", c)
+		}
+	}
+	var res Result
+	for len(args) != 0 {
+		row := make([]driver.Value, len(t.Columns))
+		for i, c := range t.Columns {
+			row[i] = t.Defaults[c]
+		}
+		for i, j := range indexes {
+			row[j] = args[i]
+		}
+		args = args[len(columns):]
+
+		generated := t.keyColumns() == 1 && !slices.Contains(indexes, 0)
+		if !generated {
+			if i := t.find(row[:t.keyColumns()]); i >= 0 {
+				if update == "" {
+					return res, fmt.Errorf("fakedb: duplicate key %v", row[:t.keyColumns()])
+				}
+				id, err := t.onDuplicate(i, row, update)
+				if err != nil {
+					return res, err
+				}
+				if id != 0 {
+					res.LastInsertID = id
+				}
+				res.RowsAffected += 2
+				continue
+			}
+		} else {
+			row[0] = t.nextID()
+			if res.LastInsertID == 0 {
+				res.LastInsertID = row[0].(int64)
+			}
+		}
+		t.Rows = append(t.Rows, row)
+		res.RowsAffected++
+	}
+	return res, nil
+}
+
+// onDuplicate updates the stored row i with the values of row named by
+// update, returning the key LAST_INSERT_ID sets, if any.
+func (t *Table) onDuplicate(i int, row []driver.Value, update string) (int64, error) {
+	var id int64
+	for _, set := range splitList(update) {
+		c, expr, _ := strings.Cut(set, " = ")
+		j := slices.Index(t.Columns, c)
+		switch {
+		case j < 0:
+			return 0, fmt.Errorf("fakedb: unknown column %s", c)
+		case expr == "VALUES("+c+")":
+			t.Rows[i][j] = row[j]
+		case expr == "LAST_INSERT_ID("+c+")":
+			id, _ = t.Rows[i][j].(int64)
+		case expr == c:
+		default:
+			return 0, fmt.Errorf("fakedb: unsupported update %q", set)
+		}
+	}
+	return id, nil
+}
+
+func (t *Table) nextID() int64 {
+	if t.AutoIncrement == 0 {
+		t.AutoIncrement = 1
+		for _, row := range t.Rows {
+			if id, ok := row[0].(int64); ok && id >= t.AutoIncrement {
+				t.AutoIncrement = id + 1
+			}
+		}
+	}
+	id := t.AutoIncrement
+	t.AutoIncrement++
+	return id
+}
+
+// find returns the index of the row with key, or -1.
+func (t *Table) find(key []driver.Value) int {
+	return slices.IndexFunc(t.Rows, func(row []driver.Value) bool {
+		return slices.EqualFunc(row[:len(key)], key, equal)
+	})
+}
+
+// update applies the assignments of set to the rows matching cond.
+func (t *Table) update(set []string, cond string, args []driver.Value) (Result, error) {
+	type assignment struct {
+		column int
+		value  func(old driver.Value) driver.Value
+	}
+	var assignments []assignment
+	for _, s := range set {
+		c, expr, _ := strings.Cut(s, " = ")
+		j := slices.Index(t.Columns, c)
+		if j < 0 {
+			return Result{}, fmt.Errorf("fakedb: unknown column %s", c)
+		}
+		switch expr {
+		case "?":
+			if len(args) == 0 {
+				return Result{}, fmt.Errorf("fakedb: too few arguments")
+			}
+			v := args[0]
+			args = args[1:]
+			assignments = append(assignments, assignment{j, func(driver.Value) driver.Value { return v }})
+		case "NULL":
+			assignments = append(assignments, assignment{j, func(driver.Value) driver.Value { return nil }})
+		case c + " + 1":
+			assignments = append(assignments, assignment{j, func(old driver.Value) driver.Value {
+				n, _ := old.(int64)
+				return n + 1
+			}})
+		default:
+			return Result{}, fmt.Errorf("fakedb: unsupported assignment %q", s)
+		}
+	}
+	matched, err := t.where(cond, args)
+	if err != nil {
+		return Result{}, err
+	}
+	for _, i := range matched {
+		for _, a := range assignments {
+			t.Rows[i][a.column] = a.value(t.Rows[i][a.column])
+		}
+	}
+	return Result{RowsAffected: int64(len(matched))}, nil
+}
+
+// where returns the indexes of the rows matching cond, with its placeholders
+// bound to args.
+func (t *Table) where(cond string, args []driver.Value) ([]int, error) {
+	type term struct {
+		columns []int
+		// values are the rows of values the columns may hold, none for IS NULL
+		values [][]driver.Value
+	}
+	var terms []term
+	cond = strings.TrimSpace(cond)
+	for _, c := range splitTerms(cond) {
+		c = strings.TrimSpace(c)
+		switch {
+		case strings.HasSuffix(c, " = ?"):
+			j := slices.Index(t.Columns, strings.TrimSuffix(c, " = ?"))
+			if j < 0 || len(args) == 0 {
+				return nil, fmt.Errorf("fakedb: unsupported condition %q", c)
+			}
+			terms = append(terms, term{[]int{j}, [][]driver.Value{{args[0]}}})
+			args = args[1:]
+		case strings.HasSuffix(c, " IS NULL"):
+			j := slices.Index(t.Columns, strings.TrimSuffix(c, " IS NULL"))
+			if j < 0 {
+				return nil, fmt.Errorf("fakedb: unsupported condition %q", c)
+			}
+			terms = append(terms, term{columns: []int{j}})
+		case inRE.MatchString(c):
+			m := inRE.FindStringSubmatch(c)
+			tm := term{}
+			for _, name := range splitList(m[1]) {
+				j := slices.Index(t.Columns, name)
+				if j < 0 {
+					return nil, fmt.Errorf("fakedb: unsupported condition %q", c)
+				}
+				tm.columns = append(tm.columns, j)
+			}
+			n := strings.Count(c, "?")
+			if n%len(tm.columns) != 0 || n > len(args) {
+				return nil, fmt.Errorf("fakedb: unsupported condition %q", c)
+			}
+			for ; n != 0; n -= len(tm.columns) {
+				tm.values = append(tm.values, args[:len(tm.columns)])
+				args = args[len(tm.columns):]
+			}
+			terms = append(terms, tm)
+		default:
+			return nil, fmt.Errorf("fakedb: unsupported condition %q", c)
+		}
+	}
+	if len(args) != 0 {
+		return nil, fmt.Errorf("fakedb: %d arguments left over", len(args))
+	}
+	var matched []int
+rows:
+	for i, row := range t.Rows {
+		for _, tm := range terms {
+			values := make([]driver.Value, len(tm.columns))
+			for k, j := range tm.columns {
+				values[k] = row[j]
+			}
+			if tm.values == nil {
+				if values[0] != nil {
+					continue rows
+				}
+				continue
+			}
+			if !slices.ContainsFunc(tm.values, func(want []driver.Value) bool {
+				return slices.EqualFunc(values, want, equal)
+			}) {
+				continue rows
+			}
+		}
+		matched = append(matched, i)
+	}
+	return matched, nil
+}
+
+// splitTerms splits cond on its top level ANDs.
+func splitTerms(cond string) []string {
+	if cond == "" {
+		return nil
+	}
+	var terms []string
+	depth, start := 0, 0
+	for i := 0; i < len(cond); i++ {
+		switch cond[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ' ':
+			if depth == 0 && strings.HasPrefix(cond[i:], " AND ") {
+				terms = append(terms, cond[start:i])
+				start = i + len(" AND ")
+				i = start - 1
+			}
+		}
+	}
+	return append(terms, cond[start:])
+}
+
+// splitList splits a comma separated list, trimming its items.
+func splitList(s string) []string {
+	items := strings.Split(s, ",")
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+	return items
+}
+
+// equal reports whether two driver values are equal.
+func equal(a, b driver.Value) bool {
+	switch x := a.(type) {
+	case time.Time:
+		y, ok := b.(time.Time)
+		return ok && x.Equal(y)
+	case []byte:
+		y, ok := b.([]byte)
+		return ok && bytes.Equal(x, y)
+	}
+	if _, ok := b.([]byte); ok {
+		return false
+	}
+	return a == b
+}
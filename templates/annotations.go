@@ -0,0 +1,20 @@
+//go:build xotpl
+
+// Code generated by generate/annotations.go. DO NOT EDIT.
+
+package gotpl
+
+// columnAnnotations are the annotations of the columns, keyed by table and
+// column name.
+var columnAnnotations = map[string]map[string]columnAnnotation{
+	"User": {
+		"updated_at": {DBUpdated: true},
+		"deleted_at": {SoftDelete: true},
+		"version":    {RowVersion: true},
+	},
+	"Role": {
+		"updated_at": {DBUpdated: true},
+		"deleted_at": {SoftDelete: true},
+		"version":    {RowVersion: true},
+	},
+}
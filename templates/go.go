@@ -552,6 +552,20 @@ func emitSchema(ctx context.Context, schema xo.Schema, emit func(xo.Template)) e
 				Data:     index,
 			})
 		}
+		// emit leftmost prefix lookups for composite unique indexes
+		for _, i := range prefixIndexes(t) {
+			index, err := convertIndex(ctx, table, i)
+			if err != nil {
+				return err
+			}
+			emit(xo.Template{
+				Dest:     strings.ToLower(table.GoName) + ext,
+				Partial:  "index",
+				SortType: table.Type,
+				SortName: index.SQLName + "_" + index.Fields[0].SQLName,
+				Data:     index,
+			})
+		}
 		// emit fkeys
 		for _, fk := range t.ForeignKeys {
 			fkey, err := convertFKey(ctx, table, fk)
@@ -650,11 +664,18 @@ func convertTable(ctx context.Context, t xo.Table) (Table, error) {
 		if err != nil {
 			return Table{}, err
 		}
+		a := columnAnnotations[t.Name][z.Name]
+		f.SoftDelete, f.RowVersion, f.DBUpdated = a.SoftDelete, a.RowVersion, a.DBUpdated
 		cols = append(cols, f)
 		if z.IsPrimary {
 			pkCols = append(pkCols, f)
 		}
 	}
+	// tables without a primary key (ie, join tables) are keyed on their
+	// first unique index
+	if len(pkCols) == 0 {
+		pkCols = uniqueKeyFields(cols, t.Indexes)
+	}
 	return Table{
 		GoName:      camelExport(singularize(t.Name)),
 		SQLName:     t.Name,
@@ -665,6 +686,54 @@ func convertTable(ctx context.Context, t xo.Table) (Table, error) {
 	}, nil
 }
 
+// uniqueKeyFields returns the fields of the first unique index, marking the
+// matching columns in cols as primary so that the generated Insert, Update,
+// Upsert and Delete use them as the row key.
+func uniqueKeyFields(cols []Field, indexes []xo.Index) []Field {
+	for _, i := range indexes {
+		if !i.IsUnique || len(i.Fields) == 0 {
+			continue
+		}
+		var keys []Field
+		for _, z := range i.Fields {
+			for j := range cols {
+				if cols[j].SQLName == z.Name {
+					cols[j].IsPrimary = true
+					keys = append(keys, cols[j])
+				}
+			}
+		}
+		return keys
+	}
+	return nil
+}
+
+// prefixIndexes returns a non-unique index on the leading column of each
+// composite unique index, unless the table already has an index on that
+// column alone. MySQL serves these lookups from the composite index, so no
+// separate index is created for them.
+func prefixIndexes(t xo.Table) []xo.Index {
+	covered := make(map[string]bool)
+	for _, i := range t.Indexes {
+		if len(i.Fields) == 1 {
+			covered[i.Fields[0].Name] = true
+		}
+	}
+	var indexes []xo.Index
+	for _, i := range t.Indexes {
+		if !i.IsUnique || len(i.Fields) < 2 || covered[i.Fields[0].Name] {
+			continue
+		}
+		covered[i.Fields[0].Name] = true
+		indexes = append(indexes, xo.Index{
+			Name:   i.Name,
+			Fields: i.Fields[:1],
+			Func:   t.Name + "_by_" + i.Fields[0].Name,
+		})
+	}
+	return indexes
+}
+
 func convertIndex(ctx context.Context, t Table, i xo.Index) (Index, error) {
 	var fields []Field
 	for _, z := range i.Fields {
@@ -746,6 +815,7 @@ func convertField(ctx context.Context, tf transformFunc, f xo.Field) (Field, err
 		Zero:       zero,
 		IsPrimary:  f.IsPrimary,
 		IsSequence: f.IsSequence,
+		Default:    f.Default,
 		Comment:    f.Comment,
 	}, nil
 }
@@ -896,14 +966,33 @@ func (f *Funcs) FuncMap() template.FuncMap {
 		"logf_pkeys":          f.logf_pkeys,
 		"logf_update":         f.logf_update,
 		// type
-		"names":        f.names,
-		"names_all":    f.names_all,
-		"names_ignore": f.names_ignore,
-		"params":       f.params,
-		"zero":         f.zero,
-		"type":         f.typefn,
-		"field":        f.field,
-		"short":        f.short,
+		"names":             f.names,
+		"names_all":         f.names_all,
+		"names_ignore":      f.names_ignore,
+		"params":            f.params,
+		"zero":              f.zero,
+		"type":              f.typefn,
+		"field":             f.field,
+		"filter_type":       f.filter_type,
+		"filter_init":       f.filter_init,
+		"unexport":          unexport,
+		"soft_delete":       softDelete,
+		"soft_deleted":      softDeleted,
+		"soft_delete_field": softDeleteField,
+		"versioned":         versioned,
+		"version_field":     versionField,
+		"audited":           audited,
+		"defaulted":         defaulted,
+		"conflicting":       conflicting,
+		"read_back":         readBack,
+		"bound":             bound,
+		"written":           written,
+		"plural":            inflector.Pluralize,
+		"db_updated":        dbUpdated,
+		"nullables":         nullables,
+		"unset":             unset,
+		"isset":             isset,
+		"short":             f.short,
 		// sqlstr funcs
 		"querystr": f.querystr,
 		"sqlstr":   f.sqlstr,
@@ -1236,7 +1325,13 @@ func (f *Funcs) db_update(name string, v interface{}) string {
 		for _, pk := range x.PrimaryKeys {
 			ignore = append(ignore, pk.GoName)
 		}
+		if versioned(x) {
+			ignore = append(ignore, versionField(x).GoName)
+		}
 		p = append(p, f.names_ignore(prefix, x, ignore...), f.names(prefix, x.PrimaryKeys))
+		if versioned(x) {
+			p = append(p, prefix+versionField(x).GoName)
+		}
 	default:
 		return fmt.Sprintf("[[ UNSUPPORTED TYPE 9: %T ]]", v)
 	}
@@ -1316,7 +1411,13 @@ func (f *Funcs) logf_update(v interface{}) string {
 		for _, pk := range x.PrimaryKeys {
 			ignore = append(ignore, pk.GoName)
 		}
+		if versioned(x) {
+			ignore = append(ignore, versionField(x).GoName)
+		}
 		p = append(p, f.names_ignore(prefix, x, ignore...), f.names(prefix, x.PrimaryKeys))
+		if versioned(x) {
+			p = append(p, prefix+versionField(x).GoName)
+		}
 	default:
 		return fmt.Sprintf("[[ UNSUPPORTED TYPE 13: %T ]]", v)
 	}
@@ -1534,7 +1635,8 @@ func (f *Funcs) sqlstr_update_base(prefix string, v interface{}) (int, []string)
 		var n int
 		var list []string
 		for _, z := range x.Fields {
-			if z.IsPrimary {
+			// upserts leave the soft delete column of a conflicting row
+			if z.IsPrimary || (prefix == "" && versionBumped(x, z)) || (prefix != "" && softDeleted(x, z)) {
 				continue
 			}
 			name, param := f.colname(z), f.nth(n)
@@ -1544,6 +1646,11 @@ func (f *Funcs) sqlstr_update_base(prefix string, v interface{}) (int, []string)
 			list = append(list, fmt.Sprintf("%s = %s", name, param))
 			n++
 		}
+		// the version is bumped rather than set
+		if prefix == "" && versioned(x) {
+			version := f.colname(versionField(x))
+			list = append(list, version+" = "+version+" + 1")
+		}
 		name := ""
 		if prefix == "" {
 			name = f.schemafn(x.SQLName) + " "
@@ -1567,6 +1674,10 @@ func (f *Funcs) sqlstr_update(v interface{}) []string {
 		for i, z := range x.PrimaryKeys {
 			list = append(list, fmt.Sprintf("%s = %s", f.colname(z), f.nth(n+i)))
 		}
+		// only the version that was read may be updated
+		if versioned(x) {
+			list = append(list, fmt.Sprintf("%s = %s", f.colname(versionField(x)), f.nth(n+len(x.PrimaryKeys))))
+		}
 		return append(lines, "WHERE "+strings.Join(list, " AND "))
 	}
 	return []string{fmt.Sprintf("[[ UNSUPPORTED TYPE 20: %T ]]", v)}
@@ -1617,7 +1728,8 @@ func (f *Funcs) sqlstr_upsert_mysql(v interface{}) []string {
 		var list []string
 		i := len(x.Fields)
 		for _, z := range x.Fields {
-			if z.IsSequence {
+			// a conflicting row keeps its soft delete column
+			if z.IsSequence || z.IsPrimary || softDeleted(x, z) {
 				continue
 			}
 			name := f.colname(z)
@@ -1724,6 +1836,10 @@ func (f *Funcs) sqlstr_index(v interface{}) []string {
 		for i, z := range x.Fields {
 			list = append(list, fmt.Sprintf("%s = %s", f.colname(z), f.nth(i)))
 		}
+		// soft deleted rows are not found
+		if softDelete(x.Table) {
+			list = append(list, f.colname(softDeleteField(x.Table))+" IS NULL")
+		}
 		return []string{
 			"SELECT ",
 			strings.Join(fields, ", ") + " ",
@@ -1926,6 +2042,232 @@ func (f *Funcs) field(field Field) (string, error) {
 	return fmt.Sprintf("\t%s %s%s // %s", field.GoName, f.typefn(field.Type), tag, comment), nil
 }
 
+// filter_type generates the predicate builder type for a field of table t.
+// Nullable sql.Null* fields compare against their underlying Go type.
+func (f *Funcs) filter_type(t Table, field Field) string {
+	typ := field.Type
+	if z, ok := nullTypes[typ]; ok {
+		typ = z
+	}
+	if typ == "string" {
+		return fmt.Sprintf("StringColumnFilter[%s]", t.GoName)
+	}
+	return fmt.Sprintf("ColumnFilter[%s, %s]", t.GoName, f.typefn(typ))
+}
+
+// filter_init generates the composite literal initializing the predicate
+// builder for a field of table t.
+func (f *Funcs) filter_init(t Table, field Field) string {
+	typ := f.filter_type(t, field)
+	init := fmt.Sprintf("{column: %q}", f.colname(field))
+	if strings.HasPrefix(typ, "StringColumnFilter") {
+		init = fmt.Sprintf("{ColumnFilter[%s, string]%s}", t.GoName, init)
+	}
+	return typ + init
+}
+
+// unexport lower cases the leading initialism or letter of name, ie UserRole
+// becomes userRole and ID becomes id.
+func unexport(name string) string {
+	i := 0
+	for i < len(name) && 'A' <= name[i] && name[i] <= 'Z' {
+		i++
+	}
+	if i > 1 && i < len(name) {
+		i--
+	}
+	return strings.ToLower(name[:i]) + name[i:]
+}
+
+// softDeleteField returns the soft_delete field of t, or the zero Field.
+func softDeleteField(t Table) Field {
+	for _, z := range t.Fields {
+		if z.SoftDelete {
+			return z
+		}
+	}
+	return Field{}
+}
+
+// softDelete reports whether rows of t are soft deleted, which is the case
+// when t has a primary key and a soft_delete field.
+func softDelete(t Table) bool {
+	return len(t.PrimaryKeys) != 0 && softDeleteField(t).SoftDelete
+}
+
+// softDeleted reports whether z is the soft_delete field of t.
+func softDeleted(t Table, z Field) bool {
+	return softDelete(t) && z.SoftDelete
+}
+
+// auditTable is the table the audit hook records mutations in. Its own rows
+// are not audited, see the AuditEvent message in proto/auth.proto.
+const auditTable = "AuditEvent"
+
+// audited reports whether the mutating methods of t pass their changes to the
+// audit hook, which is the case for tables with a primary key other than
+// auditTable.
+func audited(t Table) bool {
+	return len(t.PrimaryKeys) != 0 && t.SQLName != auditTable
+}
+
+// dbUpdated reports whether the database sets z on every update, as its
+// db_update_action says. Updates never write it.
+func dbUpdated(z Field) bool {
+	return z.DBUpdated
+}
+
+// versionField returns the row_version field of t, or the zero Field.
+func versionField(t Table) Field {
+	for _, z := range t.Fields {
+		if z.RowVersion {
+			return z
+		}
+	}
+	return Field{}
+}
+
+// versioned reports whether updates of t check and bump a row version, which
+// is the case when t has a primary key and a row_version field.
+func versioned(t Table) bool {
+	return len(t.PrimaryKeys) != 0 && versionField(t).RowVersion
+}
+
+// versionBumped reports whether z is the row_version field of t, which
+// updates bump rather than set.
+func versionBumped(t Table, z Field) bool {
+	return versioned(t) && z.RowVersion
+}
+
+// defaulted returns the fields of t with a database default, other than
+// sequences and primary keys. The statements of mode, one of "insert",
+// "upsert" or "update", leave them out when unset so that the database
+// assigns or keeps them. Updates also leave out the row version of a
+// versioned table, which they bump instead, and the fields the database sets
+// on update.
+func defaulted(t Table, mode string) []Field {
+	var fields []Field
+	for _, z := range t.Fields {
+		switch {
+		case z.Default == "" || z.IsSequence || z.IsPrimary:
+		case mode == "update" && (versionBumped(t, z) || dbUpdated(z)):
+		default:
+			fields = append(fields, z)
+		}
+	}
+	return fields
+}
+
+// bound returns the fields of t that the statements of mode always bind, see
+// defaulted. Inserts leave out sequences, updates leave out primary keys, the
+// row version of a versioned table and the fields the database sets on update.
+func bound(t Table, mode string) []Field {
+	var fields []Field
+	for _, z := range t.Fields {
+		switch {
+		case z.Default != "" && !z.IsSequence && !z.IsPrimary:
+		case mode == "insert" && z.IsSequence:
+		case mode == "update" && (z.IsPrimary || versionBumped(t, z) || dbUpdated(z)):
+		default:
+			fields = append(fields, z)
+		}
+	}
+	return fields
+}
+
+// written returns the fields of t that the statements of mode write when
+// every column with a database default is set, see bound and defaulted.
+func written(t Table, mode string) []Field {
+	var fields []Field
+	for _, z := range t.Fields {
+		switch {
+		case mode == "insert" && z.IsSequence:
+		case mode == "update" && (z.IsPrimary || versionBumped(t, z) || dbUpdated(z)):
+		default:
+			fields = append(fields, z)
+		}
+	}
+	return fields
+}
+
+// conflicting returns the fields of t that upserts bind and update on
+// conflict: those updates bind, less the soft_delete field, so that upserting
+// a soft deleted row cannot restore it.
+func conflicting(t Table) []Field {
+	var fields []Field
+	for _, z := range bound(t, "update") {
+		if !softDeleted(t, z) {
+			fields = append(fields, z)
+		}
+	}
+	return fields
+}
+
+// readBack returns the fields of t that multi-row inserts and upserts read
+// back: those with a database default, and the soft_delete field, which an
+// upsert conflicting with a soft deleted row keeps.
+func readBack(t Table) []Field {
+	fields := defaulted(t, "insert")
+	for _, z := range t.Fields {
+		if softDeleted(t, z) {
+			fields = append(fields, z)
+		}
+	}
+	return fields
+}
+
+// nullables returns the fields of t that may hold NULL.
+func nullables(t Table) []Field {
+	var fields []Field
+	for _, z := range t.Fields {
+		if nullTypes[z.Type] != "" || strings.HasPrefix(z.Type, "*") {
+			fields = append(fields, z)
+		}
+	}
+	return fields
+}
+
+// unset generates the Go expression reporting whether the field z of the
+// receiver v holds its zero value.
+func unset(v string, z Field) string {
+	name := v + "." + z.GoName
+	switch {
+	case z.Type == "time.Time":
+		return name + ".IsZero()"
+	case nullTypes[z.Type] != "":
+		return "!" + name + ".Valid"
+	case strings.HasPrefix(z.Type, "[]"):
+		return "len(" + name + ") == 0"
+	}
+	return name + " == " + z.Zero
+}
+
+// isset generates the negation of unset.
+func isset(v string, z Field) string {
+	name := v + "." + z.GoName
+	switch {
+	case z.Type == "time.Time":
+		return "!" + name + ".IsZero()"
+	case nullTypes[z.Type] != "":
+		return name + ".Valid"
+	case strings.HasPrefix(z.Type, "[]"):
+		return "len(" + name + ") != 0"
+	}
+	return name + " != " + z.Zero
+}
+
+// nullTypes maps the database/sql null types to their underlying Go type.
+var nullTypes = map[string]string{
+	"sql.NullBool":    "bool",
+	"sql.NullByte":    "byte",
+	"sql.NullFloat64": "float64",
+	"sql.NullInt16":   "int16",
+	"sql.NullInt32":   "int32",
+	"sql.NullInt64":   "int64",
+	"sql.NullString":  "string",
+	"sql.NullTime":    "time.Time",
+}
+
 // short generates a safe Go identifier for typ. typ is first checked
 // against shorts, and if not found, then the value is calculated and
 // stored in the shorts for future use.
@@ -2350,7 +2692,23 @@ type Field struct {
 	Zero       string
 	IsPrimary  bool
 	IsSequence bool
+	Default    string
 	Comment    string
+	// SoftDelete, RowVersion and DBUpdated are the annotations of a table
+	// column, see columnAnnotation.
+	SoftDelete bool
+	RowVersion bool
+	DBUpdated  bool
+}
+
+// columnAnnotation holds the annotations of a column in proto/auth.proto that
+// the database does not record: the soft_delete and row_version options in
+// proto/options.proto, and whether it has the db_update_action option of
+// protobuf-db. generate/annotations.go writes them to columnAnnotations.
+type columnAnnotation struct {
+	SoftDelete bool
+	RowVersion bool
+	DBUpdated  bool
 }
 
 // QueryParam is a custom query parameter template.
@@ -221,6 +221,8 @@ func (err ErrInvalid{{ $e.GoName }}) Error() string {
 
 {{ define "typedef" }}
 {{- $t := .Data -}}
+{{- $softDelete := soft_delete_field $t -}}
+{{- $version := version_field $t -}}
 {{- if $t.Comment -}}
 // {{ $t.Comment | eval $t.GoName }}
 {{- else -}}
@@ -248,6 +250,56 @@ func ({{ short $t }} *{{ $t.GoName }}) Deleted() bool {
 	return {{ short $t }}._deleted
 }
 
+{{ if audited $t -}}
+// auditBefore loads the stored [{{ $t.GoName }}] as the before image of an audit
+// entry. It returns nil when no audit hook is set or no row is stored.
+func ({{ short $t }} *{{ $t.GoName }}) auditBefore(ctx context.Context, db DB) (*{{ $t.GoName }}, error) {
+	if auditHook == nil {
+		return nil, nil
+	}
+	const sqlstr = `SELECT ` +
+		`{{ range $i, $f := $t.Fields }}{{ if $i }}, {{ end }}{{ $f.SQLName }}{{ end }} ` +
+		`FROM {{ $t.SQLName }} ` +
+		`WHERE {{ range $i, $k := $t.PrimaryKeys }}{{ if $i }} AND {{ end }}{{ $k.SQLName }} = ?{{ end }}`
+	logf(sqlstr, {{ names (print (short $t) ".") $t.PrimaryKeys }})
+	before := {{ $t.GoName }}{
+		_exists: true,
+	}
+	switch err := db.QueryRowContext(ctx, sqlstr, {{ names (print (short $t) ".") $t.PrimaryKeys }}).Scan({{ names "&before." $t }}); {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, logerror(err)
+	}
+	return &before, nil
+}
+
+// audit passes a mutation of the [{{ $t.GoName }}] to the audit hook, with the row
+// before and after it, either of which may be nil.
+func ({{ short $t }} *{{ $t.GoName }}) audit(ctx context.Context, db DB, action string, before, after *{{ $t.GoName }}) error {
+	if auditHook == nil {
+		return nil
+	}
+	entry := AuditEntry{
+		Table:      "{{ $t.SQLName }}",
+		Action:     action,
+		PrimaryKey: auditKey({{ names (print (short $t) ".") $t.PrimaryKeys }}),
+	}
+	// keep typed nils out of the interfaces
+	if before != nil {
+		entry.Before = before
+	}
+	if after != nil {
+		entry.After = after
+	}
+	if err := auditHook(ctx, db, entry); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+
+{{ end -}}
+
 // {{ func_name_context "Insert" }} inserts the [{{ $t.GoName }}] to the database.
 {{ recv_context $t "Insert" }} {
 	switch {
@@ -256,7 +308,58 @@ func ({{ short $t }} *{{ $t.GoName }}) Deleted() bool {
 	case {{ short $t }}._deleted: // deleted
 		return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
 	}
-{{ if $t.Manual -}}
+	// run the BeforeInsert hook
+	if err := beforeInsert(ctx, db, {{ short $t }}); err != nil {
+		return logerror(&ErrInsertFailed{err})
+	}
+{{ if and (driver "mysql") (defaulted $t "insert") -}}
+	// insert{{ if $t.Manual }} (manual){{ else }} (primary key generated and returned by database){{ end }}, leaving
+	// unset columns with a database default to the database
+	columns := []string{ {{- range $i, $z := bound $t "insert" }}{{ if $i }}, {{ end }}"{{ $z.SQLName }}"{{ end -}} }
+	args := []interface{}{ {{- range $i, $z := bound $t "insert" }}{{ if $i }}, {{ end }}{{ short $t }}.{{ $z.GoName }}{{ end -}} }
+	var defaults []string
+	var dest []interface{}
+{{- range defaulted $t "insert" }}
+	if {{ unset (short $t) . }} {
+		defaults, dest = append(defaults, "{{ .SQLName }}"), append(dest, &{{ short $t }}.{{ .GoName }})
+	} else {
+		columns, args = append(columns, "{{ .SQLName }}"), append(args, {{ short $t }}.{{ .GoName }})
+	}
+{{- end }}
+	sqlstr := `INSERT INTO {{ $t.SQLName }} (` + strings.Join(columns, ", ") + `) ` +
+		`VALUES (` + bindvars(len(args)) + `)`
+	// run
+	logf(sqlstr, args...)
+{{- if $t.Manual }}
+	if _, err := db.ExecContext(ctx, sqlstr, args...); err != nil {
+		return logerror(err)
+	}
+{{- else }}
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
+		return logerror(err)
+	}
+	// retrieve id
+	id, err := res.LastInsertId()
+	if err != nil {
+		return logerror(err)
+	}
+	// set primary key
+	{{ short $t }}.{{ (index $t.PrimaryKeys 0).GoName }} = {{ (index $t.PrimaryKeys 0).Type }}(id)
+{{- end }}
+{{- if $t.PrimaryKeys }}
+	// read back the values the database assigned
+	if len(defaults) != 0 {
+		sqlstr := `SELECT ` + strings.Join(defaults, ", ") + ` ` +
+			`FROM {{ $t.SQLName }} ` +
+			`WHERE {{ range $i, $k := $t.PrimaryKeys }}{{ if $i }} AND {{ end }}{{ $k.SQLName }} = ?{{ end }}`
+		logf(sqlstr, {{ names (print (short $t) ".") $t.PrimaryKeys }})
+		if err := db.QueryRowContext(ctx, sqlstr, {{ names (print (short $t) ".") $t.PrimaryKeys }}).Scan(dest...); err != nil {
+			return logerror(err)
+		}
+	}
+{{- end }}
+{{- else if $t.Manual -}}
 	// insert (manual)
 	{{ sqlstr "insert_manual" $t }}
 	// run
@@ -312,7 +415,15 @@ func ({{ short $t }} *{{ $t.GoName }}) Deleted() bool {
 {{- end }}
 	// set exists
 	{{ short $t }}._exists = true
+	// run the AfterInsert hook
+	if err := afterInsert(ctx, db, {{ short $t }}); err != nil {
+		return logerror(&ErrInsertFailed{err})
+	}
+{{- if audited $t }}
+	return {{ short $t }}.audit(ctx, db, "insert", nil, {{ short $t }})
+{{- else }}
 	return nil
+{{- end }}
 }
 
 {{ if context_both -}}
@@ -334,6 +445,118 @@ func ({{ short $t }} *{{ $t.GoName }}) Deleted() bool {
 	case {{ short $t }}._deleted: // deleted
 		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
 	}
+	// run the BeforeUpdate hook
+	if err := beforeUpdate(ctx, db, {{ short $t }}); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+{{- if audited $t }}
+	before, err := {{ short $t }}.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+{{- end }}
+{{ if versioned $t -}}
+{{ if and (driver "mysql") (defaulted $t "update") -}}
+	// update with primary key{{ if versioned $t }} and version{{ end }}, leaving unset columns
+	// with a database default unchanged
+	set := []string{ {{- range $i, $z := bound $t "update" }}{{ if $i }}, {{ end }}"{{ $z.SQLName }} = ?"{{ end -}} }
+	args := []interface{}{ {{- range $i, $z := bound $t "update" }}{{ if $i }}, {{ end }}{{ short $t }}.{{ $z.GoName }}{{ end -}} }
+{{- range defaulted $t "update" }}
+	if {{ isset (short $t) . }} {
+		set, args = append(set, "{{ .SQLName }} = ?"), append(args, {{ short $t }}.{{ .GoName }})
+	}
+{{- end }}
+	set = append(set, "{{ $version.SQLName }} = {{ $version.SQLName }} + 1")
+	sqlstr := `UPDATE {{ $t.SQLName }} SET ` + strings.Join(set, ", ") + ` ` +
+		`WHERE {{ range $i, $k := $t.PrimaryKeys }}{{ $k.SQLName }} = ? AND {{ end }}{{ $version.SQLName }} = ?`
+	args = append(args, {{ names (print (short $t) ".") $t.PrimaryKeys }}, {{ short $t }}.{{ $version.GoName }})
+	// run
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+{{- else -}}
+	// update with primary key and version
+	{{ sqlstr "update" $t }}
+	// run
+	{{ logf_update $t }}
+	res, err := {{ db_update "Exec" $t }}
+{{- end }}
+	if err != nil {
+		return logerror(err)
+	}
+	// no match means the row changed since it was read
+	switch n, err := res.RowsAffected(); {
+	case err != nil:
+		return logerror(err)
+	case n == 0:
+		return logerror(&ErrUpdateFailed{ErrStaleVersion})
+	}
+	{{ short $t }}.{{ $version.GoName }}++
+{{- range $t.Fields }}{{ if db_updated . }}
+	// read back the {{ .SQLName }} the database set
+	const selstr = `SELECT {{ .SQLName }} FROM {{ $t.SQLName }} ` +
+		`WHERE {{ range $i, $k := $t.PrimaryKeys }}{{ if $i }} AND {{ end }}{{ $k.SQLName }} = ?{{ end }}`
+	logf(selstr, {{ names (print (short $t) ".") $t.PrimaryKeys }})
+	if err := db.QueryRowContext(ctx, selstr, {{ names (print (short $t) ".") $t.PrimaryKeys }}).Scan(&{{ short $t }}.{{ .GoName }}); err != nil {
+		return logerror(err)
+	}
+{{- end }}{{ end }}
+	// run the AfterUpdate hook
+	if err := afterUpdate(ctx, db, {{ short $t }}); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+{{- if audited $t }}
+	return {{ short $t }}.audit(ctx, db, "update", before, {{ short $t }})
+{{- else }}
+	return nil
+{{- end }}
+}
+{{ else if and (driver "mysql") (defaulted $t "update") -}}
+	// update with primary key{{ if versioned $t }} and version{{ end }}, leaving unset columns
+	// with a database default unchanged
+	set := []string{ {{- range $i, $z := bound $t "update" }}{{ if $i }}, {{ end }}"{{ $z.SQLName }} = ?"{{ end -}} }
+	args := []interface{}{ {{- range $i, $z := bound $t "update" }}{{ if $i }}, {{ end }}{{ short $t }}.{{ $z.GoName }}{{ end -}} }
+{{- range defaulted $t "update" }}
+	if {{ isset (short $t) . }} {
+		set, args = append(set, "{{ .SQLName }} = ?"), append(args, {{ short $t }}.{{ .GoName }})
+	}
+{{- end }}
+	sqlstr := `UPDATE {{ $t.SQLName }} SET ` + strings.Join(set, ", ") + ` ` +
+		`WHERE {{ range $i, $k := $t.PrimaryKeys }}{{ if $i }} AND {{ end }}{{ $k.SQLName }} = ?{{ end }}`
+	args = append(args, {{ names (print (short $t) ".") $t.PrimaryKeys }})
+	// run
+{{- if bound $t "update" }}
+	logf(sqlstr, args...)
+	if _, err := db.ExecContext(ctx, sqlstr, args...); err != nil {
+		return logerror(err)
+	}
+{{- else }}
+	if len(set) != 0 {
+		logf(sqlstr, args...)
+		if _, err := db.ExecContext(ctx, sqlstr, args...); err != nil {
+			return logerror(err)
+		}
+	}
+{{- end }}
+{{- range $t.Fields }}{{ if db_updated . }}
+	// read back the {{ .SQLName }} the database set
+	const selstr = `SELECT {{ .SQLName }} FROM {{ $t.SQLName }} ` +
+		`WHERE {{ range $i, $k := $t.PrimaryKeys }}{{ if $i }} AND {{ end }}{{ $k.SQLName }} = ?{{ end }}`
+	logf(selstr, {{ names (print (short $t) ".") $t.PrimaryKeys }})
+	if err := db.QueryRowContext(ctx, selstr, {{ names (print (short $t) ".") $t.PrimaryKeys }}).Scan(&{{ short $t }}.{{ .GoName }}); err != nil {
+		return logerror(err)
+	}
+{{- end }}{{ end }}
+	// run the AfterUpdate hook
+	if err := afterUpdate(ctx, db, {{ short $t }}); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+{{- if audited $t }}
+	return {{ short $t }}.audit(ctx, db, "update", before, {{ short $t }})
+{{- else }}
+	return nil
+{{- end }}
+}
+{{ else -}}
 	// update with {{ if driver "postgres" }}composite {{ end }}primary key
 	{{ sqlstr "update" $t }}
 	// run
@@ -341,9 +564,17 @@ func ({{ short $t }} *{{ $t.GoName }}) Deleted() bool {
 	if _, err := {{ db_update "Exec" $t }}; err != nil {
 		return logerror(err)
 	}
+	// run the AfterUpdate hook
+	if err := afterUpdate(ctx, db, {{ short $t }}); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+{{- if audited $t }}
+	return {{ short $t }}.audit(ctx, db, "update", before, {{ short $t }})
+{{- else }}
 	return nil
+{{- end }}
 }
-
+{{ end }}
 {{ if context_both -}}
 // Update updates a [{{ $t.GoName }}] in the database.
 {{ recv $t "Update" }} {
@@ -375,6 +606,92 @@ func ({{ short $t }} *{{ $t.GoName }}) Deleted() bool {
 	case {{ short $t }}._deleted: // deleted
 		return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
 	}
+	// run the BeforeInsert hook
+	if err := beforeInsert(ctx, db, {{ short $t }}); err != nil {
+		return logerror(&ErrUpsertFailed{err})
+	}
+{{- if audited $t }}
+	before, err := {{ short $t }}.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+{{- end }}
+{{- if and (driver "mysql") (defaulted $t "upsert") }}
+	// upsert, leaving unset columns with a database default to the database
+	// or, on conflict, to their stored values
+	columns := []string{ {{- range $i, $z := bound $t "insert" }}{{ if $i }}, {{ end }}"{{ $z.SQLName }}"{{ end -}} }
+	args := []interface{}{ {{- range $i, $z := bound $t "insert" }}{{ if $i }}, {{ end }}{{ short $t }}.{{ $z.GoName }}{{ end -}} }
+	update := []string{ {{- range $i, $z := conflicting $t }}{{ if $i }}, {{ end }}"{{ $z.SQLName }} = VALUES({{ $z.SQLName }})"{{ end -}} }
+	var defaults []string
+	var dest []interface{}
+{{- range defaulted $t "upsert" }}
+	if {{ unset (short $t) . }} {
+		defaults, dest = append(defaults, "{{ .SQLName }}"), append(dest, &{{ short $t }}.{{ .GoName }})
+	} else {
+		columns, args = append(columns, "{{ .SQLName }}"), append(args, {{ short $t }}.{{ .GoName }})
+{{- if db_updated . }}
+		// on conflict the database sets it instead
+		defaults, dest = append(defaults, "{{ .SQLName }}"), append(dest, &{{ short $t }}.{{ .GoName }})
+{{- else }}
+		update = append(update, "{{ .SQLName }} = VALUES({{ .SQLName }})")
+{{- end }}
+	}
+{{- end }}
+{{- range $t.Fields }}{{ if soft_deleted $t . }}
+	// a conflicting soft deleted row stays deleted, see [{{ $t.GoName }}.Restore]
+	defaults, dest = append(defaults, "{{ .SQLName }}"), append(dest, &{{ short $t }}.{{ .GoName }})
+{{- end }}{{ end }}
+{{- range $t.PrimaryKeys }}{{ if .IsSequence }}
+	// an unset {{ .SQLName }} is generated by the database, or on conflict with
+	// another unique key is that of the stored row, and read back either way
+	if {{ isset (short $t) . }} {
+		columns, args = append(columns, "{{ .SQLName }}"), append(args, {{ short $t }}.{{ .GoName }})
+	} else {
+		update = append(update, "{{ .SQLName }} = LAST_INSERT_ID({{ .SQLName }})")
+	}
+{{- end }}{{ end }}
+{{- if not (conflicting $t) }}
+	if len(update) == 0 { // keep the stored row
+		update = append(update, "{{ (index $t.PrimaryKeys 0).SQLName }} = {{ (index $t.PrimaryKeys 0).SQLName }}")
+	}
+{{- end }}
+	sqlstr := `INSERT INTO {{ $t.SQLName }} (` + strings.Join(columns, ", ") + `) ` +
+		`VALUES (` + bindvars(len(args)) + `) ` +
+		`ON DUPLICATE KEY UPDATE ` + strings.Join(update, ", ")
+	// run
+	logf(sqlstr, args...)
+{{- if $t.Manual }}
+	if _, err := db.ExecContext(ctx, sqlstr, args...); err != nil {
+		return logerror(err)
+	}
+{{- else }}{{ with index $t.PrimaryKeys 0 }}
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
+		return logerror(err)
+	}
+	if {{ unset (short $t) . }} {
+		// retrieve id
+		id, err := res.LastInsertId()
+		if err != nil {
+			return logerror(err)
+		}
+		{{ short $t }}.{{ .GoName }} = {{ .Type }}(id)
+	}
+{{- end }}{{ end }}
+	// read back the values the database assigned or kept
+	if len(defaults) != 0 {
+		sqlstr := `SELECT ` + strings.Join(defaults, ", ") + ` ` +
+			`FROM {{ $t.SQLName }} ` +
+			`WHERE {{ range $i, $k := $t.PrimaryKeys }}{{ if $i }} AND {{ end }}{{ $k.SQLName }} = ?{{ end }}`
+		logf(sqlstr, {{ names (print (short $t) ".") $t.PrimaryKeys }})
+		if err := db.QueryRowContext(ctx, sqlstr, {{ names (print (short $t) ".") $t.PrimaryKeys }}).Scan(dest...); err != nil {
+			return logerror(err)
+		}
+	}
+{{- range $t.Fields }}{{ if soft_deleted $t . }}
+	{{ short $t }}._deleted = {{ short $t }}.{{ .GoName }}.Valid
+{{- end }}{{ end }}
+{{- else }}
 	// upsert
 	{{ sqlstr "upsert" $t }}
 	// run
@@ -382,9 +699,18 @@ func ({{ short $t }} *{{ $t.GoName }}) Deleted() bool {
 	if _, err := {{ db_prefix "Exec" false $t }}; err != nil {
 		return logerror(err)
 	}
+{{- end }}
 	// set exists
 	{{ short $t }}._exists = true
+	// run the AfterInsert hook
+	if err := afterInsert(ctx, db, {{ short $t }}); err != nil {
+		return logerror(&ErrUpsertFailed{err})
+	}
+{{- if audited $t }}
+	return {{ short $t }}.audit(ctx, db, "upsert", before, {{ short $t }})
+{{- else }}
 	return nil
+{{- end }}
 }
 
 {{ if context_both -}}
@@ -395,6 +721,157 @@ func ({{ short $t }} *{{ $t.GoName }}) Deleted() bool {
 {{- end -}}
 {{- end }}
 
+{{ if soft_delete $t -}}
+// {{ func_name_context "Delete" }} soft deletes the [{{ $t.GoName }}], setting its {{ $softDelete.SQLName }} to
+// the current time. Soft deleted rows are left out of lookups, pages and counts
+// until restored with [{{ $t.GoName }}.Restore]. See [{{ $t.GoName }}.HardDelete].
+// It returns sql.ErrNoRows when no stored row was left to delete.
+{{ recv_context $t "Delete" }} {
+	switch {
+	case !{{ short $t }}._exists: // doesn't exist
+		return nil
+	case {{ short $t }}._deleted: // deleted
+		return nil
+	}
+	// run the BeforeDelete hook
+	if err := beforeDelete(ctx, db, {{ short $t }}); err != nil {
+		return logerror(err)
+	}
+{{- if audited $t }}
+	before, err := {{ short $t }}.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+{{- end }}
+	// soft delete with primary key
+	const sqlstr = `UPDATE {{ $t.SQLName }} SET {{ $softDelete.SQLName }} = ? ` +
+		`WHERE {{ range $i, $k := $t.PrimaryKeys }}{{ if $i }} AND {{ end }}{{ $k.SQLName }} = ?{{ end }} AND {{ $softDelete.SQLName }} IS NULL`
+	now := time.Now()
+	// run
+	logf(sqlstr, now, {{ names (print (short $t) ".") $t.PrimaryKeys }})
+	res, err := db.ExecContext(ctx, sqlstr, now, {{ names (print (short $t) ".") $t.PrimaryKeys }})
+	if err != nil {
+		return logerror(err)
+	}
+	// no match means the row is gone or already soft deleted
+	switch n, err := res.RowsAffected(); {
+	case err != nil:
+		return logerror(err)
+	case n == 0:
+		return logerror(sql.ErrNoRows)
+	}
+	// set deleted
+	{{ short $t }}.{{ $softDelete.GoName }} = sql.NullTime{Time: now, Valid: true}
+	{{ short $t }}._deleted = true
+	// run the AfterDelete hook
+	if err := afterDelete(ctx, db, {{ short $t }}); err != nil {
+		return logerror(err)
+	}
+{{- if audited $t }}
+	return {{ short $t }}.audit(ctx, db, "delete", before, {{ short $t }})
+{{- else }}
+	return nil
+{{- end }}
+}
+
+// HardDelete permanently deletes the [{{ $t.GoName }}] from the database, whether or not
+// it is soft deleted.
+func ({{ short $t }} *{{ $t.GoName }}) HardDelete(ctx context.Context, db DB) error {
+	if !{{ short $t }}._exists { // doesn't exist
+		return nil
+	}
+	// run the BeforeDelete hook
+	if err := beforeDelete(ctx, db, {{ short $t }}); err != nil {
+		return logerror(err)
+	}
+{{- if audited $t }}
+	before, err := {{ short $t }}.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+{{- end }}
+{{ if eq (len $t.PrimaryKeys) 1 -}}
+	// delete with single primary key
+	{{ sqlstr "delete" $t }}
+	// run
+	{{ logf_pkeys $t }}
+	if _, err := {{ db "Exec" (print (short $t) "." (index $t.PrimaryKeys 0).GoName) }}; err != nil {
+		return logerror(err)
+	}
+{{- else -}}
+	// delete with composite primary key
+	{{ sqlstr "delete" $t }}
+	// run
+	{{ logf_pkeys $t }}
+	if _, err := {{ db "Exec" (names (print (short $t) ".") $t.PrimaryKeys) }}; err != nil {
+		return logerror(err)
+	}
+{{- end }}
+	// set deleted
+	{{ short $t }}._deleted = true
+	// run the AfterDelete hook
+	if err := afterDelete(ctx, db, {{ short $t }}); err != nil {
+		return logerror(err)
+	}
+{{- if audited $t }}
+	return {{ short $t }}.audit(ctx, db, "delete", before, nil)
+{{- else }}
+	return nil
+{{- end }}
+}
+
+
+// Restore undeletes the soft deleted [{{ $t.GoName }}], clearing its {{ $softDelete.SQLName }}. Soft
+// deleted rows are retrieved with [{{ $t.GoName }}By{{ range $t.PrimaryKeys }}{{ .GoName }}{{ end }}WithDeleted].
+func ({{ short $t }} *{{ $t.GoName }}) Restore(ctx context.Context, db DB) error {
+	if !{{ short $t }}._exists { // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	}
+{{- if audited $t }}
+	before, err := {{ short $t }}.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+{{- end }}
+	// restore with primary key
+	const sqlstr = `UPDATE {{ $t.SQLName }} SET {{ $softDelete.SQLName }} = NULL ` +
+		`WHERE {{ range $i, $k := $t.PrimaryKeys }}{{ if $i }} AND {{ end }}{{ $k.SQLName }} = ?{{ end }}`
+	// run
+	logf(sqlstr, {{ names (print (short $t) ".") $t.PrimaryKeys }})
+	if _, err := db.ExecContext(ctx, sqlstr, {{ names (print (short $t) ".") $t.PrimaryKeys }}); err != nil {
+		return logerror(err)
+	}
+	// set restored
+	{{ short $t }}.{{ $softDelete.GoName }} = sql.NullTime{}
+	{{ short $t }}._deleted = false
+{{- if audited $t }}
+	return {{ short $t }}.audit(ctx, db, "restore", before, {{ short $t }})
+{{- else }}
+	return nil
+{{- end }}
+}
+
+// {{ $t.GoName }}By{{ range $t.PrimaryKeys }}{{ .GoName }}{{ end }}WithDeleted retrieves a row from '{{ schema $t.SQLName }}' as a [{{ $t.GoName }}]
+// by primary key, including a soft deleted row, which is marked as deleted.
+func {{ $t.GoName }}By{{ range $t.PrimaryKeys }}{{ .GoName }}{{ end }}WithDeleted(ctx context.Context, db DB, {{ params $t.PrimaryKeys true }}) (*{{ $t.GoName }}, error) {
+	// query
+	const sqlstr = `SELECT ` +
+		`{{ range $i, $f := $t.Fields }}{{ if $i }}, {{ end }}{{ $f.SQLName }}{{ end }} ` +
+		`FROM {{ $t.SQLName }} ` +
+		`WHERE {{ range $i, $k := $t.PrimaryKeys }}{{ if $i }} AND {{ end }}{{ $k.SQLName }} = ?{{ end }}`
+	// run
+	logf(sqlstr, {{ params $t.PrimaryKeys false }})
+	{{ short $t }} := {{ $t.GoName }}{
+		_exists: true,
+	}
+	if err := db.QueryRowContext(ctx, sqlstr, {{ params $t.PrimaryKeys false }}).Scan({{ names (print "&" (short $t) ".") $t }}); err != nil {
+		return nil, logerror(err)
+	}
+	{{ short $t }}._deleted = {{ short $t }}.{{ $softDelete.GoName }}.Valid
+	return &{{ short $t }}, nil
+}
+
+{{ else -}}
 // {{ func_name_context "Delete" }} deletes the [{{ $t.GoName }}] from the database.
 {{ recv_context $t "Delete" }} {
 	switch {
@@ -403,6 +880,16 @@ func ({{ short $t }} *{{ $t.GoName }}) Deleted() bool {
 	case {{ short $t }}._deleted: // deleted
 		return nil
 	}
+	// run the BeforeDelete hook
+	if err := beforeDelete(ctx, db, {{ short $t }}); err != nil {
+		return logerror(err)
+	}
+{{- if audited $t }}
+	before, err := {{ short $t }}.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+{{- end }}
 {{ if eq (len $t.PrimaryKeys) 1 -}}
 	// delete with single primary key
 	{{ sqlstr "delete" $t }}
@@ -422,7 +909,15 @@ func ({{ short $t }} *{{ $t.GoName }}) Deleted() bool {
 {{- end }}
 	// set deleted
 	{{ short $t }}._deleted = true
+	// run the AfterDelete hook
+	if err := afterDelete(ctx, db, {{ short $t }}); err != nil {
+		return logerror(err)
+	}
+{{- if audited $t }}
+	return {{ short $t }}.audit(ctx, db, "delete", before, nil)
+{{- else }}
 	return nil
+{{- end }}
 }
 
 {{ if context_both -}}
@@ -432,7 +927,193 @@ func ({{ short $t }} *{{ $t.GoName }}) Deleted() bool {
 }
 {{- end -}}
 {{- end }}
+{{- end }}
 {{- $t := .Data -}}
+{{- $softDelete := soft_delete_field $t -}}
+{{- $version := version_field $t -}}
+// {{ $t.GoName }}Column is a column name of '{{ schema $t.SQLName }}'.
+type {{ $t.GoName }}Column string
+
+// {{ $t.GoName }}Column values.
+const (
+{{ range $t.Fields -}}
+	// {{ $t.GoName }}Column{{ .GoName }} is the '{{ .SQLName }}' column.
+	{{ $t.GoName }}Column{{ .GoName }} {{ $t.GoName }}Column = "{{ .SQLName }}"
+{{ end -}}
+)
+
+// Valid returns true when the [{{ $t.GoName }}Column] is a column of '{{ schema $t.SQLName }}'.
+func (c {{ $t.GoName }}Column) Valid() bool {
+	switch c {
+	case {{ range $i, $f := $t.Fields }}{{ if $i }}, {{ end }}{{ $t.GoName }}Column{{ $f.GoName }}{{ end }}:
+		return true
+	}
+	return false
+}
+
+// Nullable returns true when the [{{ $t.GoName }}Column] is a column of
+// '{{ schema $t.SQLName }}' that may hold NULL.
+func (c {{ $t.GoName }}Column) Nullable() bool {
+{{- with nullables $t }}
+	switch c {
+	case {{ range $i, $f := . }}{{ if $i }}, {{ end }}{{ $t.GoName }}Column{{ $f.GoName }}{{ end }}:
+		return true
+	}
+{{- end }}
+	return false
+}
+
+// Parse{{ $t.GoName }}Column parses a client supplied column name, returning
+// [ErrUnknownColumn] when it is not a column of '{{ schema $t.SQLName }}'.
+func Parse{{ $t.GoName }}Column(s string) ({{ $t.GoName }}Column, error) {
+	if c := {{ $t.GoName }}Column(s); c.Valid() {
+		return c, nil
+	}
+	return "", &ErrUnknownColumn{Table: "{{ $t.SQLName }}", Column: s}
+}
+
+// ColumnValue returns the value of column for the [{{ $t.GoName }}], or nil when
+// column is not a valid [{{ $t.GoName }}Column].
+func ({{ short $t }} *{{ $t.GoName }}) ColumnValue(column {{ $t.GoName }}Column) interface{} {
+	switch column {
+{{ range $t.Fields -}}
+	case {{ $t.GoName }}Column{{ .GoName }}:
+		return {{ short $t }}.{{ .GoName }}
+{{ end -}}
+	}
+	return nil
+}
+
+{{ if and $t.PrimaryKeys (ne (len $t.Fields) (len $t.PrimaryKeys)) -}}
+// UpdateColumns updates only the listed columns of the [{{ $t.GoName }}] in the
+// database. Unlisted columns keep their stored values, so columns the database
+// maintains itself, such as ON UPDATE CURRENT_TIMESTAMP, are left to it. The
+// row is then reloaded so the [{{ $t.GoName }}] reflects the stored values.
+func ({{ short $t }} *{{ $t.GoName }}) UpdateColumns(ctx context.Context, db DB, columns ...{{ $t.GoName }}Column) error {
+	switch {
+	case !{{ short $t }}._exists: // doesn't exist
+		return logerror(&ErrUpdateFailed{ErrDoesNotExist})
+	case {{ short $t }}._deleted: // deleted
+		return logerror(&ErrUpdateFailed{ErrMarkedForDeletion})
+	}
+	// check the listed columns
+	var listed []{{ $t.GoName }}Column
+	seen := make(map[{{ $t.GoName }}Column]bool)
+	for _, c := range columns {
+		switch {
+		case !c.Valid():
+			return logerror(&ErrUpdateFailed{&ErrUnknownColumn{Table: "{{ $t.SQLName }}", Column: string(c)}})
+		case {{ range $i, $k := $t.PrimaryKeys }}{{ if $i }} || {{ end }}c == {{ $t.GoName }}Column{{ $k.GoName }}{{ end }}:
+			return logerror(&ErrUpdateFailed{ErrPrimaryKeyColumn})
+{{- if versioned $t }}
+		case c == {{ $t.GoName }}Column{{ $version.GoName }}:
+			return logerror(&ErrUpdateFailed{ErrVersionColumn})
+{{- end }}
+		case seen[c]:
+			continue
+		}
+		seen[c] = true
+		listed = append(listed, c)
+	}
+	if len(listed) == 0 {
+		return nil
+	}
+	// run the BeforeUpdate hook
+	if err := beforeUpdate(ctx, db, {{ short $t }}); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+	// build the set list from the values the hook left
+	var set []string
+	var args []interface{}
+	for _, c := range listed {
+		set = append(set, string(c)+" = ?")
+		args = append(args, {{ short $t }}.ColumnValue(c))
+	}
+{{- if audited $t }}
+	before, err := {{ short $t }}.auditBefore(ctx, db)
+	if err != nil {
+		return err
+	}
+{{- end }}
+{{- if versioned $t }}
+	set = append(set, "{{ $version.SQLName }} = {{ $version.SQLName }} + 1")
+	// update with primary key and version
+	sqlstr := `UPDATE {{ $t.SQLName }} SET ` + strings.Join(set, ", ") + ` ` +
+		`WHERE {{ range $i, $k := $t.PrimaryKeys }}{{ $k.SQLName }} = ? AND {{ end }}{{ $version.SQLName }} = ?`
+	args = append(args, {{ names (print (short $t) ".") $t.PrimaryKeys }}, {{ short $t }}.{{ $version.GoName }})
+	// run
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
+		return logerror(err)
+	}
+	// no match means the row changed since it was read
+	switch n, err := res.RowsAffected(); {
+	case err != nil:
+		return logerror(err)
+	case n == 0:
+		return logerror(&ErrUpdateFailed{ErrStaleVersion})
+	}
+{{- else }}
+	// update with primary key
+	sqlstr := `UPDATE {{ $t.SQLName }} SET ` + strings.Join(set, ", ") + ` ` +
+		`WHERE {{ range $i, $k := $t.PrimaryKeys }}{{ if $i }} AND {{ end }}{{ $k.SQLName }} = ?{{ end }}`
+	args = append(args, {{ names (print (short $t) ".") $t.PrimaryKeys }})
+	// run
+	logf(sqlstr, args...)
+	if _, err := db.ExecContext(ctx, sqlstr, args...); err != nil {
+		return logerror(err)
+	}
+{{- end }}
+	// reload
+	const selstr = `SELECT ` +
+		`{{ range $i, $f := $t.Fields }}{{ if $i }}, {{ end }}{{ $f.SQLName }}{{ end }} ` +
+		`FROM {{ $t.SQLName }} ` +
+		`WHERE {{ range $i, $k := $t.PrimaryKeys }}{{ if $i }} AND {{ end }}{{ $k.SQLName }} = ?{{ end }}`
+	logf(selstr, {{ names (print (short $t) ".") $t.PrimaryKeys }})
+	if err := db.QueryRowContext(ctx, selstr, {{ names (print (short $t) ".") $t.PrimaryKeys }}).Scan({{ names (print "&" (short $t) ".") $t }}); err != nil {
+		return logerror(err)
+	}
+	// run the AfterUpdate hook
+	if err := afterUpdate(ctx, db, {{ short $t }}); err != nil {
+		return logerror(&ErrUpdateFailed{err})
+	}
+{{- if audited $t }}
+	return {{ short $t }}.audit(ctx, db, "update", before, {{ short $t }})
+{{- else }}
+	return nil
+{{- end }}
+}
+
+{{ end -}}
+// decode{{ $t.GoName }}ColumnValue decodes a JSON encoded value of column.
+func decode{{ $t.GoName }}ColumnValue(column {{ $t.GoName }}Column, buf []byte) (interface{}, error) {
+	switch column {
+{{ range $t.Fields -}}
+	case {{ $t.GoName }}Column{{ .GoName }}:
+		var v {{ type .Type }}
+		err := json.Unmarshal(buf, &v)
+		return v, err
+{{ end -}}
+	}
+	return nil, &ErrUnknownColumn{Table: "{{ $t.SQLName }}", Column: string(column)}
+}
+
+// {{ $t.GoName }}Filters holds a typed predicate builder for each column of '{{ schema $t.SQLName }}'.
+type {{ $t.GoName }}Filters struct {
+{{ range $t.Fields -}}
+	{{ .GoName }} {{ filter_type $t . }}
+{{ end -}}
+}
+
+// {{ $t.GoName }}Filter builds predicates over '{{ schema $t.SQLName }}' for
+// [{{ $t.GoName }}KeysetPage], [{{ $t.GoName }}Count] and [{{ $t.GoName }}DeleteWhere].
+var {{ $t.GoName }}Filter = {{ $t.GoName }}Filters{
+{{ range $t.Fields -}}
+	{{ .GoName }}: {{ filter_init $t . }},
+{{ end -}}
+}
+
 // {{ $t.GoName }}KeysetPage retrieves a page of [{{ $t.GoName }}] records using keyset pagination with dynamic filtering.
 //
 // The keyset pagination retrieves results after or before a specific value (`key`)
@@ -441,64 +1122,53 @@ func ({{ short $t }} *{{ $t.GoName }}) Deleted() bool {
 // If `order` is `ASC`, it retrieves records where the value of `column` is greater than `key`.
 // If `order` is `DESC`, it retrieves records where the value of `column` is less than `key`.
 //
-// Filters are dynamically provided via a `filters` map, where keys are column names and values are either single values or slices for `IN` clauses.
-func {{ $t.GoName }}KeysetPage(ctx context.Context, db DB, column string, key interface{}, limit int, order string, filters map[string]interface{}) ([]*{{ $t.GoName }}, *{{ $t.GoName }}, error) {
+// Rows are further restricted by `where`, built from [{{ $t.GoName }}Filter]; the zero
+// [Predicate] applies no filter.
+//
+// `column` must be a valid [{{ $t.GoName }}Column], otherwise [ErrUnknownColumn] is
+// returned before any SQL is built. Nullable columns return [ErrNullableColumn].
+func {{ $t.GoName }}KeysetPage(ctx context.Context, db DB, column {{ $t.GoName }}Column, key interface{}, limit int, order string, where Predicate[{{ $t.GoName }}]) ([]*{{ $t.GoName }}, *{{ $t.GoName }}, error) {
     if order != "ASC" && order != "DESC" {
         return nil, nil, fmt.Errorf("invalid order: %s", order)
     }
 
+    // Only known columns may be interpolated into the query
+    if !column.Valid() {
+        return nil, nil, logerror(&ErrUnknownColumn{Table: "{{ $t.SQLName }}", Column: string(column)})
+    }
+    if column.Nullable() {
+        return nil, nil, logerror(ErrNullableColumn)
+    }
+
     // Start building the query
     query := fmt.Sprintf(
-        `SELECT * FROM {{ $t.SQLName }} 
-         WHERE %s %s ?`, 
+        `SELECT {{ range $i, $f := $t.Fields }}{{ if $i }}, {{ end }}{{ $f.SQLName }}{{ end }} `+
+        `FROM {{ $t.SQLName }} WHERE %s %s ?`,
         column, condition(order),
     )
 
+{{- if soft_delete $t }}
+
+    // Leave out soft deleted rows
+    query += " AND {{ $softDelete.SQLName }} IS NULL"
+{{- end }}
+
     // Arguments for the query
     args := []interface{}{key}
 
-    // Dynamically add filters from the `filters` map to the query
-    for field, value := range filters {
-        switch v := value.(type) {
-        case []int:
-            if len(v) > 0 {
-                placeholders := make([]string, len(v))
-                for i := range v {
-                    placeholders[i] = "?"
-                    args = append(args, v[i])
-                }
-                query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
-            }
-        case []string:
-            if len(v) > 0 {
-                placeholders := make([]string, len(v))
-                for i := range v {
-                    placeholders[i] = "?"
-                    args = append(args, v[i])
-                }
-                query += fmt.Sprintf(" AND %s IN (%s)", field, strings.Join(placeholders, ", "))
-            }
-        default:
-            // Handle NULL and NOT NULL checks
-            if value == nil {
-                query += fmt.Sprintf(" AND %s IS NULL", field)
-            } else if value == "NOT NULL" {
-                query += fmt.Sprintf(" AND %s IS NOT NULL", field)
-            } else {
-                query += fmt.Sprintf(" AND %s = ?", field)
-                args = append(args, value)
-            }
-        }
+    // Add the filter predicate
+    if !where.IsZero() {
+        cond, condArgs := where.SQL()
+        query += " AND (" + cond + ")"
+        args = append(args, condArgs...)
     }
 
     // Finalize the query with the order and limit
     query += fmt.Sprintf(" ORDER BY %s %s LIMIT ?", column, order)
     args = append(args, limit)
 
-    // Log the final query for debugging purposes
-    log.Printf("Executing query: %s with args: %v", query, args)
-
     // Execute the query
+    logf(query, args...)
     rows, err := db.QueryContext(ctx, query, args...)
     if err != nil {
         return nil, nil, logerror(err)
@@ -536,4 +1206,544 @@ func {{ $t.GoName }}KeysetPage(ctx context.Context, db DB, column string, key in
 
     return results, lastItem, nil
 }
+{{ if $t.PrimaryKeys }}
+// {{ $t.GoName }}Page is a page of [{{ $t.GoName }}] records returned by [{{ $t.GoName }}CursorPage].
+type {{ $t.GoName }}Page struct {
+	Items []*{{ $t.GoName }}
+	// NextCursor retrieves the following page. It is empty on the last page.
+	NextCursor string
+	// PrevCursor retrieves the preceding page. It is empty on the first page.
+	PrevCursor string
+}
+
+// {{ unexport $t.GoName }}KeysetColumns returns column followed by the primary key columns
+// of '{{ schema $t.SQLName }}' that break ties between equal values of column.
+func {{ unexport $t.GoName }}KeysetColumns(column {{ $t.GoName }}Column) []{{ $t.GoName }}Column {
+	columns := []{{ $t.GoName }}Column{column}
+{{- range $t.PrimaryKeys }}
+	if column != {{ $t.GoName }}Column{{ .GoName }} {
+		columns = append(columns, {{ $t.GoName }}Column{{ .GoName }})
+	}
+{{- end }}
+	return columns
+}
+
+// {{ $t.GoName }}CursorPage retrieves a page of [{{ $t.GoName }}] records ordered by
+// (`column`, primary key) in `order` (`ASC` or `DESC`), so that rows sharing a
+// value of `column` are neither skipped nor repeated between pages.
+//
+// An empty `cursor` retrieves the first page. Passing the returned NextCursor or
+// PrevCursor, together with the same `column` and `order`, retrieves the
+// following or preceding page. Cursors are opaque and return [ErrInvalidCursor]
+// when they are malformed or were issued for a different column or order.
+//
+// `column` must not be nullable, otherwise [ErrNullableColumn] is returned.
+// Rows are further restricted by `where`, built from [{{ $t.GoName }}Filter].
+func {{ $t.GoName }}CursorPage(ctx context.Context, db DB, column {{ $t.GoName }}Column, order, cursor string, limit int, where Predicate[{{ $t.GoName }}]) (*{{ $t.GoName }}Page, error) {
+	switch {
+	case order != "ASC" && order != "DESC":
+		return nil, fmt.Errorf("invalid order: %s", order)
+	case limit <= 0:
+		return nil, fmt.Errorf("invalid limit: %d", limit)
+	case !column.Valid():
+		return nil, logerror(&ErrUnknownColumn{Table: "{{ $t.SQLName }}", Column: string(column)})
+	case column.Nullable():
+		return nil, logerror(ErrNullableColumn)
+	}
+	columns := {{ unexport $t.GoName }}KeysetColumns(column)
+	// decode the boundary row
+	var backward bool
+	var conds []string
+	var args []interface{}
+	if cursor != "" {
+		c, err := decodeCursor(cursor, string(column), order)
+		if err != nil || len(c.Values) != len(columns) {
+			return nil, logerror(ErrInvalidCursor)
+		}
+		for i, col := range columns {
+			v, err := decode{{ $t.GoName }}ColumnValue(col, c.Values[i])
+			if err != nil {
+				return nil, logerror(ErrInvalidCursor)
+			}
+			args = append(args, v)
+		}
+		backward = c.Backward
+	}
+	// paging backward walks the index in reverse
+	dir := order
+	if backward {
+		dir = reverse(order)
+	}
+	if cursor != "" {
+		conds = append(conds, keysetCondition(columns, dir))
+	}
+{{- if soft_delete $t }}
+	// leave out soft deleted rows
+	conds = append(conds, "{{ $softDelete.SQLName }} IS NULL")
+{{- end }}
+	if !where.IsZero() {
+		cond, condArgs := where.SQL()
+		conds = append(conds, "("+cond+")")
+		args = append(args, condArgs...)
+	}
+	// query
+	sqlstr := `SELECT ` +
+		`{{ range $i, $f := $t.Fields }}{{ if $i }}, {{ end }}{{ $f.SQLName }}{{ end }} ` +
+		`FROM {{ $t.SQLName }}`
+	if len(conds) != 0 {
+		sqlstr += ` WHERE ` + strings.Join(conds, " AND ")
+	}
+	sqlstr += ` ORDER BY ` + keysetOrderBy(columns, dir) + ` LIMIT ?`
+	// fetch one extra row to learn whether another page follows
+	args = append(args, limit+1)
+	// run
+	logf(sqlstr, args...)
+	rows, err := db.QueryContext(ctx, sqlstr, args...)
+	if err != nil {
+		return nil, logerror(err)
+	}
+	defer rows.Close()
+	// process
+	var res []*{{ $t.GoName }}
+	for rows.Next() {
+		{{ short $t }} := {{ $t.GoName }}{
+			_exists: true,
+		}
+		// scan
+		if err := rows.Scan({{ names (print "&" (short $t) ".") $t }}); err != nil {
+			return nil, logerror(err)
+		}
+		res = append(res, &{{ short $t }})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logerror(err)
+	}
+	more := len(res) > limit
+	if more {
+		res = res[:limit]
+	}
+	if backward {
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+	page := &{{ $t.GoName }}Page{Items: res}
+	if len(res) == 0 {
+		return page, nil
+	}
+	// a backward page always has rows after it, and a forward page from a
+	// cursor always has rows before it
+	hasNext, hasPrev := more, cursor != ""
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+	if hasNext {
+		if page.NextCursor, err = {{ unexport $t.GoName }}Cursor(res[len(res)-1], column, order, false); err != nil {
+			return nil, logerror(err)
+		}
+	}
+	if hasPrev {
+		if page.PrevCursor, err = {{ unexport $t.GoName }}Cursor(res[0], column, order, true); err != nil {
+			return nil, logerror(err)
+		}
+	}
+	return page, nil
+}
+
+// {{ unexport $t.GoName }}Cursor encodes the position of {{ short $t }} as a cursor for [{{ $t.GoName }}CursorPage].
+func {{ unexport $t.GoName }}Cursor({{ short $t }} *{{ $t.GoName }}, column {{ $t.GoName }}Column, order string, backward bool) (string, error) {
+	var values []interface{}
+	for _, col := range {{ unexport $t.GoName }}KeysetColumns(column) {
+		values = append(values, {{ short $t }}.ColumnValue(col))
+	}
+	return encodeCursor(string(column), order, backward, values)
+}
+{{ end }}
+// {{ $t.GoName }}Count returns the number of [{{ $t.GoName }}] records matching `where`.
+func {{ $t.GoName }}Count(ctx context.Context, db DB, where Predicate[{{ $t.GoName }}]) (int64, error) {
+	cond, args := where.SQL()
+{{- if soft_delete $t }}
+	// leave out soft deleted rows
+	sqlstr := `SELECT COUNT(*) FROM {{ $t.SQLName }} WHERE (` + cond + `) AND {{ $softDelete.SQLName }} IS NULL`
+{{- else }}
+	sqlstr := `SELECT COUNT(*) FROM {{ $t.SQLName }} WHERE ` + cond
+{{- end }}
+	// run
+	logf(sqlstr, args...)
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlstr, args...).Scan(&count); err != nil {
+		return 0, logerror(err)
+	}
+	return count, nil
+}
+
+// {{ $t.GoName }}DeleteWhere deletes the [{{ $t.GoName }}] records matching `where`,
+// returning the number of records deleted. The zero [Predicate] is rejected with
+// [ErrEmptyPredicate] rather than deleting every record.
+{{- if soft_delete $t }}
+//
+// Records are soft deleted, as with [{{ $t.GoName }}.Delete].
+{{- end }}
+func {{ $t.GoName }}DeleteWhere(ctx context.Context, db DB, where Predicate[{{ $t.GoName }}]) (int64, error) {
+	if where.IsZero() {
+		return 0, logerror(ErrEmptyPredicate)
+	}
+	cond, args := where.SQL()
+{{- if $t.PrimaryKeys }}
+	// with {{ if audited $t }}an audit hook or {{ end }}delete hooks the matching rows are locked and
+	// deleted one by one, so that each deletion is {{ if audited $t }}audited and {{ end }}hooked
+	if {{ if audited $t }}auditHook != nil || {{ end }}hasDeleteHooks(&{{ $t.GoName }}{}) {
+		sqlstr := `SELECT ` +
+			`{{ range $i, $f := $t.Fields }}{{ if $i }}, {{ end }}{{ $f.SQLName }}{{ end }} ` +
+			`FROM {{ $t.SQLName }} WHERE (` + cond + `){{ if soft_delete $t }} AND {{ $softDelete.SQLName }} IS NULL{{ end }} FOR UPDATE`
+		logf(sqlstr, args...)
+		rows, err := db.QueryContext(ctx, sqlstr, args...)
+		if err != nil {
+			return 0, logerror(err)
+		}
+		var matched []*{{ $t.GoName }}
+		for rows.Next() {
+			{{ short $t }} := {{ $t.GoName }}{
+				_exists: true,
+			}
+			if err := rows.Scan({{ names (print "&" (short $t) ".") $t }}); err != nil {
+				rows.Close()
+				return 0, logerror(err)
+			}
+			matched = append(matched, &{{ short $t }})
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return 0, logerror(err)
+		}
+		for _, {{ short $t }} := range matched {
+			if err := {{ short $t }}.Delete(ctx, db); err != nil {
+				return 0, err
+			}
+		}
+		return int64(len(matched)), nil
+	}
+{{- end }}
+{{- if soft_delete $t }}
+	// soft delete
+	sqlstr := `UPDATE {{ $t.SQLName }} SET {{ $softDelete.SQLName }} = ? WHERE (` + cond + `) AND {{ $softDelete.SQLName }} IS NULL`
+	args = append([]interface{}{time.Now()}, args...)
+{{- else }}
+	sqlstr := `DELETE FROM {{ $t.SQLName }} WHERE ` + cond
+{{- end }}
+	// run
+	logf(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
+	if err != nil {
+		return 0, logerror(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, logerror(err)
+	}
+	return n, nil
+}
+{{- if $t.PrimaryKeys }}
+{{- $p := plural $t.GoName }}
+
+// batchColumns returns the columns and values of the [{{ $t.GoName }}] in a
+// multi-row insert or upsert. As with [{{ $t.GoName }}.Insert], unset columns with a
+// database default are left out.{{ range $t.Fields }}{{ if .IsSequence }} Upserts also bind the generated primary key.{{ end }}{{ end }}
+func ({{ short $t }} *{{ $t.GoName }}) batchColumns(upsert bool) ([]string, []interface{}) {
+{{- range $t.Fields }}{{ if .IsSequence }}
+	var columns []string
+	var args []interface{}
+	if upsert {
+		columns, args = append(columns, "{{ .SQLName }}"), append(args, {{ short $t }}.{{ .GoName }})
+	}
+	columns = append(columns, {{ range $i, $z := bound $t "insert" }}{{ if $i }}, {{ end }}"{{ $z.SQLName }}"{{ end }})
+	args = append(args, {{ range $i, $z := bound $t "insert" }}{{ if $i }}, {{ end }}{{ short $t }}.{{ $z.GoName }}{{ end }})
+{{- end }}{{ end }}
+{{- if $t.Manual }}
+	columns := []string{ {{- range $i, $z := bound $t "insert" }}{{ if $i }}, {{ end }}"{{ $z.SQLName }}"{{ end -}} }
+	args := []interface{}{ {{- range $i, $z := bound $t "insert" }}{{ if $i }}, {{ end }}{{ short $t }}.{{ $z.GoName }}{{ end -}} }
+{{- end }}
+{{- range defaulted $t "insert" }}
+	if {{ isset (short $t) . }} {
+		columns, args = append(columns, "{{ .SQLName }}"), append(args, {{ short $t }}.{{ .GoName }})
+	}
+{{- end }}
+	return columns, args
+}
+{{- if defaulted $t "insert" }}
+
+// readBack{{ $p }} reads the values of the columns with a database default
+// back into rows, after a multi-row insert or upsert left some to the database.
+{{- if soft_delete $t }}
+// The soft delete column is read back too, as upserts keep that of a
+// conflicting row.
+{{- end }}
+func readBack{{ $p }}(ctx context.Context, db DB, rows []*{{ $t.GoName }}) error {
+	type key struct {
+{{- range $t.PrimaryKeys }}
+		{{ .GoName }} {{ .Type }}
+{{- end }}
+	}
+	byKey := make(map[key]*{{ $t.GoName }}, len(rows))
+	var args []interface{}
+	for _, {{ short $t }} := range rows {
+		byKey[key{ {{- names (print (short $t) ".") $t.PrimaryKeys -}} }] = {{ short $t }}
+		args = append(args, {{ names (print (short $t) ".") $t.PrimaryKeys }})
+	}
+	sqlstr := `SELECT ` +
+		`{{ range $i, $k := $t.PrimaryKeys }}{{ $k.SQLName }}, {{ end }}{{ range $i, $z := read_back $t }}{{ if $i }}, {{ end }}{{ $z.SQLName }}{{ end }} ` +
+		`FROM {{ $t.SQLName }} ` +
+		`WHERE ({{ range $i, $k := $t.PrimaryKeys }}{{ if $i }}, {{ end }}{{ $k.SQLName }}{{ end }}) IN (` + batchValues({{ len $t.PrimaryKeys }}, len(rows)) + `)`
+	// run
+	logf(sqlstr, args...)
+	res, err := db.QueryContext(ctx, sqlstr, args...)
+	if err != nil {
+		return logerror(err)
+	}
+	defer res.Close()
+	for res.Next() {
+		var stored {{ $t.GoName }}
+		if err := res.Scan({{ names "&stored." $t.PrimaryKeys }}, {{ names "&stored." (read_back $t) }}); err != nil {
+			return logerror(err)
+		}
+		if {{ short $t }} := byKey[key{ {{- names "stored." $t.PrimaryKeys -}} }]; {{ short $t }} != nil {
+			{{ names (print (short $t) ".") (read_back $t) }} = {{ names "stored." (read_back $t) }}
+		}
+	}
+	if err := res.Err(); err != nil {
+		return logerror(err)
+	}
+	return nil
+}
+{{- end }}
+
+// Insert{{ $p }} inserts the [{{ $t.GoName }}] rows to the database with multi-row
+// statements, split under the limits of [SetBatchLimits]. As with
+// [{{ $t.GoName }}.Insert]{{ if not $t.Manual }}, the primary keys the database generates are set on
+// the rows{{ end }}, unset columns with a database default are read back, and the
+// hooks{{ if audited $t }} and the audit hook{{ end }} run for every row.
+func Insert{{ $p }}(ctx context.Context, db DB, rows []*{{ $t.GoName }}) error {
+	for _, {{ short $t }} := range rows {
+		switch {
+		case {{ short $t }}._exists: // already exists
+			return logerror(&ErrInsertFailed{ErrAlreadyExists})
+		case {{ short $t }}._deleted: // deleted
+			return logerror(&ErrInsertFailed{ErrMarkedForDeletion})
+		}
+		// run the BeforeInsert hook
+		if err := beforeInsert(ctx, db, {{ short $t }}); err != nil {
+			return logerror(&ErrInsertFailed{err})
+		}
+	}
+	for _, b := range batches(rows, func({{ short $t }} *{{ $t.GoName }}) ([]string, []interface{}) {
+		return {{ short $t }}.batchColumns(false)
+	}) {
+		// insert
+		sqlstr := `INSERT INTO {{ $t.SQLName }} (` + strings.Join(b.columns, ", ") + `) ` +
+			`VALUES ` + batchValues(len(b.columns), len(b.rows))
+		// run
+		logf(sqlstr, b.args...)
+{{- if $t.Manual }}
+		if _, err := db.ExecContext(ctx, sqlstr, b.args...); err != nil {
+			return logerror(err)
+		}
+{{- else }}
+		res, err := db.ExecContext(ctx, sqlstr, b.args...)
+		if err != nil {
+			return logerror(err)
+		}
+		// retrieve ids, which MySQL generates consecutively for the rows of a
+		// multi-row insert, starting with the returned one, as long as
+		// auto_increment_increment is left at 1
+		id, err := res.LastInsertId()
+		if err != nil {
+			return logerror(err)
+		}
+		for i, {{ short $t }} := range b.rows {
+			{{ short $t }}.{{ (index $t.PrimaryKeys 0).GoName }} = {{ (index $t.PrimaryKeys 0).Type }}(id + int64(i))
+		}
+{{- end }}
+{{- if defaulted $t "insert" }}
+		// read back the values the database assigned
+		if len(b.columns) < {{ len (written $t "insert") }} {
+			if err := readBack{{ $p }}(ctx, db, b.rows); err != nil {
+				return err
+			}
+		}
+{{- end }}
+	}
+	for _, {{ short $t }} := range rows {
+		// set exists
+		{{ short $t }}._exists = true
+		// run the AfterInsert hook
+		if err := afterInsert(ctx, db, {{ short $t }}); err != nil {
+			return logerror(&ErrInsertFailed{err})
+		}
+{{- if audited $t }}
+		if err := {{ short $t }}.audit(ctx, db, "insert", nil, {{ short $t }}); err != nil {
+			return err
+		}
+{{- end }}
+	}
+	return nil
+}
+
+// Upsert{{ $p }} performs an upsert of the [{{ $t.GoName }}] rows with multi-row
+// statements, split under the limits of [SetBatchLimits]. As with
+// [{{ $t.GoName }}.Upsert], unset columns with a database default are left to the
+// database or, on conflict, keep their stored values, and are read back.
+{{- if not $t.Manual }} The
+// rows must carry their primary keys, as MySQL reports the generated key of
+// only one row of a multi-row upsert; insert new rows with [Insert{{ $p }}].
+{{- end }}
+{{- if audited $t }} With
+// an audit hook set, the stored rows are loaded one by one for their audit
+// entries.
+{{- end }}
+func Upsert{{ $p }}(ctx context.Context, db DB, rows []*{{ $t.GoName }}) error {
+{{- if audited $t }}
+	befores := make([]*{{ $t.GoName }}, len(rows))
+{{- end }}
+	for {{ if audited $t }}i{{ else }}_{{ end }}, {{ short $t }} := range rows {
+		switch {
+		case {{ short $t }}._deleted: // deleted
+			return logerror(&ErrUpsertFailed{ErrMarkedForDeletion})
+{{- if not $t.Manual }}
+		case {{ unset (short $t) (index $t.PrimaryKeys 0) }}: // primary key unset
+			return logerror(&ErrUpsertFailed{ErrPrimaryKeyUnset})
+{{- end }}
+		}
+		// run the BeforeInsert hook
+		if err := beforeInsert(ctx, db, {{ short $t }}); err != nil {
+			return logerror(&ErrUpsertFailed{err})
+		}
+{{- if audited $t }}
+		before, err := {{ short $t }}.auditBefore(ctx, db)
+		if err != nil {
+			return err
+		}
+		befores[i] = before
+{{- end }}
+	}
+	for _, b := range batches(rows, func({{ short $t }} *{{ $t.GoName }}) ([]string, []interface{}) {
+		return {{ short $t }}.batchColumns(true)
+	}) {
+		// upsert, updating the columns other than the primary key on conflict
+		var update []string
+		for _, c := range b.columns {
+			switch c {
+			case {{ range $i, $k := $t.PrimaryKeys }}{{ if $i }}, {{ end }}"{{ $k.SQLName }}"{{ end }}:
+{{- range $t.Fields }}{{ if db_updated . }}
+			case "{{ .SQLName }}": // on conflict the database sets it instead
+{{- end }}{{ end }}
+{{- range $t.Fields }}{{ if soft_deleted $t . }}
+			case "{{ .SQLName }}": // a conflicting soft deleted row stays deleted
+{{- end }}{{ end }}
+			default:
+				update = append(update, c+" = VALUES("+c+")")
+			}
+		}
+{{- if not (conflicting $t) }}
+		if len(update) == 0 { // keep the stored rows
+			update = append(update, "{{ (index $t.PrimaryKeys 0).SQLName }} = {{ (index $t.PrimaryKeys 0).SQLName }}")
+		}
+{{- end }}
+		sqlstr := `INSERT INTO {{ $t.SQLName }} (` + strings.Join(b.columns, ", ") + `) ` +
+			`VALUES ` + batchValues(len(b.columns), len(b.rows)) + ` ` +
+			`ON DUPLICATE KEY UPDATE ` + strings.Join(update, ", ")
+		// run
+		logf(sqlstr, b.args...)
+		if _, err := db.ExecContext(ctx, sqlstr, b.args...); err != nil {
+			return logerror(err)
+		}
+{{- if defaulted $t "upsert" }}
+		// read back the values the database assigned or kept
+{{- $always := soft_delete $t }}{{ range $t.Fields }}{{ if db_updated . }}{{ $always = true }}{{ end }}{{ end }}
+{{- if $always }}
+		if err := readBack{{ $p }}(ctx, db, b.rows); err != nil {
+			return err
+		}
+{{- else }}
+		if len(b.columns) < {{ len (written $t "upsert") }} {
+			if err := readBack{{ $p }}(ctx, db, b.rows); err != nil {
+				return err
+			}
+		}
+{{- end }}
+{{- end }}
+	}
+	for {{ if audited $t }}i{{ else }}_{{ end }}, {{ short $t }} := range rows {
+		// set exists
+		{{ short $t }}._exists = true
+{{- range $t.Fields }}{{ if soft_deleted $t . }}
+		{{ short $t }}._deleted = {{ short $t }}.{{ .GoName }}.Valid
+{{- end }}{{ end }}
+		// run the AfterInsert hook
+		if err := afterInsert(ctx, db, {{ short $t }}); err != nil {
+			return logerror(&ErrUpsertFailed{err})
+		}
+{{- if audited $t }}
+		if err := {{ short $t }}.audit(ctx, db, "upsert", befores[i], {{ short $t }}); err != nil {
+			return err
+		}
+{{- end }}
+	}
+	return nil
+}
+{{- if eq (len $t.PrimaryKeys) 1 }}
+{{- $k := index $t.PrimaryKeys 0 }}
+
+// Delete{{ $p }}ByIDs deletes the [{{ $t.GoName }}] records with the primary keys
+// ids, returning the number of records deleted. The ids are split into
+// statements under the limits of [SetBatchLimits], each deleting as
+// [{{ $t.GoName }}DeleteWhere] does.
+func Delete{{ $p }}ByIDs(ctx context.Context, db DB, ids []{{ $k.Type }}) (int64, error) {
+	var n int64
+{{- if soft_delete $t }}
+	// leave a placeholder for the {{ $softDelete.SQLName }} of the soft delete
+	for _, chunk := range chunks(ids, batchPlaceholders-1) {
+{{- else }}
+	for _, chunk := range chunks(ids, batchPlaceholders) {
+{{- end }}
+		deleted, err := {{ $t.GoName }}DeleteWhere(ctx, db, {{ $t.GoName }}Filter.{{ $k.GoName }}.In(chunk...))
+		n += deleted
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+{{- else }}
+
+// Delete{{ $p }} deletes the [{{ $t.GoName }}] records with the primary keys of
+// rows, returning the number of records deleted. The rows are split into
+// statements under the limits of [SetBatchLimits], each deleting as
+// [{{ $t.GoName }}DeleteWhere] does.
+func Delete{{ $p }}(ctx context.Context, db DB, rows []*{{ $t.GoName }}) (int64, error) {
+	var n int64
+{{- if soft_delete $t }}
+	// leave a placeholder for the {{ $softDelete.SQLName }} of the soft delete
+	for _, chunk := range chunks(rows, (batchPlaceholders-1)/{{ len $t.PrimaryKeys }}) {
+{{- else }}
+	for _, chunk := range chunks(rows, batchPlaceholders/{{ len $t.PrimaryKeys }}) {
+{{- end }}
+		var args []interface{}
+		for _, {{ short $t }} := range chunk {
+			args = append(args, {{ names (print (short $t) ".") $t.PrimaryKeys }})
+		}
+		where := Predicate[{{ $t.GoName }}]{
+			sql:  `({{ range $i, $k := $t.PrimaryKeys }}{{ if $i }}, {{ end }}{{ $k.SQLName }}{{ end }}) IN (` + batchValues({{ len $t.PrimaryKeys }}, len(chunk)) + `)`,
+			args: args,
+		}
+		deleted, err := {{ $t.GoName }}DeleteWhere(ctx, db, where)
+		n += deleted
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+{{- end }}
+{{- end }}
+
 {{ end }}